@@ -18,6 +18,7 @@ import (
 	"warehouse-service/internal/repository"
 	"warehouse-service/pkg/log"
 	"warehouse-service/pkg/server"
+	"warehouse-service/pkg/worker"
 )
 
 const (
@@ -50,6 +51,24 @@ func Run() {
 		warehouse.WithStoreCache(repositories.Store),
 		warehouse.WithInventoryRepository(repositories.Inventory),
 		warehouse.WithInventoryCache(repositories.Inventory),
+		warehouse.WithReservationRepository(repositories.Reservation),
+		warehouse.WithMovementRepository(repositories.Movement),
+		warehouse.WithAlertRepository(repositories.Alert),
+		warehouse.WithTransferRepository(repositories.Transfer),
+		warehouse.WithCountRepository(repositories.Count),
+		warehouse.WithLotRepository(repositories.Lot),
+		warehouse.WithSerialRepository(repositories.Serial),
+		warehouse.WithLocationRepository(repositories.Location),
+		warehouse.WithUnitRepository(repositories.Unit),
+		warehouse.WithPriceRepository(repositories.Price),
+		warehouse.WithCityRepository(repositories.City),
+		warehouse.WithCountryRepository(repositories.Country),
+		warehouse.WithCurrencyRepository(repositories.Currency),
+		warehouse.WithScheduleRepository(repositories.Schedule),
+		warehouse.WithDeliveryRepository(repositories.Delivery),
+		warehouse.WithCalendarRepository(repositories.Calendar),
+		warehouse.WithSlotRepository(repositories.Slot),
+		warehouse.WithRateRepository(repositories.Rate),
 	)
 
 	if err != nil {
@@ -81,6 +100,74 @@ func Run() {
 		return
 	}
 
+	// Run background jobs until the application is stopped
+	jobs, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	go worker.Every(jobs, configs.WORKER.Interval, func(ctx context.Context) {
+		if _, err := warehouseService.ExpireReservations(ctx); err != nil {
+			logger.Error("ERR_EXPIRE_RESERVATIONS", zap.Error(err))
+		}
+	})
+
+	// the first run checks every inventory ever moved, the later ones look one interval
+	// further back than the previous run to catch movements committed late
+	var alertsSince time.Time
+	go worker.Every(jobs, configs.WORKER.Interval, func(ctx context.Context) {
+		started := time.Now()
+		if _, err := warehouseService.EvaluateAlerts(ctx, alertsSince); err != nil {
+			logger.Error("ERR_EVALUATE_ALERTS", zap.Error(err))
+			return
+		}
+		alertsSince = started.Add(-configs.WORKER.Interval)
+	})
+
+	// prices are flipped at their boundaries, looking back one interval like the alerts
+	var pricesSince time.Time
+	go worker.Every(jobs, configs.WORKER.Interval, func(ctx context.Context) {
+		started := time.Now()
+		if _, err := warehouseService.ApplyPrices(ctx, pricesSince); err != nil {
+			logger.Error("ERR_APPLY_PRICES", zap.Error(err))
+			return
+		}
+		pricesSince = started.Add(-configs.WORKER.Interval)
+	})
+
+	// the nearby search is indexed at start and again each interval for the stores changed elsewhere
+	indexStores := func(ctx context.Context) {
+		if err := warehouseService.IndexStores(ctx); err != nil {
+			logger.Error("ERR_INDEX_STORES", zap.Error(err))
+		}
+	}
+
+	indexStores(jobs)
+	go worker.Every(jobs, configs.WORKER.Interval, indexStores)
+
+	// the rates file is loaded at start and again whenever it is replaced
+	if configs.RATES.File != "" {
+		var ratesLoaded time.Time
+		loadRates := func(ctx context.Context) {
+			info, err := os.Stat(configs.RATES.File)
+			if err != nil {
+				logger.Error("ERR_LOAD_RATES", zap.Error(err))
+				return
+			}
+
+			if !info.ModTime().After(ratesLoaded) {
+				return
+			}
+
+			if _, err = warehouseService.LoadRates(ctx, configs.RATES.File); err != nil {
+				logger.Error("ERR_LOAD_RATES", zap.Error(err))
+				return
+			}
+			ratesLoaded = info.ModTime()
+		}
+
+		loadRates(jobs)
+		go worker.Every(jobs, configs.WORKER.Interval, loadRates)
+	}
+
 	// Graceful Shutdown
 	var wait time.Duration
 	flag.DurationVar(&wait, "graceful-timeout", time.Second*15, "the duration for which the httpServer gracefully wait for existing connections to finish - e.g. 15s or 1m")
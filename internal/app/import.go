@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"os"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"warehouse-service/internal/config"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/repository"
+	"warehouse-service/internal/service/warehouse"
+	"warehouse-service/pkg/log"
+)
+
+// Import loads a CSV file of inventories into the database, prints the report
+// and returns the exit code of the command.
+//
+//	warehouse-service import -file stock.csv -reason receipt -actor nightly-sync
+func Import(args []string) int {
+	logger := log.New(version, description)
+
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	file := flags.String("file", "-", "path to the CSV file, - reads the standard input")
+	reason := flags.String("reason", movement.ReasonAdjustment, "reason of the stock movements")
+	actor := flags.String("actor", "cli", "actor of the stock movements")
+	flags.Parse(args)
+
+	if !movement.IsReason(*reason) {
+		logger.Error("ERR_INIT_FLAGS", zap.Error(errors.New("reason: unknown reason "+*reason)))
+		return 2
+	}
+
+	configs, err := config.New()
+	if err != nil {
+		logger.Error("ERR_INIT_CONFIG", zap.Error(err))
+		return 1
+	}
+
+	repositories, err := repository.New(
+		repository.WithPostgresStore(schema, configs.POSTGRES.DSN))
+	if err != nil {
+		logger.Error("ERR_INIT_REPOSITORY", zap.Error(err))
+		return 1
+	}
+	defer repositories.Close()
+
+	warehouseService, err := warehouse.New(
+		warehouse.WithStoreRepository(repositories.Store),
+		warehouse.WithInventoryRepository(repositories.Inventory),
+		warehouse.WithMovementRepository(repositories.Movement),
+		warehouse.WithAlertRepository(repositories.Alert),
+		warehouse.WithPriceRepository(repositories.Price),
+	)
+	if err != nil {
+		logger.Error("ERR_INIT_WAREHOUSE_SERVICE", zap.Error(err))
+		return 1
+	}
+
+	var src io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			logger.Error("ERR_OPEN_FILE", zap.Error(err))
+			return 1
+		}
+		defer f.Close()
+		src = f
+	}
+
+	req := movement.Request{
+		Reason:        *reason,
+		Actor:         *actor,
+		CorrelationID: uuid.New().String(),
+	}
+
+	res, err := warehouseService.ImportInventory(context.Background(), src, req)
+	if err != nil {
+		logger.Error("ERR_IMPORT_INVENTORY", zap.Error(err))
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(res)
+
+	return 0
+}
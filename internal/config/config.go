@@ -17,12 +17,16 @@ const (
 	defaultHTTPWriteTimeout       = 15 * time.Second
 	defaultHTTPIdleTimeout        = 60 * time.Second
 	defaultHTTPMaxHeaderMegabytes = 1
+
+	defaultWorkerInterval = time.Minute
 )
 
 type (
 	Configs struct {
 		HTTP     HTTPConfig
 		POSTGRES DatabaseConfig
+		WORKER   WorkerConfig
+		RATES    RatesConfig
 	}
 
 	HTTPConfig struct {
@@ -44,6 +48,15 @@ type (
 	DatabaseConfig struct {
 		DSN string
 	}
+
+	WorkerConfig struct {
+		Interval time.Duration
+	}
+
+	// RatesConfig points to the exchange rates file, no rates are loaded when it is blank.
+	RatesConfig struct {
+		File string
+	}
 )
 
 // New populates Configs struct with values from config file
@@ -75,5 +88,19 @@ func New() (cfg Configs, err error) {
 		return
 	}
 
+	cfg.WORKER = WorkerConfig{
+		Interval: defaultWorkerInterval,
+	}
+
+	err = envconfig.Process("WORKER", &cfg.WORKER)
+	if err != nil {
+		return
+	}
+
+	err = envconfig.Process("RATES", &cfg.RATES)
+	if err != nil {
+		return
+	}
+
 	return
 }
@@ -0,0 +1,44 @@
+package alert
+
+import (
+	"time"
+)
+
+type Response struct {
+	ID          string     `json:"id"`
+	InventoryID string     `json:"inventory_id"`
+	StoreID     string     `json:"store_id"`
+	ProductID   string     `json:"product_id"`
+	Kind        string     `json:"kind"`
+	State       string     `json:"state"`
+	Quantity    int        `json:"quantity"`
+	Threshold   int        `json:"threshold"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ResolvedAt  *time.Time `json:"resolved_at"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:          data.ID,
+		InventoryID: data.InventoryID,
+		StoreID:     data.StoreID,
+		ProductID:   data.ProductID,
+		Kind:        data.Kind,
+		State:       data.State,
+		Quantity:    data.Quantity,
+		Threshold:   data.Threshold,
+		CreatedAt:   data.CreatedAt,
+		UpdatedAt:   data.UpdatedAt,
+		ResolvedAt:  data.ResolvedAt,
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
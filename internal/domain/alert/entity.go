@@ -0,0 +1,35 @@
+package alert
+
+import "time"
+
+const (
+	KindLowStock  = "low_stock"
+	KindOverstock = "overstock"
+)
+
+const (
+	StateOpen         = "open"
+	StateAcknowledged = "acknowledged"
+	StateResolved     = "resolved"
+)
+
+type Entity struct {
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+	ResolvedAt  *time.Time `db:"resolved_at"`
+	ID          string     `db:"id"`
+	InventoryID string     `db:"inventory_id"`
+	StoreID     string     `db:"store_id"`
+	ProductID   string     `db:"product_id"`
+	Kind        string     `db:"kind"`
+	State       string     `db:"state"`
+	Quantity    int        `db:"quantity"`
+	Threshold   int        `db:"threshold"`
+}
+
+// Filter narrows the listing down, empty fields match everything.
+type Filter struct {
+	State   string
+	Kind    string
+	StoreID string
+}
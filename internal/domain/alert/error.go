@@ -0,0 +1,7 @@
+package alert
+
+import (
+	"errors"
+)
+
+var ErrorNotOpen = errors.New("alert: is not open")
@@ -0,0 +1,18 @@
+package alert
+
+import (
+	"context"
+	"time"
+)
+
+// Repository keeps at most one unresolved alert per inventory and kind.
+// Raise refreshes that alert instead of adding another one, and brings back
+// an alert resolved after reopenSince, so an item bouncing around its
+// threshold keeps a single alert. Resolve closes the unresolved alert, if any.
+type Repository interface {
+	Select(ctx context.Context, filter Filter) (dest []Entity, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Raise(ctx context.Context, data Entity, reopenSince time.Time) (err error)
+	Resolve(ctx context.Context, inventoryID, kind string, quantity int) (err error)
+	Acknowledge(ctx context.Context, id string) (err error)
+}
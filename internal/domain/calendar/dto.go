@@ -0,0 +1,169 @@
+package calendar
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"warehouse-service/internal/domain/schedule"
+)
+
+// SeasonRequest adds a season, a season without periods keeps the store closed throughout.
+type SeasonRequest struct {
+	Name      *string           `json:"name"`
+	ValidFrom string            `json:"valid_from"`
+	ValidTo   *string           `json:"valid_to"`
+	Periods   []schedule.Period `json:"periods"`
+}
+
+func (s *SeasonRequest) Bind(r *http.Request) error {
+	validFrom, err := ParseDate(s.ValidFrom)
+	if err != nil {
+		return errors.New("valid_from: must be a date written as YYYY-MM-DD")
+	}
+
+	if s.ValidTo != nil {
+		validTo, err := ParseDate(*s.ValidTo)
+		if err != nil {
+			return errors.New("valid_to: must be a date written as YYYY-MM-DD")
+		}
+
+		if validTo.Before(validFrom) {
+			return errors.New("valid_to: cannot be before valid_from")
+		}
+	}
+
+	for _, object := range s.Periods {
+		if err = object.Validate(); err != nil {
+			return errors.New("periods." + err.Error())
+		}
+	}
+
+	return nil
+}
+
+type SeasonResponse struct {
+	ID        string            `json:"id"`
+	StoreID   string            `json:"store_id"`
+	Name      *string           `json:"name"`
+	ValidFrom string            `json:"valid_from"`
+	ValidTo   *string           `json:"valid_to"`
+	Periods   []schedule.Period `json:"periods"`
+}
+
+func ParseFromSeason(data Season) (res SeasonResponse) {
+	res = SeasonResponse{
+		ID:        data.ID,
+		StoreID:   data.StoreID,
+		Name:      data.Name,
+		ValidFrom: data.ValidFrom.Format(DateLayout),
+		Periods:   make([]schedule.Period, 0),
+	}
+
+	if data.ValidTo != nil {
+		validTo := data.ValidTo.Format(DateLayout)
+		res.ValidTo = &validTo
+	}
+	json.Unmarshal(data.Periods, &res.Periods)
+
+	return
+}
+
+func ParseFromSeasons(data []Season) (res []SeasonResponse) {
+	res = make([]SeasonResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromSeason(object))
+	}
+	return
+}
+
+// ExceptionRequest sets the hours of one date, closed or open for the hours given.
+type ExceptionRequest struct {
+	Closed bool             `json:"closed"`
+	Hours  []schedule.Hours `json:"hours"`
+	Note   *string          `json:"note"`
+}
+
+func (s *ExceptionRequest) Bind(r *http.Request) error {
+	if s.Closed && len(s.Hours) > 0 {
+		return errors.New("hours: must be empty when closed")
+	}
+
+	if !s.Closed && len(s.Hours) == 0 {
+		return errors.New("hours: cannot be empty unless closed")
+	}
+
+	for _, object := range s.Hours {
+		if err := object.Validate(); err != nil {
+			return errors.New("hours." + err.Error())
+		}
+	}
+
+	return nil
+}
+
+type ExceptionResponse struct {
+	StoreID string           `json:"store_id"`
+	Date    string           `json:"date"`
+	Closed  bool             `json:"closed"`
+	Hours   []schedule.Hours `json:"hours"`
+	Note    *string          `json:"note"`
+}
+
+func ParseFromException(data Exception) (res ExceptionResponse) {
+	res = ExceptionResponse{
+		StoreID: data.StoreID,
+		Date:    data.Date.Format(DateLayout),
+		Closed:  data.Closed,
+		Hours:   make([]schedule.Hours, 0),
+		Note:    data.Note,
+	}
+	json.Unmarshal(data.Hours, &res.Hours)
+
+	return
+}
+
+func ParseFromExceptions(data []Exception) (res []ExceptionResponse) {
+	res = make([]ExceptionResponse, 0)
+	for _, object := range data {
+		res = append(res, ParseFromException(object))
+	}
+	return
+}
+
+// DayResponse is the hours a store keeps on a date and where they come from.
+type DayResponse struct {
+	Date   string           `json:"date"`
+	Source string           `json:"source"`
+	Closed bool             `json:"closed"`
+	Hours  []schedule.Hours `json:"hours"`
+}
+
+// ParseFromCalendar resolves the hours of the days from the date on.
+func ParseFromCalendar(data Calendar, from time.Time, days int) (res []DayResponse) {
+	res = make([]DayResponse, 0, days)
+	for i := 0; i < days; i++ {
+		date := from.AddDate(0, 0, i)
+		hours, source := data.Resolve(date)
+		if hours == nil {
+			hours = make([]schedule.Hours, 0)
+		}
+
+		res = append(res, DayResponse{
+			Date:   date.Format(DateLayout),
+			Source: source,
+			Closed: len(hours) == 0,
+			Hours:  hours,
+		})
+	}
+	return
+}
+
+// ParseDate reads a date written as YYYY-MM-DD.
+func ParseDate(value string) (date time.Time, err error) {
+	if date, err = time.Parse(DateLayout, value); err != nil {
+		err = ErrorDate
+	}
+	return
+}
@@ -0,0 +1,127 @@
+package calendar
+
+import (
+	"encoding/json"
+	"time"
+
+	"warehouse-service/internal/domain/schedule"
+)
+
+// DateLayout is how the dates of the calendar are written.
+const DateLayout = "2006-01-02"
+
+const (
+	SourceException = "exception"
+	SourceSeason    = "season"
+	SourceDefault   = "default"
+)
+
+// Season is a weekly pattern of a store from ValidFrom through ValidTo, both dates
+// included, open ended when ValidTo is nil. It replaces the default schedule.
+type Season struct {
+	CreatedAt time.Time  `db:"created_at"`
+	ID        string     `db:"id"`
+	StoreID   string     `db:"store_id"`
+	Name      *string    `db:"name"`
+	ValidFrom time.Time  `db:"valid_from"`
+	ValidTo   *time.Time `db:"valid_to"`
+	Periods   []byte     `db:"periods"`
+}
+
+// Covers reports whether the season is valid on the date.
+func (e Season) Covers(date string) bool {
+	return e.ValidFrom.Format(DateLayout) <= date && (e.ValidTo == nil || e.ValidTo.Format(DateLayout) >= date)
+}
+
+// Exception replaces the hours of a store on one date, the store is either closed
+// all day or open for the hours given.
+type Exception struct {
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+	StoreID   string    `db:"store_id"`
+	Date      time.Time `db:"date"`
+	Closed    bool      `db:"closed"`
+	Hours     []byte    `db:"hours"`
+	Note      *string   `db:"note"`
+}
+
+// Calendar holds what decides the hours of a store, Default is nil without an
+// active weekly schedule.
+type Calendar struct {
+	Default    []schedule.Period
+	Seasons    []Season
+	Exceptions []Exception
+}
+
+// Within keeps the exceptions from one date through another.
+func Within(data []Exception, from, to time.Time) (dest []Exception) {
+	first, last := from.Format(DateLayout), to.Format(DateLayout)
+	for _, object := range data {
+		if date := object.Date.Format(DateLayout); date >= first && date <= last {
+			dest = append(dest, object)
+		}
+	}
+	return
+}
+
+// IsEmpty reports whether nothing tells the hours of the store.
+func (c Calendar) IsEmpty() bool {
+	return c.Default == nil && len(c.Seasons) == 0 && len(c.Exceptions) == 0
+}
+
+// Resolve returns the hours of the date and where they come from: the exception
+// of the date first, then the season covering it, the one starting last when
+// seasons overlap, then the default schedule. The source is blank when none applies.
+func (c Calendar) Resolve(date time.Time) (hours []schedule.Hours, source string) {
+	day := date.Format(DateLayout)
+
+	for _, object := range c.Exceptions {
+		if object.Date.Format(DateLayout) != day {
+			continue
+		}
+
+		hours = make([]schedule.Hours, 0)
+		if !object.Closed {
+			json.Unmarshal(object.Hours, &hours)
+		}
+		return hours, SourceException
+	}
+
+	var season *Season
+	for i, object := range c.Seasons {
+		if !object.Covers(day) {
+			continue
+		}
+
+		if season == nil || object.ValidFrom.After(season.ValidFrom) ||
+			object.ValidFrom.Equal(season.ValidFrom) && object.CreatedAt.After(season.CreatedAt) {
+			season = &c.Seasons[i]
+		}
+	}
+
+	if season != nil {
+		var periods []schedule.Period
+		json.Unmarshal(season.Periods, &periods)
+		return onDate(periods, date), SourceSeason
+	}
+
+	if c.Default != nil {
+		return onDate(c.Default, date), SourceDefault
+	}
+
+	return nil, ""
+}
+
+// HoursOn returns the hours of each date the way Resolve does, for schedule.Evaluate.
+func (c Calendar) HoursOn(date time.Time) []schedule.Hours {
+	hours, _ := c.Resolve(date)
+	return hours
+}
+
+func onDate(periods []schedule.Period, date time.Time) []schedule.Hours {
+	hours := schedule.Weekly(periods)(date)
+	if hours == nil {
+		hours = make([]schedule.Hours, 0)
+	}
+	return hours
+}
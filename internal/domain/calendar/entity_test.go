@@ -0,0 +1,229 @@
+package calendar
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"warehouse-service/internal/domain/schedule"
+)
+
+func TestCalendarResolve(t *testing.T) {
+	// a Monday
+	date := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+	day := func(value string) time.Time {
+		parsed, err := time.Parse(DateLayout, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+	weekly := func(from, to string) []schedule.Period {
+		return []schedule.Period{{Day: "monday", From: from, To: to}}
+	}
+	season := func(validFrom string, validTo *string, created int, from, to string) Season {
+		periods, _ := json.Marshal(weekly(from, to))
+		data := Season{
+			CreatedAt: time.Date(2026, time.January, created, 0, 0, 0, 0, time.UTC),
+			ValidFrom: day(validFrom),
+			Periods:   periods,
+		}
+		if validTo != nil {
+			end := day(*validTo)
+			data.ValidTo = &end
+		}
+		return data
+	}
+	exception := func(date string, closed bool, hours ...schedule.Hours) Exception {
+		data, _ := json.Marshal(hours)
+		return Exception{Date: day(date), Closed: closed, Hours: data}
+	}
+	until := func(value string) *string {
+		return &value
+	}
+
+	tests := []struct {
+		name     string
+		calendar Calendar
+		hours    []schedule.Hours
+		source   string
+	}{
+		{
+			name:     "nothing tells the hours",
+			calendar: Calendar{},
+			hours:    nil,
+			source:   "",
+		},
+		{
+			name:     "default schedule",
+			calendar: Calendar{Default: weekly("09:00", "18:00")},
+			hours:    []schedule.Hours{{From: "09:00", To: "18:00"}},
+			source:   SourceDefault,
+		},
+		{
+			name:     "default schedule closed on the day",
+			calendar: Calendar{Default: []schedule.Period{{Day: "tuesday", From: "09:00", To: "18:00"}}},
+			hours:    []schedule.Hours{},
+			source:   SourceDefault,
+		},
+		{
+			name: "season covering the date replaces the default",
+			calendar: Calendar{
+				Default: weekly("09:00", "18:00"),
+				Seasons: []Season{season("2026-10-01", until("2026-10-31"), 1, "10:00", "16:00")},
+			},
+			hours:  []schedule.Hours{{From: "10:00", To: "16:00"}},
+			source: SourceSeason,
+		},
+		{
+			name: "season ended before the date",
+			calendar: Calendar{
+				Default: weekly("09:00", "18:00"),
+				Seasons: []Season{season("2026-06-01", until("2026-10-18"), 1, "10:00", "16:00")},
+			},
+			hours:  []schedule.Hours{{From: "09:00", To: "18:00"}},
+			source: SourceDefault,
+		},
+		{
+			name: "season starting on the date",
+			calendar: Calendar{
+				Default: weekly("09:00", "18:00"),
+				Seasons: []Season{season("2026-10-19", nil, 1, "10:00", "16:00")},
+			},
+			hours:  []schedule.Hours{{From: "10:00", To: "16:00"}},
+			source: SourceSeason,
+		},
+		{
+			name: "season without a default schedule",
+			calendar: Calendar{
+				Seasons: []Season{season("2026-10-01", nil, 1, "10:00", "16:00")},
+			},
+			hours:  []schedule.Hours{{From: "10:00", To: "16:00"}},
+			source: SourceSeason,
+		},
+		{
+			name: "overlapping seasons, the one starting last wins",
+			calendar: Calendar{
+				Seasons: []Season{
+					season("2026-10-10", nil, 1, "11:00", "15:00"),
+					season("2026-09-01", nil, 2, "10:00", "16:00"),
+				},
+			},
+			hours:  []schedule.Hours{{From: "11:00", To: "15:00"}},
+			source: SourceSeason,
+		},
+		{
+			name: "seasons starting together, the one created last wins",
+			calendar: Calendar{
+				Seasons: []Season{
+					season("2026-10-01", nil, 2, "11:00", "15:00"),
+					season("2026-10-01", nil, 1, "10:00", "16:00"),
+				},
+			},
+			hours:  []schedule.Hours{{From: "11:00", To: "15:00"}},
+			source: SourceSeason,
+		},
+		{
+			name: "exception replaces the season and the default",
+			calendar: Calendar{
+				Default:    weekly("09:00", "18:00"),
+				Seasons:    []Season{season("2026-10-01", nil, 1, "10:00", "16:00")},
+				Exceptions: []Exception{exception("2026-10-19", false, schedule.Hours{From: "12:00", To: "14:00"})},
+			},
+			hours:  []schedule.Hours{{From: "12:00", To: "14:00"}},
+			source: SourceException,
+		},
+		{
+			name: "closed exception",
+			calendar: Calendar{
+				Default:    weekly("09:00", "18:00"),
+				Exceptions: []Exception{exception("2026-10-19", true)},
+			},
+			hours:  []schedule.Hours{},
+			source: SourceException,
+		},
+		{
+			name: "exception of another date",
+			calendar: Calendar{
+				Default:    weekly("09:00", "18:00"),
+				Exceptions: []Exception{exception("2026-10-20", true)},
+			},
+			hours:  []schedule.Hours{{From: "09:00", To: "18:00"}},
+			source: SourceDefault,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hours, source := tt.calendar.Resolve(date)
+
+			if source != tt.source {
+				t.Errorf("source is %q, want %q", source, tt.source)
+			}
+
+			if !reflect.DeepEqual(hours, tt.hours) {
+				t.Errorf("hours are %#v, want %#v", hours, tt.hours)
+			}
+		})
+	}
+}
+
+func TestWithin(t *testing.T) {
+	day := func(value string) Exception {
+		date, err := time.Parse(DateLayout, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return Exception{Date: date}
+	}
+	data := []Exception{day("2026-10-18"), day("2026-10-19"), day("2026-10-20"), day("2026-10-21")}
+
+	almaty := time.FixedZone("Asia/Almaty", 5*60*60)
+
+	tests := []struct {
+		name string
+		from time.Time
+		to   time.Time
+		want []string
+	}{
+		{
+			name: "one day",
+			from: time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC),
+			to:   time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC),
+			want: []string{"2026-10-19"},
+		},
+		{
+			name: "both ends included",
+			from: time.Date(2026, time.October, 19, 23, 0, 0, 0, time.UTC),
+			to:   time.Date(2026, time.October, 20, 1, 0, 0, 0, time.UTC),
+			want: []string{"2026-10-19", "2026-10-20"},
+		},
+		{
+			name: "dates of the local day",
+			from: time.Date(2026, time.October, 21, 2, 0, 0, 0, almaty),
+			to:   time.Date(2026, time.October, 21, 2, 0, 0, 0, almaty),
+			want: []string{"2026-10-21"},
+		},
+		{
+			name: "nothing in the range",
+			from: time.Date(2026, time.October, 22, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2026, time.October, 25, 0, 0, 0, 0, time.UTC),
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, object := range Within(data, tt.from, tt.to) {
+				got = append(got, object.Date.Format(DateLayout))
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("dates are %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package calendar
+
+import (
+	"errors"
+)
+
+var (
+	ErrorDate = errors.New("calendar: dates must be written as YYYY-MM-DD")
+)
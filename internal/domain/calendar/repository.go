@@ -0,0 +1,22 @@
+package calendar
+
+import (
+	"context"
+	"time"
+)
+
+// Repository keeps the seasons and the exceptions of the stores. An exception is
+// keyed by its store and date, saving one again replaces it. SelectExceptions lists
+// the exceptions from one date through another. The ByStores methods read them
+// for several stores at once, in the same order per store.
+type Repository interface {
+	SelectSeasons(ctx context.Context, storeID string) (dest []Season, err error)
+	SelectSeasonsByStores(ctx context.Context, storeIDs []string) (dest []Season, err error)
+	CreateSeason(ctx context.Context, data Season) (id string, err error)
+	DeleteSeason(ctx context.Context, storeID, id string) (err error)
+
+	SelectExceptions(ctx context.Context, storeID string, from, to time.Time) (dest []Exception, err error)
+	SelectExceptionsByStores(ctx context.Context, storeIDs []string, from, to time.Time) (dest []Exception, err error)
+	SaveException(ctx context.Context, data Exception) (err error)
+	DeleteException(ctx context.Context, storeID string, date time.Time) (err error)
+}
@@ -3,20 +3,18 @@ package city
 import (
 	"errors"
 	"net/http"
+
+	"warehouse-service/internal/domain/country"
 )
 
 type Request struct {
-	ID        string `json:"id"`
 	Name      string `json:"name"`
 	GeoCenter string `json:"geocenter"`
 	CountryID string `json:"country_id"`
+	TimeZone  string `json:"time_zone"`
 }
 
 func (s *Request) Bind(r *http.Request) error {
-	if s.ID == "" {
-		return errors.New("ID: cannot be blank")
-	}
-
 	if s.Name == "" {
 		return errors.New("name: cannot be blank")
 	}
@@ -25,6 +23,15 @@ func (s *Request) Bind(r *http.Request) error {
 		return errors.New("geocenter: cannot be blank")
 	}
 
+	if s.CountryID == "" {
+		return errors.New("country_id: cannot be blank")
+	}
+
+	// a blank zone takes the one of the country
+	if s.TimeZone != "" && !country.IsTimeZone(s.TimeZone) {
+		return errors.New("time_zone: must be a zone of the tz database")
+	}
+
 	return nil
 }
 
@@ -33,6 +40,7 @@ type Response struct {
 	Name      string `json:"name"`
 	GeoCenter string `json:"geocenter"`
 	CountryID string `json:"country_id"`
+	TimeZone  string `json:"time_zone,omitempty"`
 }
 
 func ParseFromEntity(data *Entity) (res *Response) {
@@ -47,6 +55,10 @@ func ParseFromEntity(data *Entity) (res *Response) {
 		CountryID: data.CountryID,
 	}
 
+	if data.TimeZone != nil {
+		res.TimeZone = *data.TimeZone
+	}
+
 	return
 }
 
@@ -2,6 +2,7 @@ package city
 
 import "time"
 
+// Entity is a city, a nil TimeZone takes the one of the country.
 type Entity struct {
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
@@ -9,4 +10,5 @@ type Entity struct {
 	CountryID string    `db:"country_id"`
 	Name      *string   `db:"name"`
 	GeoCenter *string   `db:"geocenter"`
+	TimeZone  *string   `db:"time_zone"`
 }
@@ -0,0 +1,11 @@
+package city
+
+import (
+	"errors"
+)
+
+var (
+	ErrorDuplicate      = errors.New("city: a city with that name already exists")
+	ErrorInUse          = errors.New("city: the city still has stores")
+	ErrorUnknownCountry = errors.New("city: no country with that id")
+)
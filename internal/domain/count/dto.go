@@ -0,0 +1,150 @@
+package count
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Request opens a session over the whole store, or only over the listed products.
+type Request struct {
+	StoreID    string   `json:"store_id"`
+	ProductIDs []string `json:"product_ids"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.StoreID == "" {
+		return errors.New("store_id: cannot be blank")
+	}
+
+	for _, productID := range s.ProductIDs {
+		if productID == "" {
+			return errors.New("product_ids: cannot contain a blank id")
+		}
+	}
+
+	return nil
+}
+
+// EntryRequest is a counted quantity, the counter defaults to the actor of the request.
+type EntryRequest struct {
+	ProductID string `json:"product_id"`
+	Quantity  *int   `json:"quantity"`
+	Counter   string `json:"counter"`
+}
+
+func (s *EntryRequest) Bind(r *http.Request) error {
+	if s.ProductID == "" {
+		return errors.New("product_id: cannot be blank")
+	}
+
+	if s.Quantity == nil || *s.Quantity < 0 {
+		return errors.New("quantity: must be zero or greater")
+	}
+
+	if s.Counter == "" {
+		s.Counter = r.Header.Get("X-Actor-ID")
+	}
+
+	return nil
+}
+
+type ItemResponse struct {
+	InventoryID string `json:"inventory_id"`
+	ProductID   string `json:"product_id"`
+	Expected    int    `json:"expected"`
+	Counted     *int   `json:"counted"`
+}
+
+type Response struct {
+	ID        string         `json:"id"`
+	StoreID   string         `json:"store_id"`
+	Status    string         `json:"status"`
+	Items     []ItemResponse `json:"items"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	ClosedAt  *time.Time     `json:"closed_at"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:        data.ID,
+		StoreID:   data.StoreID,
+		Status:    data.Status,
+		Items:     make([]ItemResponse, 0, len(data.Items)),
+		CreatedAt: data.CreatedAt,
+		UpdatedAt: data.UpdatedAt,
+		ClosedAt:  data.ClosedAt,
+	}
+
+	for _, item := range data.Items {
+		res.Items = append(res.Items, ItemResponse{
+			InventoryID: item.InventoryID,
+			ProductID:   item.ProductID,
+			Expected:    item.Expected,
+			Counted:     item.Counted,
+		})
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+// VarianceItemResponse tells how the shelf differs from the record. Moved is
+// what the record changed by between opening the session and the last count.
+type VarianceItemResponse struct {
+	InventoryID string `json:"inventory_id"`
+	ProductID   string `json:"product_id"`
+	Expected    int    `json:"expected"`
+	Moved       *int   `json:"moved"`
+	Counted     *int   `json:"counted"`
+	Variance    *int   `json:"variance"`
+}
+
+type VarianceResponse struct {
+	ID        string                 `json:"id"`
+	Status    string                 `json:"status"`
+	Counted   int                    `json:"counted"`
+	Uncounted int                    `json:"uncounted"`
+	Variance  int                    `json:"variance"`
+	Items     []VarianceItemResponse `json:"items"`
+}
+
+func ParseVarianceFromEntity(data Entity) (res VarianceResponse) {
+	res = VarianceResponse{
+		ID:     data.ID,
+		Status: data.Status,
+		Items:  make([]VarianceItemResponse, 0, len(data.Items)),
+	}
+
+	for _, item := range data.Items {
+		row := VarianceItemResponse{
+			InventoryID: item.InventoryID,
+			ProductID:   item.ProductID,
+			Expected:    item.Expected,
+			Counted:     item.Counted,
+			Variance:    item.Variance(),
+		}
+
+		if item.Balance != nil {
+			moved := *item.Balance - item.Expected
+			row.Moved = &moved
+		}
+
+		if row.Variance == nil {
+			res.Uncounted++
+		} else {
+			res.Counted++
+			res.Variance += *row.Variance
+		}
+
+		res.Items = append(res.Items, row)
+	}
+	return
+}
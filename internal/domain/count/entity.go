@@ -0,0 +1,56 @@
+package count
+
+import "time"
+
+const (
+	StatusOpen      = "open"
+	StatusApproved  = "approved"
+	StatusCancelled = "cancelled"
+)
+
+type Entity struct {
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+	ClosedAt  *time.Time `db:"closed_at"`
+	ID        string     `db:"id"`
+	StoreID   string     `db:"store_id"`
+	Status    string     `db:"status"`
+	Items     []Item     `db:"-"`
+}
+
+// Item is an inventory being counted. Expected is its quantity when the
+// session opened, Counted sums the latest count of every counter and Balance
+// is the quantity on record when the last count came in.
+type Item struct {
+	CountID     string `db:"count_id"`
+	InventoryID string `db:"inventory_id"`
+	ProductID   string `db:"product_id"`
+	Expected    int    `db:"expected"`
+	Counted     *int   `db:"counted"`
+	Balance     *int   `db:"balance"`
+}
+
+// Variance is the difference between the shelf and the record at the moment of
+// the last count, so stock sold before the count is on neither side and the
+// approval, applying it on top of the current quantity, keeps what moved after.
+func (i Item) Variance() *int {
+	if i.Counted == nil || i.Balance == nil {
+		return nil
+	}
+	variance := *i.Counted - *i.Balance
+	return &variance
+}
+
+// Entry is the quantity of the item counted by one counter, a later entry of the same counter replaces it.
+type Entry struct {
+	InventoryID string
+	ProductID   string
+	Counter     string
+	Quantity    int
+}
+
+// Filter narrows the listing down, empty fields match everything.
+type Filter struct {
+	StoreID string
+	Status  string
+}
@@ -0,0 +1,12 @@
+package count
+
+import (
+	"errors"
+)
+
+var (
+	ErrorNotOpen         = errors.New("count: is not open")
+	ErrorNothingToCount  = errors.New("count: the store has no inventory to count")
+	ErrorAlreadyCounting = errors.New("count: an inventory is already being counted")
+	ErrorNotCounted      = errors.New("count: the product is not part of the count")
+)
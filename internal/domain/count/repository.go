@@ -0,0 +1,23 @@
+package count
+
+import (
+	"context"
+
+	"warehouse-service/internal/domain/movement"
+)
+
+// Repository runs count sessions. Create snapshots the inventories of the store,
+// or only the given products, and marks them with the session until it is
+// approved or cancelled; an inventory is counted by one session at a time.
+// Record stores the entry together with the quantity on record at that moment.
+// Approve adjusts every counted item by its variance, journaling the movements,
+// and fails with inventory.ErrorInsufficientStock without applying any of them
+// when one would leave the quantity below zero.
+type Repository interface {
+	Select(ctx context.Context, filter Filter) (dest []Entity, err error)
+	Create(ctx context.Context, data Entity, productIDs []string) (dest string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Record(ctx context.Context, id string, entry Entry) (err error)
+	Approve(ctx context.Context, id string, change movement.Entity) (err error)
+	Cancel(ctx context.Context, id string) (err error)
+}
@@ -6,25 +6,26 @@ import (
 )
 
 type Request struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	Name     string `json:"name"`
+	TimeZone string `json:"time_zone"`
 }
 
 func (s *Request) Bind(r *http.Request) error {
-	if s.ID == "" {
-		return errors.New("ID: cannot be blank")
-	}
-
 	if s.Name == "" {
 		return errors.New("name: cannot be blank")
 	}
 
+	if !IsTimeZone(s.TimeZone) {
+		return errors.New("time_zone: must be a zone of the tz database")
+	}
+
 	return nil
 }
 
 type Response struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	TimeZone string `json:"time_zone"`
 }
 
 func ParseFromEntity(data *Entity) (res *Response) {
@@ -37,5 +38,17 @@ func ParseFromEntity(data *Entity) (res *Response) {
 		Name: *data.Name,
 	}
 
+	if data.TimeZone != nil {
+		res.TimeZone = *data.TimeZone
+	}
+
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []*Response) {
+	res = make([]*Response, 0)
+	for i := range data {
+		res = append(res, ParseFromEntity(&data[i]))
+	}
 	return
 }
@@ -1,10 +1,26 @@
 package country
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type Entity struct {
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
 	ID        string    `db:"id"`
 	Name      *string   `db:"name"`
+	TimeZone  *string   `db:"time_zone"`
+}
+
+// IsTimeZone tells whether the name is a zone of the tz database, such as Asia/Almaty.
+// Local is refused as it depends on the host the service runs on.
+func IsTimeZone(name string) bool {
+	if strings.TrimSpace(name) == "" || name == "Local" {
+		return false
+	}
+
+	_, err := time.LoadLocation(name)
+
+	return err == nil
 }
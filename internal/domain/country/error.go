@@ -0,0 +1,10 @@
+package country
+
+import (
+	"errors"
+)
+
+var (
+	ErrorDuplicate = errors.New("country: a country with that name already exists")
+	ErrorInUse     = errors.New("country: the country still has cities or currencies")
+)
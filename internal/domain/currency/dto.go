@@ -3,35 +3,43 @@ package currency
 import (
 	"errors"
 	"net/http"
+	"strconv"
 )
 
 type Request struct {
-	ID       string `json:"id"`
-	Sign     string `json:"sing"`
-	Decimals string `json:"decimals"`
-	Prefix   bool   `json:"prefix"`
+	CountryID string `json:"country_id"`
+	Code      string `json:"code"`
+	Sign      string `json:"sing"`
+	Decimals  string `json:"decimals"`
+	Prefix    bool   `json:"prefix"`
 }
 
 func (s *Request) Bind(r *http.Request) error {
-	if s.ID == "" {
-		return errors.New("ID: cannot be blank")
+	if s.CountryID == "" {
+		return errors.New("country_id: cannot be blank")
+	}
+
+	if s.Code != "" && !IsCode(s.Code) {
+		return errors.New("code: must be an ISO 4217 code")
 	}
 
 	if s.Sign == "" {
 		return errors.New("sign: cannot be blank")
 	}
 
-	if s.Decimals == "" {
-		return errors.New("decimals: cannot be blank")
+	if decimals, err := strconv.Atoi(s.Decimals); err != nil || decimals < 0 || decimals > 4 {
+		return errors.New("decimals: must be a whole number from 0 to 4")
 	}
 	return nil
 }
 
 type Response struct {
-	ID       string `json:"id"`
-	Sign     string `json:"sing"`
-	Decimals string `json:"decimals"`
-	Prefix   bool   `json:"prefix"`
+	ID        string `json:"id"`
+	CountryID string `json:"country_id,omitempty"`
+	Code      string `json:"code,omitempty"`
+	Sign      string `json:"sing"`
+	Decimals  string `json:"decimals"`
+	Prefix    bool   `json:"prefix"`
 }
 
 func ParseFromEntity(data *Entity) (res *Response) {
@@ -40,10 +48,15 @@ func ParseFromEntity(data *Entity) (res *Response) {
 	}
 
 	res = &Response{
-		ID:       data.ID,
-		Sign:     *data.Sign,
-		Decimals: *data.Decimals,
-		Prefix:   data.Prefix,
+		ID:        data.ID,
+		CountryID: data.CountryID,
+		Sign:      *data.Sign,
+		Decimals:  *data.Decimals,
+		Prefix:    data.Prefix,
+	}
+
+	if data.Code != nil {
+		res.Code = *data.Code
 	}
 
 	return
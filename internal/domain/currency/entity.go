@@ -1,13 +1,52 @@
 package currency
 
-import "time"
+import (
+	"strconv"
+	"time"
+
+	"warehouse-service/pkg/money"
+)
 
 type Entity struct {
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
 	ID        string    `db:"id"`
 	CountryID string    `db:"country_id"`
+	Code      *string   `db:"code"`
 	Sign      *string   `db:"sign"`
 	Decimals  *string   `db:"decimals"`
 	Prefix    bool      `db:"prefix"`
 }
+
+// Money returns how the amounts of the currency are written.
+func (e Entity) Money() (dest money.Currency, err error) {
+	dest.Prefix = e.Prefix
+
+	if e.Sign != nil {
+		dest.Sign = *e.Sign
+	}
+
+	if e.Decimals != nil {
+		decimals, err := strconv.Atoi(*e.Decimals)
+		if err != nil {
+			return dest, err
+		}
+		dest.Decimals = int32(decimals)
+	}
+
+	return
+}
+
+// IsCode reports whether the code looks like an ISO 4217 code, three upper case letters.
+func IsCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,10 @@
+package currency
+
+import (
+	"errors"
+)
+
+var (
+	ErrorDuplicate      = errors.New("currency: the sign, the code or the country is taken by another currency")
+	ErrorUnknownCountry = errors.New("currency: no country with that id")
+)
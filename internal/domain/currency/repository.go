@@ -2,10 +2,14 @@ package currency
 
 import "context"
 
+// Repository keeps one currency per country. Get and Update find the currency by the
+// id of its country, GetByID by its own.
 type Repository interface {
 	Select(ctx context.Context) (dest []Entity, err error)
 	Create(ctx context.Context, data Entity) (dest string, err error)
 	Get(ctx context.Context, id string) (dest *Entity, err error)
+	GetByID(ctx context.Context, id string) (dest *Entity, err error)
+	GetByCode(ctx context.Context, code string) (dest *Entity, err error)
 	Update(ctx context.Context, id string, data Entity) (err error)
 	Delete(ctx context.Context, id string) (err error)
 }
@@ -0,0 +1,89 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"warehouse-service/pkg/geo"
+)
+
+// Polygon is a delivery area, a closed ring of points whose last point repeats the first one.
+type Polygon []Area
+
+// Point reads the coordinates of the area point.
+func (a Area) Point() (dest geo.Point, err error) {
+	if dest.Lat, err = strconv.ParseFloat(a.Latitude, 64); err != nil {
+		return dest, errors.New("latitude: must be a number of degrees")
+	}
+
+	if dest.Lng, err = strconv.ParseFloat(a.Longitude, 64); err != nil {
+		return dest, errors.New("longitude: must be a number of degrees")
+	}
+
+	return dest, dest.Validate()
+}
+
+// Ring reads the points of the polygon.
+func (p Polygon) Ring() (dest geo.Polygon, err error) {
+	dest = make(geo.Polygon, 0, len(p))
+	for i, object := range p {
+		point, err := object.Point()
+		if err != nil {
+			return nil, fmt.Errorf("point %d: %w", i, err)
+		}
+		dest = append(dest, point)
+	}
+
+	return
+}
+
+// ValidateAreas checks every area is a closed ring of valid points that does not cross itself.
+func ValidateAreas(areas []Polygon) error {
+	for i, object := range areas {
+		ring, err := object.Ring()
+		if err == nil {
+			err = ring.Validate()
+		}
+
+		if err != nil {
+			return fmt.Errorf("areas[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Covers tells whether the point lies inside one of the areas. An area that cannot
+// be read, as one stored before the areas were checked, covers nothing.
+func Covers(areas []Polygon, point geo.Point) bool {
+	for _, object := range areas {
+		ring, err := object.Ring()
+		if err != nil || ring.Validate() != nil {
+			continue
+		}
+
+		if ring.Contains(point) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParsePoint reads the latitude and longitude of a query.
+func ParsePoint(lat, lng string) (geo.Point, error) {
+	if lat == "" || lng == "" {
+		return geo.Point{}, errors.New("lat, lng: cannot be blank")
+	}
+
+	return Area{Latitude: lat, Longitude: lng}.Point()
+}
+
+// CheckResponse tells whether the store delivers to the point.
+type CheckResponse struct {
+	StoreID   string  `json:"store_id"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Delivers  bool    `json:"delivers"`
+}
@@ -1,6 +1,13 @@
 package delivery
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"warehouse-service/internal/domain/schedule"
+)
 
 type Period struct {
 	Day  string `json:"day"`
@@ -14,15 +21,93 @@ type Area struct {
 }
 
 type Request struct {
-	IsActive bool     `json:"isActive" `
-	Periods  []Period `json:"periods" `
-	Areas    []Area   `json:"areas" `
+	IsActive bool      `json:"isActive" `
+	Periods  []Period  `json:"periods" `
+	Areas    []Polygon `json:"areas" `
+
+	SlotMinutes  int `json:"slotMinutes,omitempty"`
+	SlotCapacity int `json:"slotCapacity,omitempty"`
+
+	Fees *Fees `json:"fees,omitempty"`
+
+	// ClearFees is set by fees given as null, which removes the stored ones
+	ClearFees bool `json:"-"`
+}
+
+func (s *Request) UnmarshalJSON(data []byte) (err error) {
+	type request Request
+	if err = json.Unmarshal(data, (*request)(s)); err != nil {
+		return
+	}
+
+	s.ClearFees, err = nullFees(data)
+	return
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if err := ValidateAreas(s.Areas); err != nil {
+		return err
+	}
+
+	for i, object := range s.Periods {
+		if err := schedule.Period(object).Validate(); err != nil {
+			return fmt.Errorf("periods[%d].%w", i, err)
+		}
+	}
+
+	// zero keeps the slot length and capacity stored
+	if s.SlotMinutes < 0 || s.SlotMinutes > 24*60 {
+		return errors.New("slotMinutes: must be between 1 and 1440")
+	}
+
+	if s.SlotCapacity < 0 {
+		return errors.New("slotCapacity: cannot be negative")
+	}
+
+	if s.Fees != nil {
+		if err := s.Fees.Validate(len(s.Areas)); err != nil {
+			return fmt.Errorf("fees.%w", err)
+		}
+	}
+
+	return nil
 }
 
 type Response struct {
-	IsActive bool     `json:"isActive" `
-	Periods  []Period `json:"periods" `
-	Areas    []Area   `json:"areas" `
+	IsActive bool      `json:"isActive" `
+	Periods  []Period  `json:"periods" `
+	Areas    []Polygon `json:"areas" `
+
+	SlotMinutes  int `json:"slotMinutes,omitempty"`
+	SlotCapacity int `json:"slotCapacity,omitempty"`
+
+	Fees *Fees `json:"fees,omitempty"`
+
+	// ClearFees tells a store request with the fees of its delivery given as null
+	ClearFees bool `json:"-"`
+}
+
+// UnmarshalJSON reads the delivery of a store request, the way a request of its own is read.
+func (s *Response) UnmarshalJSON(data []byte) (err error) {
+	type response Response
+	if err = json.Unmarshal(data, (*response)(s)); err != nil {
+		return
+	}
+
+	s.ClearFees, err = nullFees(data)
+	return
+}
+
+// nullFees tells fees given as null from fees left out, both leave Fees nil.
+func nullFees(data []byte) (null bool, err error) {
+	var fields struct {
+		Fees json.RawMessage `json:"fees"`
+	}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return
+	}
+
+	return string(fields.Fees) == "null", nil
 }
 
 func ParseFromEntity(data *Entity) (res *Response) {
@@ -31,10 +116,17 @@ func ParseFromEntity(data *Entity) (res *Response) {
 	}
 
 	res = &Response{
-		IsActive: data.IsActive,
+		IsActive:     data.IsActive,
+		SlotMinutes:  data.SlotMinutes,
+		SlotCapacity: data.SlotCapacity,
 	}
 	json.Unmarshal(data.Periods, &res.Periods)
 	json.Unmarshal(data.Areas, &res.Areas)
 
+	if len(data.Fees) > 0 {
+		res.Fees = new(Fees)
+		json.Unmarshal(data.Fees, res.Fees)
+	}
+
 	return
 }
@@ -2,6 +2,15 @@ package delivery
 
 import "time"
 
+// The slot length and capacity of a delivery that sets neither.
+const (
+	DefaultSlotMinutes  = 60
+	DefaultSlotCapacity = 10
+)
+
+// Entity is the delivery of a store, its periods are cut into slots of SlotMinutes
+// each taking up to SlotCapacity orders. Fees is null for a store setting no fees,
+// an update with empty but not nil fees removes the stored ones.
 type Entity struct {
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
@@ -10,4 +19,9 @@ type Entity struct {
 	Periods   []byte    `db:"periods" `
 	Areas     []byte    `db:"areas" `
 	IsActive  bool      `db:"is_active" `
+
+	SlotMinutes  int `db:"slot_minutes"`
+	SlotCapacity int `db:"slot_capacity"`
+
+	Fees []byte `db:"fees"`
 }
@@ -0,0 +1,12 @@
+package delivery
+
+import (
+	"errors"
+)
+
+var (
+	ErrorNoFees      = errors.New("delivery: the store sets no delivery fees")
+	ErrorNoLocation  = errors.New("delivery: the store has no coordinates to charge by distance")
+	ErrorUnavailable = errors.New("delivery: the store does not deliver to the destination")
+	ErrorTooFar      = errors.New("delivery: the destination is beyond the last distance tier")
+)
@@ -0,0 +1,223 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/shopspring/decimal"
+
+	"warehouse-service/pkg/geo"
+)
+
+// Tier charges PerKm for each kilometre of the distance up to UpToKm, counted from
+// where the tier before it ends. A tier without UpToKm takes the rest of the distance.
+type Tier struct {
+	UpToKm *decimal.Decimal `json:"upToKm,omitempty"`
+	PerKm  decimal.Decimal  `json:"perKm"`
+}
+
+// Zone charges Fee in place of the base and distance fees for a destination inside
+// the delivery area with the index Area.
+type Zone struct {
+	Area int             `json:"area"`
+	Fee  decimal.Decimal `json:"fee"`
+}
+
+// Fees are the delivery fee rules of a store, in its currency. An order of FreeFrom
+// or more is delivered free.
+type Fees struct {
+	Base         decimal.Decimal  `json:"base"`
+	Tiers        []Tier           `json:"tiers"`
+	Zones        []Zone           `json:"zones"`
+	MinimumOrder decimal.Decimal  `json:"minimumOrder"`
+	FreeFrom     *decimal.Decimal `json:"freeFrom,omitempty"`
+}
+
+// Validate checks the amounts are not negative, the tiers run outwards with only the
+// last one unbounded, and every zone names one of the areas.
+func (f Fees) Validate(areas int) error {
+	if f.Base.IsNegative() {
+		return errors.New("base: cannot be negative")
+	}
+
+	if f.MinimumOrder.IsNegative() {
+		return errors.New("minimumOrder: cannot be negative")
+	}
+
+	if f.FreeFrom != nil && f.FreeFrom.IsNegative() {
+		return errors.New("freeFrom: cannot be negative")
+	}
+
+	lower := decimal.Zero
+	for i, object := range f.Tiers {
+		if object.PerKm.IsNegative() {
+			return fmt.Errorf("tiers[%d].perKm: cannot be negative", i)
+		}
+
+		if object.UpToKm == nil {
+			if i != len(f.Tiers)-1 {
+				return fmt.Errorf("tiers[%d].upToKm: only the last tier can leave it blank", i)
+			}
+			continue
+		}
+
+		if !object.UpToKm.GreaterThan(lower) {
+			return fmt.Errorf("tiers[%d].upToKm: must be greater than the tier before", i)
+		}
+		lower = *object.UpToKm
+	}
+
+	for i, object := range f.Zones {
+		if object.Area < 0 || object.Area >= areas {
+			return fmt.Errorf("zones[%d].area: must be the index of one of the areas", i)
+		}
+
+		if object.Fee.IsNegative() {
+			return fmt.Errorf("zones[%d].fee: cannot be negative", i)
+		}
+	}
+
+	return nil
+}
+
+// distanceFee charges the tiers for the distance.
+func (f Fees) distanceFee(distance decimal.Decimal) (dest decimal.Decimal, err error) {
+	lower := decimal.Zero
+	for _, object := range f.Tiers {
+		upper := distance
+		if object.UpToKm != nil && object.UpToKm.LessThan(distance) {
+			upper = *object.UpToKm
+		}
+
+		if upper.GreaterThan(lower) {
+			dest = dest.Add(upper.Sub(lower).Mul(object.PerKm))
+		}
+
+		if object.UpToKm == nil {
+			return dest, nil
+		}
+		lower = *object.UpToKm
+	}
+
+	if distance.GreaterThan(lower) {
+		return dest, ErrorTooFar
+	}
+
+	return
+}
+
+// Quote prices the delivery of a basket to the destination, from the origin when it is
+// known, with the amounts rounded to the decimals. The first zone covering the
+// destination sets the fee, the base and distance fees apply elsewhere.
+func (f Fees) Quote(areas []Polygon, origin *geo.Point, destination geo.Point, basket decimal.Decimal, decimals int32) (dest QuoteResponse, err error) {
+	dest = QuoteResponse{
+		BasketTotal:  basket,
+		MinimumOrder: f.MinimumOrder,
+		FreeFrom:     f.FreeFrom,
+	}
+
+	if origin != nil {
+		distance := decimal.NewFromFloat(geo.Distance(*origin, destination)).Round(2)
+		dest.DistanceKm = &distance
+	}
+
+	for _, object := range f.Zones {
+		if object.Area < len(areas) && Covers(areas[object.Area:object.Area+1], destination) {
+			area, fee := object.Area, object.Fee
+			dest.Zone, dest.ZoneFee = &area, &fee
+			break
+		}
+	}
+
+	subtotal := decimal.Zero
+	if dest.ZoneFee != nil {
+		subtotal = *dest.ZoneFee
+	} else {
+		dest.BaseFee = f.Base
+
+		if len(f.Tiers) > 0 {
+			if dest.DistanceKm == nil {
+				return dest, ErrorNoLocation
+			}
+
+			if dest.DistanceFee, err = f.distanceFee(*dest.DistanceKm); err != nil {
+				return
+			}
+		}
+		subtotal = dest.BaseFee.Add(dest.DistanceFee)
+	}
+
+	if f.FreeFrom != nil && !basket.LessThan(*f.FreeFrom) {
+		dest.Free = true
+		dest.Discount = subtotal
+	}
+
+	dest.Fee = subtotal.Sub(dest.Discount).Round(decimals)
+	dest.BaseFee = dest.BaseFee.Round(decimals)
+	dest.DistanceFee = dest.DistanceFee.Round(decimals)
+	dest.Discount = dest.Discount.Round(decimals)
+	if dest.ZoneFee != nil {
+		*dest.ZoneFee = dest.ZoneFee.Round(decimals)
+	}
+
+	dest.MeetsMinimum = !basket.LessThan(f.MinimumOrder)
+	if !dest.MeetsMinimum {
+		dest.Shortfall = f.MinimumOrder.Sub(basket)
+	}
+
+	return
+}
+
+type QuoteRequest struct {
+	Latitude    *float64         `json:"lat"`
+	Longitude   *float64         `json:"lng"`
+	BasketTotal *decimal.Decimal `json:"basket_total"`
+}
+
+func (s *QuoteRequest) Bind(r *http.Request) error {
+	if s.Latitude == nil || s.Longitude == nil {
+		return errors.New("lat, lng: cannot be blank")
+	}
+
+	if err := s.Destination().Validate(); err != nil {
+		return err
+	}
+
+	if s.BasketTotal == nil {
+		return errors.New("basket_total: cannot be blank")
+	}
+
+	if s.BasketTotal.IsNegative() {
+		return errors.New("basket_total: cannot be negative")
+	}
+
+	return nil
+}
+
+// Destination is the point the request asks to deliver to.
+func (s *QuoteRequest) Destination() geo.Point {
+	return geo.Point{Lat: *s.Latitude, Lng: *s.Longitude}
+}
+
+// QuoteResponse breaks the fee down. A zone fee replaces the base and distance fees,
+// the discount takes the whole fee off an order of FreeFrom or more.
+type QuoteResponse struct {
+	StoreID    string           `json:"store_id"`
+	Currency   string           `json:"currency,omitempty"`
+	DistanceKm *decimal.Decimal `json:"distance_km,omitempty"`
+	Zone       *int             `json:"zone,omitempty"`
+
+	BaseFee     decimal.Decimal  `json:"base_fee"`
+	DistanceFee decimal.Decimal  `json:"distance_fee"`
+	ZoneFee     *decimal.Decimal `json:"zone_fee,omitempty"`
+	Discount    decimal.Decimal  `json:"discount"`
+	Fee         decimal.Decimal  `json:"fee"`
+	Free        bool             `json:"free"`
+
+	BasketTotal  decimal.Decimal  `json:"basket_total"`
+	MinimumOrder decimal.Decimal  `json:"minimum_order"`
+	MeetsMinimum bool             `json:"meets_minimum"`
+	Shortfall    decimal.Decimal  `json:"shortfall"`
+	FreeFrom     *decimal.Decimal `json:"free_from,omitempty"`
+}
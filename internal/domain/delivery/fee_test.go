@@ -0,0 +1,207 @@
+package delivery
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"warehouse-service/pkg/geo"
+)
+
+func amount(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
+func upTo(value string) *decimal.Decimal {
+	km := amount(value)
+	return &km
+}
+
+func TestFeesDistanceFee(t *testing.T) {
+	// free up to 2 km, 100 a km up to 5 km and 50 a km beyond
+	open := Fees{Tiers: []Tier{
+		{UpToKm: upTo("2"), PerKm: amount("0")},
+		{UpToKm: upTo("5"), PerKm: amount("100")},
+		{PerKm: amount("50")},
+	}}
+	// 100 a km up to 3 km and no delivery beyond
+	bounded := Fees{Tiers: []Tier{
+		{UpToKm: upTo("3"), PerKm: amount("100")},
+	}}
+
+	tests := []struct {
+		name     string
+		fees     Fees
+		distance string
+		want     string
+		err      error
+	}{
+		{"within the free tier", open, "1.5", "0", nil},
+		{"at the end of the free tier", open, "2", "0", nil},
+		{"within the second tier", open, "3.5", "150", nil},
+		{"at the end of the second tier", open, "5", "300", nil},
+		{"within the open tier", open, "8", "450", nil},
+		{"no distance", open, "0", "0", nil},
+		{"within the last bounded tier", bounded, "2.5", "250", nil},
+		{"at the end of the last bounded tier", bounded, "3", "300", nil},
+		{"beyond the last bounded tier", bounded, "3.01", "", ErrorTooFar},
+		{"no tiers", Fees{}, "4", "", ErrorTooFar},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fee, err := tt.fees.distanceFee(amount(tt.distance))
+			if err != tt.err {
+				t.Fatalf("error is %v, want %v", err, tt.err)
+			}
+
+			if tt.err == nil && !fee.Equal(amount(tt.want)) {
+				t.Fatalf("fee is %s, want %s", fee, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeesQuote(t *testing.T) {
+	origin := &geo.Point{Lat: 0, Lng: 0}
+	// 3.34 km east of the origin
+	destination := geo.Point{Lat: 0, Lng: 0.03}
+	// a square around the destination
+	areas := []Polygon{{
+		{Latitude: "-0.01", Longitude: "0.02"},
+		{Latitude: "-0.01", Longitude: "0.04"},
+		{Latitude: "0.01", Longitude: "0.04"},
+		{Latitude: "0.01", Longitude: "0.02"},
+		{Latitude: "-0.01", Longitude: "0.02"},
+	}}
+	tiers := []Tier{
+		{UpToKm: upTo("2"), PerKm: amount("0")},
+		{PerKm: amount("100")},
+	}
+
+	tests := []struct {
+		name     string
+		fees     Fees
+		areas    []Polygon
+		origin   *geo.Point
+		basket   string
+		decimals int32
+		err      error
+
+		fee          string
+		baseFee      string
+		distanceFee  string
+		zoneFee      string
+		discount     string
+		free         bool
+		meetsMinimum bool
+		shortfall    string
+	}{
+		{
+			name:   "base fee only",
+			fees:   Fees{Base: amount("200")},
+			basket: "1000", fee: "200", baseFee: "200", distanceFee: "0", discount: "0", meetsMinimum: true, shortfall: "0",
+		},
+		{
+			name:   "base and distance fees",
+			fees:   Fees{Base: amount("200"), Tiers: tiers},
+			origin: origin,
+			basket: "1000", fee: "334", baseFee: "200", distanceFee: "134", discount: "0", meetsMinimum: true, shortfall: "0",
+		},
+		{
+			name:   "distance fees without the store location",
+			fees:   Fees{Base: amount("200"), Tiers: tiers},
+			basket: "1000", err: ErrorNoLocation,
+		},
+		{
+			name:   "destination beyond the tiers",
+			fees:   Fees{Tiers: []Tier{{UpToKm: upTo("3"), PerKm: amount("100")}}},
+			origin: origin,
+			basket: "1000", err: ErrorTooFar,
+		},
+		{
+			name:   "zone fee replaces the base and distance fees",
+			fees:   Fees{Base: amount("200"), Tiers: tiers, Zones: []Zone{{Area: 0, Fee: amount("150")}}},
+			areas:  areas,
+			basket: "1000", fee: "150", baseFee: "0", distanceFee: "0", zoneFee: "150", discount: "0", meetsMinimum: true, shortfall: "0",
+		},
+		{
+			name:   "zone of an area missing the destination",
+			fees:   Fees{Base: amount("200"), Zones: []Zone{{Area: 0, Fee: amount("150")}}},
+			areas:  []Polygon{{{"1", "1"}, {"1", "2"}, {"2", "2"}, {"1", "1"}}},
+			basket: "1000", fee: "200", baseFee: "200", distanceFee: "0", discount: "0", meetsMinimum: true, shortfall: "0",
+		},
+		{
+			name:   "basket reaching free delivery",
+			fees:   Fees{Base: amount("200"), Tiers: tiers, FreeFrom: upTo("5000")},
+			origin: origin,
+			basket: "5000", fee: "0", baseFee: "200", distanceFee: "134", discount: "334", free: true, meetsMinimum: true, shortfall: "0",
+		},
+		{
+			name:   "basket short of free delivery",
+			fees:   Fees{Base: amount("200"), FreeFrom: upTo("5000")},
+			basket: "4999.99", fee: "200", baseFee: "200", distanceFee: "0", discount: "0", meetsMinimum: true, shortfall: "0",
+		},
+		{
+			name:   "basket below the minimum order",
+			fees:   Fees{Base: amount("200"), MinimumOrder: amount("1500")},
+			basket: "1200", fee: "200", baseFee: "200", distanceFee: "0", discount: "0", meetsMinimum: false, shortfall: "300",
+		},
+		{
+			name:   "amounts rounded to the decimals",
+			fees:   Fees{Base: amount("99.5")},
+			basket: "1000", fee: "100", baseFee: "100", distanceFee: "0", discount: "0", meetsMinimum: true, shortfall: "0",
+		},
+		{
+			name:     "amounts kept to the decimals",
+			fees:     Fees{Base: amount("99.456")},
+			basket:   "1000",
+			decimals: 2,
+			fee:      "99.46", baseFee: "99.46", distanceFee: "0", discount: "0", meetsMinimum: true, shortfall: "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote, err := tt.fees.Quote(tt.areas, tt.origin, destination, amount(tt.basket), tt.decimals)
+			if err != tt.err {
+				t.Fatalf("error is %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+
+			amounts := []struct {
+				name string
+				got  decimal.Decimal
+				want string
+			}{
+				{"fee", quote.Fee, tt.fee},
+				{"base fee", quote.BaseFee, tt.baseFee},
+				{"distance fee", quote.DistanceFee, tt.distanceFee},
+				{"discount", quote.Discount, tt.discount},
+				{"shortfall", quote.Shortfall, tt.shortfall},
+			}
+			for _, object := range amounts {
+				if !object.got.Equal(amount(object.want)) {
+					t.Errorf("%s is %s, want %s", object.name, object.got, object.want)
+				}
+			}
+
+			if tt.zoneFee == "" && quote.ZoneFee != nil {
+				t.Errorf("zone fee is %s, want none", quote.ZoneFee)
+			}
+			if tt.zoneFee != "" && (quote.ZoneFee == nil || !quote.ZoneFee.Equal(amount(tt.zoneFee))) {
+				t.Errorf("zone fee is %v, want %s", quote.ZoneFee, tt.zoneFee)
+			}
+
+			if quote.Free != tt.free {
+				t.Errorf("free is %v, want %v", quote.Free, tt.free)
+			}
+
+			if quote.MeetsMinimum != tt.meetsMinimum {
+				t.Errorf("meets minimum is %v, want %v", quote.MeetsMinimum, tt.meetsMinimum)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package inventory
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Columns lists the CSV columns of an inventory in the order they are written.
+// An import needs the first four, the others are optional and may come in any order.
+var Columns = []string{
+	"store_id",
+	"product_id",
+	"quantity",
+	"price",
+	"quantity_min",
+	"quantity_max",
+	"price_special",
+	"price_previous",
+	"is_available",
+	"allow_backorder",
+}
+
+// ExportColumns are written by the export, an import skips the id and the catalog_id.
+var ExportColumns = append([]string{"id", "catalog_id"}, Columns...)
+
+// ParseHeader maps the known column names to their position in the CSV header.
+func ParseHeader(record []string) (header map[string]int, err error) {
+	header = make(map[string]int)
+	for i, name := range record {
+		header[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+
+	for _, name := range Columns[:4] {
+		if _, ok := header[name]; !ok {
+			return nil, ErrorInvalidHeader
+		}
+	}
+
+	return
+}
+
+// ParseFromRecord reads a CSV record into a request, empty optional cells are left unset.
+func ParseFromRecord(header map[string]int, record []string) (req Request, err error) {
+	value := func(name string) *string {
+		i, ok := header[name]
+		if !ok || i >= len(record) {
+			return nil
+		}
+
+		cell := strings.TrimSpace(record[i])
+		if cell == "" {
+			return nil
+		}
+		return &cell
+	}
+
+	flag := func(name string) (*bool, error) {
+		cell := value(name)
+		if cell == nil {
+			return nil, nil
+		}
+
+		parsed, err := strconv.ParseBool(*cell)
+		if err != nil {
+			return nil, errors.New(name + ": must be true or false")
+		}
+		return &parsed, nil
+	}
+
+	for name, field := range map[string]*string{"store_id": &req.StoreID, "product_id": &req.ProductID, "quantity": &req.Quantity, "price": &req.Price} {
+		if cell := value(name); cell != nil {
+			*field = *cell
+		}
+	}
+
+	req.QuantityMin = value("quantity_min")
+	req.QuantityMax = value("quantity_max")
+	req.PriceSpecial = value("price_special")
+	req.PricePrevious = value("price_previous")
+
+	if req.IsAvailable, err = flag("is_available"); err != nil {
+		return
+	}
+
+	if req.AllowBackorder, err = flag("allow_backorder"); err != nil {
+		return
+	}
+
+	err = req.Validate()
+
+	return
+}
+
+// Record formats the response as a CSV record in the order of ExportColumns.
+func (s Response) Record() []string {
+	value := func(cell *string) string {
+		if cell == nil {
+			return ""
+		}
+		return *cell
+	}
+
+	flag := func(cell *bool) string {
+		if cell == nil {
+			return ""
+		}
+		return strconv.FormatBool(*cell)
+	}
+
+	return []string{
+		s.ID,
+		s.CatalogID,
+		s.StoreID,
+		s.ProductID,
+		s.Quantity,
+		s.Price,
+		value(s.QuantityMin),
+		value(s.QuantityMax),
+		value(s.PriceSpecial),
+		value(s.PricePrevious),
+		flag(s.IsAvailable),
+		flag(s.AllowBackorder),
+	}
+}
+
+// ImportError tells why a line of the CSV file was rejected.
+type ImportError struct {
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+type ImportResponse struct {
+	Accepted int           `json:"accepted"`
+	Rejected int           `json:"rejected"`
+	Errors   []ImportError `json:"errors"`
+}
+
+// Reject counts the line as rejected and keeps the reason for the report.
+func (s *ImportResponse) Reject(line int, err error) {
+	s.Rejected++
+	s.Errors = append(s.Errors, ImportError{Line: line, Reason: err.Error()})
+}
@@ -3,22 +3,44 @@ package inventory
 import (
 	"errors"
 	"net/http"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/pkg/money"
 )
 
 type Request struct {
-	ID            string  `json:"id"`
-	StoreID       string  `json:"store_id"`
-	ProductID     string  `json:"product_id"`
-	Quantity      string  `json:"quantity"`
-	QuantityMin   *string `json:"quantity_min"`
-	QuantityMax   *string `json:"quantity_max"`
-	Price         string  `json:"price"`
-	PriceSpecial  *string `json:"price_special"`
-	PricePrevious *string `json:"price_previous"`
-	IsAvailable   *bool   `json:"is_available"`
+	ID             string  `json:"id"`
+	StoreID        string  `json:"store_id"`
+	ProductID      string  `json:"product_id"`
+	Quantity       string  `json:"quantity"`
+	QuantityMin    *string `json:"quantity_min"`
+	QuantityMax    *string `json:"quantity_max"`
+	Price          string  `json:"price"`
+	PriceSpecial   *string `json:"price_special"`
+	PricePrevious  *string `json:"price_previous"`
+	IsAvailable    *bool   `json:"is_available"`
+	AllowBackorder *bool   `json:"allow_backorder"`
+
+	// Unit names the unit of measure of the quantities, which may then carry
+	// decimals; they are converted to whole base units of the product.
+	Unit string `json:"unit"`
+
+	movement.Request
 }
 
 func (s *Request) Bind(r *http.Request) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	return s.Request.Bind(r)
+}
+
+// Validate checks the inventory fields, it is shared by the HTTP API and the CSV import.
+func (s *Request) Validate() error {
 	if s.StoreID == "" {
 		return errors.New("store_id: cannot be blank")
 	}
@@ -31,36 +53,120 @@ func (s *Request) Bind(r *http.Request) error {
 		return errors.New("name: quantity be blank")
 	}
 
+	if s.Unit != "" {
+		if quantity, err := decimal.NewFromString(s.Quantity); err != nil || quantity.IsNegative() {
+			return errors.New("quantity: must be a non-negative number")
+		}
+	} else if quantity, err := strconv.Atoi(s.Quantity); err != nil || quantity < 0 {
+		return errors.New("quantity: must be a non-negative integer")
+	}
+
 	if s.Price == "" {
 		return errors.New("address: price be blank")
 	}
 
+	if _, err := decimal.NewFromString(s.Price); err != nil {
+		return errors.New("price: must be a number")
+	}
+
+	for name, value := range map[string]*string{"quantity_min": s.QuantityMin, "quantity_max": s.QuantityMax} {
+		if value == nil {
+			continue
+		}
+		if s.Unit != "" {
+			if _, err := decimal.NewFromString(*value); err != nil {
+				return errors.New(name + ": must be a number")
+			}
+		} else if _, err := strconv.Atoi(*value); err != nil {
+			return errors.New(name + ": must be an integer")
+		}
+	}
+
+	for name, value := range map[string]*string{"price_special": s.PriceSpecial, "price_previous": s.PricePrevious} {
+		if value == nil {
+			continue
+		}
+		if _, err := decimal.NewFromString(*value); err != nil {
+			return errors.New(name + ": must be a number")
+		}
+	}
+
 	return nil
 }
 
+// AdjustRequest changes the quantity by a signed delta, e.g. -3 for a sale of three items
+// or -0.35 with the kg unit. With a bin id the stock is received into or picked from that bin.
+type AdjustRequest struct {
+	Delta decimal.Decimal `json:"delta"`
+	Unit  string          `json:"unit"`
+	BinID string          `json:"bin_id"`
+
+	movement.Request
+}
+
+func (s *AdjustRequest) Bind(r *http.Request) error {
+	if s.Delta.IsZero() {
+		return errors.New("delta: cannot be zero")
+	}
+
+	if s.Unit == "" && !s.Delta.IsInteger() {
+		return errors.New("delta: must be an integer without a unit")
+	}
+
+	if s.Reason == "" {
+		s.Reason = movement.ReasonReceipt
+		if s.Delta.IsNegative() {
+			s.Reason = movement.ReasonSale
+		}
+	}
+
+	return s.Request.Bind(r)
+}
+
 type Response struct {
-	ID            string  `json:"id"`
-	StoreID       string  `json:"store_id"`
-	ProductID     string  `json:"product_id"`
-	Quantity      string  `json:"quantity"`
-	QuantityMin   *string `json:"quantity_min"`
-	QuantityMax   *string `json:"quantity_max"`
-	Price         string  `json:"price"`
-	PriceSpecial  *string `json:"price_special"`
-	PricePrevious *string `json:"price_previous"`
-	IsAvailable   *bool   `json:"is_available"`
+	ID             string  `json:"id"`
+	StoreID        string  `json:"store_id"`
+	CatalogID      string  `json:"catalog_id,omitempty"`
+	ProductID      string  `json:"product_id"`
+	Quantity       string  `json:"quantity"`
+	QuantityMin    *string `json:"quantity_min"`
+	QuantityMax    *string `json:"quantity_max"`
+	Price          string  `json:"price"`
+	PriceSpecial   *string `json:"price_special"`
+	PricePrevious  *string `json:"price_previous"`
+	IsAvailable    *bool   `json:"is_available"`
+	AllowBackorder *bool   `json:"allow_backorder"`
+	CountID        *string `json:"count_id,omitempty"`
+	IsSerialized   *bool   `json:"is_serialized"`
+	Unit           string  `json:"unit,omitempty"`
+	Currency       string  `json:"currency,omitempty"`
+	PriceDisplay   string  `json:"price_display,omitempty"`
+}
+
+// View tells a read how to show the inventory, the zero value shows the quantities
+// in the base unit and the prices in the currency of the store, written in English.
+type View struct {
+	Unit     string
+	Currency string
+	Locale   money.Locale
 }
 
 func ParseFromEntity(data Entity) (res Response) {
 	res = Response{
-		ID:            data.ID,
-		StoreID:       data.StoreID,
-		Quantity:      *data.Quantity,
-		QuantityMin:   data.QuantityMin,
-		QuantityMax:   data.QuantityMax,
-		Price:         *data.Price,
-		PriceSpecial:  data.PriceSpecial,
-		PricePrevious: data.PricePrevious,
+		ID:             data.ID,
+		StoreID:        data.StoreID,
+		CatalogID:      data.CatalogID,
+		ProductID:      data.ProductID,
+		Quantity:       *data.Quantity,
+		QuantityMin:    data.QuantityMin,
+		QuantityMax:    data.QuantityMax,
+		Price:          *data.Price,
+		PriceSpecial:   data.PriceSpecial,
+		PricePrevious:  data.PricePrevious,
+		IsAvailable:    data.IsAvailable,
+		AllowBackorder: data.AllowBackorder,
+		CountID:        data.CountID,
+		IsSerialized:   data.IsSerialized,
 	}
 	return
 }
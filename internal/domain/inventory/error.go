@@ -0,0 +1,13 @@
+package inventory
+
+import (
+	"errors"
+)
+
+var (
+	ErrorInsufficientStock = errors.New("inventory: insufficient stock")
+	ErrorDuplicate         = errors.New("inventory: the product is already stocked in the store")
+	ErrorInvalidHeader     = errors.New("inventory: csv header must contain store_id, product_id, quantity and price")
+	ErrorInvalidFormat     = errors.New("inventory: format must be csv or ndjson")
+	ErrorSerialized        = errors.New("inventory: the quantity of a serialized item follows its serial numbers")
+)
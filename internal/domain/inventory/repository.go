@@ -1,11 +1,28 @@
 package inventory
 
-import "context"
+import (
+	"context"
 
+	"warehouse-service/internal/domain/movement"
+)
+
+// Repository keeps one inventory per store and product: Create fails with
+// ErrorDuplicate for a known pair, while Upsert and UpsertBatch update the
+// existing one. UpsertBatch applies the whole batch or nothing, and keeps the
+// price of every row in the history of an inventory that has one.
+// Every change of the quantity made by any of the write methods is
+// journaled as a movement with the given reason, actor and correlation id.
+// Adjust fails with ErrorInsufficientStock instead of leaving the quantity
+// below zero, unless the item allows backorders. Each calls fn for every
+// matching inventory without loading them all at once and stops on its first error.
 type Repository interface {
 	Select(ctx context.Context) (dest []Entity, err error)
-	Create(ctx context.Context, data Entity) (dest string, err error)
+	Each(ctx context.Context, filter Filter, fn func(data Entity) error) (err error)
+	Create(ctx context.Context, data Entity, change movement.Entity) (dest string, err error)
+	Upsert(ctx context.Context, data Entity, change movement.Entity) (dest string, err error)
+	UpsertBatch(ctx context.Context, data []Entity, change movement.Entity) (err error)
 	Get(ctx context.Context, id string) (dest Entity, err error)
-	Update(ctx context.Context, id string, data Entity) (err error)
+	Update(ctx context.Context, id string, data Entity, change movement.Entity) (err error)
+	Adjust(ctx context.Context, id string, delta int, change movement.Entity) (balance int, err error)
 	Delete(ctx context.Context, id string) (err error)
 }
@@ -0,0 +1,127 @@
+package location
+
+import (
+	"errors"
+	"net/http"
+)
+
+type Request struct {
+	ParentID *string `json:"parent_id"`
+	Kind     string  `json:"kind"`
+	Code     string  `json:"code"`
+	Name     *string `json:"name"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if !IsKind(s.Kind) {
+		return errors.New("kind: must be zone, aisle or bin")
+	}
+
+	if s.Code == "" {
+		return errors.New("code: cannot be blank")
+	}
+
+	if s.Kind == KindZone && s.ParentID != nil {
+		return errors.New("parent_id: a zone cannot have a parent")
+	}
+
+	if s.Kind != KindZone && (s.ParentID == nil || *s.ParentID == "") {
+		return errors.New("parent_id: cannot be blank")
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID       string  `json:"id"`
+	StoreID  string  `json:"store_id"`
+	ParentID *string `json:"parent_id"`
+	Kind     string  `json:"kind"`
+	Code     string  `json:"code"`
+	Name     *string `json:"name"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:       data.ID,
+		StoreID:  data.StoreID,
+		ParentID: data.ParentID,
+		Kind:     data.Kind,
+		Code:     data.Code,
+		Name:     data.Name,
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
+
+// MoveRequest moves stock between two bins of the inventory store, a blank bin
+// id stands for the unassigned stock.
+type MoveRequest struct {
+	FromBinID string `json:"from_bin_id"`
+	ToBinID   string `json:"to_bin_id"`
+	Quantity  int    `json:"quantity"`
+}
+
+func (s *MoveRequest) Bind(r *http.Request) error {
+	if s.FromBinID == s.ToBinID {
+		return errors.New("to_bin_id: must differ from from_bin_id")
+	}
+
+	if s.Quantity <= 0 {
+		return errors.New("quantity: must be greater than zero")
+	}
+
+	return nil
+}
+
+type StockResponse struct {
+	BinID       string `json:"bin_id"`
+	Path        string `json:"path"`
+	InventoryID string `json:"inventory_id"`
+	ProductID   string `json:"product_id"`
+	Quantity    int    `json:"quantity"`
+}
+
+func ParseStockFromEntities(data []Stock) (res []StockResponse) {
+	res = make([]StockResponse, 0)
+	for _, object := range data {
+		res = append(res, StockResponse{
+			BinID:       object.LocationID,
+			Path:        object.Path,
+			InventoryID: object.InventoryID,
+			ProductID:   object.ProductID,
+			Quantity:    object.Quantity,
+		})
+	}
+	return
+}
+
+// BinsResponse splits the inventory quantity over its bins, a negative quantity
+// of a backordered item leaves nothing unassigned.
+type BinsResponse struct {
+	Quantity   int             `json:"quantity"`
+	Unassigned int             `json:"unassigned"`
+	Bins       []StockResponse `json:"bins"`
+}
+
+func ParseBinsFromEntities(quantity int, data []Stock) (res BinsResponse) {
+	res = BinsResponse{
+		Quantity:   quantity,
+		Unassigned: quantity,
+		Bins:       ParseStockFromEntities(data),
+	}
+	for _, object := range data {
+		res.Unassigned -= object.Quantity
+	}
+	if res.Unassigned < 0 {
+		res.Unassigned = 0
+	}
+	return
+}
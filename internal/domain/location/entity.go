@@ -0,0 +1,57 @@
+package location
+
+import "time"
+
+const (
+	KindZone  = "zone"
+	KindAisle = "aisle"
+	KindBin   = "bin"
+)
+
+// parents tells the kind a location must be placed under, zones sit right under the store.
+var parents = map[string]string{
+	KindZone:  "",
+	KindAisle: KindZone,
+	KindBin:   KindAisle,
+}
+
+// Entity is a zone, an aisle or a bin of a store. Stock only sits in bins.
+type Entity struct {
+	CreatedAt time.Time `db:"created_at"`
+	ID        string    `db:"id"`
+	StoreID   string    `db:"store_id"`
+	ParentID  *string   `db:"parent_id"`
+	Kind      string    `db:"kind"`
+	Code      string    `db:"code"`
+	Name      *string   `db:"name"`
+}
+
+// Stock is the part of an inventory quantity put away in a bin. The bins of an
+// inventory never hold more than its quantity, the rest is unassigned stock; a
+// decrement not aimed at a bin takes the unassigned stock first and then empties
+// the bins holding the least.
+type Stock struct {
+	LocationID  string `db:"location_id"`
+	InventoryID string `db:"inventory_id"`
+	StoreID     string `db:"store_id"`
+	ProductID   string `db:"product_id"`
+	Path        string `db:"path"`
+	Quantity    int    `db:"quantity"`
+}
+
+// Filter narrows the stock down to an inventory or to a product of a store.
+type Filter struct {
+	InventoryID string
+	StoreID     string
+	ProductID   string
+}
+
+// ParentKind returns the kind of location the given kind is placed under.
+func ParentKind(kind string) string {
+	return parents[kind]
+}
+
+func IsKind(kind string) bool {
+	_, ok := parents[kind]
+	return ok
+}
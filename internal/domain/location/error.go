@@ -0,0 +1,13 @@
+package location
+
+import (
+	"errors"
+)
+
+var (
+	ErrorParent     = errors.New("location: an aisle must be placed in a zone and a bin in an aisle of the same store")
+	ErrorDuplicate  = errors.New("location: the code is already used at this level")
+	ErrorNotEmpty   = errors.New("location: the location still holds other locations or stock")
+	ErrorNotBin     = errors.New("location: stock can only be put in a bin of the inventory store")
+	ErrorUnassigned = errors.New("location: not enough unassigned stock to put away")
+)
@@ -0,0 +1,21 @@
+package location
+
+import (
+	"context"
+
+	"warehouse-service/internal/domain/movement"
+)
+
+// Repository keeps the locations of the stores and the stock put away in bins.
+// An empty bin id in Move stands for the unassigned stock. Adjust changes the
+// inventory quantity and the bin together, journaling the change as a movement.
+type Repository interface {
+	Select(ctx context.Context, storeID string) (dest []Entity, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Create(ctx context.Context, data Entity) (id string, err error)
+	Delete(ctx context.Context, id string) (err error)
+
+	SelectStock(ctx context.Context, filter Filter) (dest []Stock, err error)
+	Move(ctx context.Context, inventoryID, fromID, toID string, quantity int) (err error)
+	Adjust(ctx context.Context, inventoryID, binID string, delta int, change movement.Entity) (balance int, err error)
+}
@@ -0,0 +1,87 @@
+package lot
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"warehouse-service/internal/domain/movement"
+)
+
+type Request struct {
+	Number    string `json:"number"`
+	ExpiresAt string `json:"expires_at"`
+	Quantity  int    `json:"quantity"`
+	movement.Request
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.Number == "" {
+		return errors.New("number: cannot be blank")
+	}
+
+	if _, err := time.Parse(DateLayout, s.ExpiresAt); err != nil {
+		return errors.New("expires_at: must be a date formatted as YYYY-MM-DD")
+	}
+
+	if s.Quantity <= 0 {
+		return errors.New("quantity: must be greater than zero")
+	}
+
+	return s.Request.Bind(r)
+}
+
+// Response tells how much of the lot is held by reservations, holds take the lots first-expiry-first-out too.
+type Response struct {
+	Number    string `json:"number"`
+	ExpiresAt string `json:"expires_at"`
+	Quantity  int    `json:"quantity"`
+	Held      int    `json:"held"`
+	Available int    `json:"available"`
+}
+
+// ParseFromEntities spreads the held quantity over the lots in the order they are consumed.
+func ParseFromEntities(data []Entity, held int) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		hold := held
+		if hold > object.Quantity {
+			hold = object.Quantity
+		}
+		held -= hold
+
+		res = append(res, Response{
+			Number:    object.Number,
+			ExpiresAt: object.ExpiresAt.Format(DateLayout),
+			Quantity:  object.Quantity,
+			Held:      hold,
+			Available: object.Quantity - hold,
+		})
+	}
+	return
+}
+
+type ExpiringResponse struct {
+	InventoryID string `json:"inventory_id"`
+	ProductID   string `json:"product_id"`
+	Number      string `json:"number"`
+	ExpiresAt   string `json:"expires_at"`
+	DaysLeft    int    `json:"days_left"`
+	Quantity    int    `json:"quantity"`
+}
+
+// ParseExpiringFromEntities counts the days left from today, an expired lot has a negative count.
+func ParseExpiringFromEntities(data []Entity, today time.Time) (res []ExpiringResponse) {
+	res = make([]ExpiringResponse, 0)
+	for _, object := range data {
+		res = append(res, ExpiringResponse{
+			InventoryID: object.InventoryID,
+			ProductID:   object.ProductID,
+			Number:      object.Number,
+			ExpiresAt:   object.ExpiresAt.Format(DateLayout),
+			DaysLeft:    int(object.ExpiresAt.Sub(today).Hours() / 24),
+			Quantity:    object.Quantity,
+		})
+	}
+	return
+}
@@ -0,0 +1,28 @@
+package lot
+
+import "time"
+
+// DateLayout is the format of the expiry dates in requests and responses.
+const DateLayout = "2006-01-02"
+
+// Entity is a part of the inventory quantity sharing one expiry date. The lots
+// of an inventory never add up to more than its quantity, the rest is stock
+// received without a lot.
+type Entity struct {
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+	ExpiresAt   time.Time `db:"expires_at"`
+	InventoryID string    `db:"inventory_id"`
+	StoreID     string    `db:"store_id"`
+	ProductID   string    `db:"product_id"`
+	Number      string    `db:"number"`
+	Quantity    int       `db:"quantity"`
+}
+
+// Less orders the lots first-expiry-first-out, the order stock is taken from them.
+func Less(a, b Entity) bool {
+	if !a.ExpiresAt.Equal(b.ExpiresAt) {
+		return a.ExpiresAt.Before(b.ExpiresAt)
+	}
+	return a.Number < b.Number
+}
@@ -0,0 +1,7 @@
+package lot
+
+import (
+	"errors"
+)
+
+var ErrorExpiryMismatch = errors.New("lot: the lot is already stocked with another expiry date")
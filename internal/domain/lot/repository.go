@@ -0,0 +1,19 @@
+package lot
+
+import (
+	"context"
+	"time"
+
+	"warehouse-service/internal/domain/movement"
+)
+
+// Repository keeps the lots of the inventories. Receive adds the quantity to the
+// lot and to the inventory at once and journals the movement. Any decrement of an
+// inventory quantity takes the same amount out of its lots first-expiry-first-out,
+// whichever repository makes it. SelectExpiring lists the lots of the store
+// expiring before the given moment, expired ones included.
+type Repository interface {
+	Select(ctx context.Context, inventoryID string) (dest []Entity, err error)
+	SelectExpiring(ctx context.Context, storeID string, before time.Time) (dest []Entity, err error)
+	Receive(ctx context.Context, data Entity, change movement.Entity) (balance int, err error)
+}
@@ -0,0 +1,71 @@
+package movement
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Request describes why the stock is changed and who changes it.
+// The actor and the correlation id fall back to the X-Actor-ID and
+// X-Correlation-ID headers, and then to the id of the HTTP request.
+type Request struct {
+	Reason        string `json:"reason"`
+	Actor         string `json:"actor"`
+	CorrelationID string `json:"correlation_id"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.Reason != "" && !IsReason(s.Reason) {
+		return errors.New("reason: must be one of receipt, sale, adjustment, transfer, write_off")
+	}
+
+	if s.Actor == "" {
+		s.Actor = r.Header.Get("X-Actor-ID")
+	}
+
+	if s.CorrelationID == "" {
+		s.CorrelationID = r.Header.Get("X-Correlation-ID")
+	}
+
+	if s.CorrelationID == "" {
+		s.CorrelationID = middleware.GetReqID(r.Context())
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID            string    `json:"id"`
+	InventoryID   string    `json:"inventory_id"`
+	Reason        string    `json:"reason"`
+	Delta         int       `json:"delta"`
+	Balance       int       `json:"balance"`
+	Actor         string    `json:"actor,omitempty"`
+	CorrelationID string    `json:"correlation_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:            data.ID,
+		InventoryID:   data.InventoryID,
+		Reason:        data.Reason,
+		Delta:         data.Delta,
+		Balance:       data.Balance,
+		Actor:         data.Actor,
+		CorrelationID: data.CorrelationID,
+		CreatedAt:     data.CreatedAt,
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
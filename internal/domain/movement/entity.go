@@ -0,0 +1,31 @@
+package movement
+
+import "time"
+
+const (
+	ReasonReceipt    = "receipt"
+	ReasonSale       = "sale"
+	ReasonAdjustment = "adjustment"
+	ReasonTransfer   = "transfer"
+	ReasonWriteOff   = "write_off"
+)
+
+type Entity struct {
+	CreatedAt     time.Time `db:"created_at"`
+	ID            string    `db:"id"`
+	InventoryID   string    `db:"inventory_id"`
+	Reason        string    `db:"reason"`
+	Delta         int       `db:"delta"`
+	Balance       int       `db:"balance"`
+	Actor         string    `db:"actor"`
+	CorrelationID string    `db:"correlation_id"`
+}
+
+// IsReason reports whether the value is one of the known reason codes.
+func IsReason(value string) bool {
+	switch value {
+	case ReasonReceipt, ReasonSale, ReasonAdjustment, ReasonTransfer, ReasonWriteOff:
+		return true
+	}
+	return false
+}
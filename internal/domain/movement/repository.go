@@ -0,0 +1,14 @@
+package movement
+
+import (
+	"context"
+	"time"
+)
+
+// Repository only reads the journal. Movements are written by the repositories
+// that change the stock, inside the same transaction as the quantity itself.
+// SelectChanged lists the inventories with a movement recorded since the given moment.
+type Repository interface {
+	Select(ctx context.Context, inventoryID string) (dest []Entity, err error)
+	SelectChanged(ctx context.Context, since time.Time) (dest []string, err error)
+}
@@ -0,0 +1,78 @@
+package price
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Request schedules a price, it takes effect right away without valid_from and
+// lasts until the next price without valid_to.
+type Request struct {
+	Price        string     `json:"price"`
+	PriceSpecial *string    `json:"price_special"`
+	ValidFrom    *time.Time `json:"valid_from"`
+	ValidTo      *time.Time `json:"valid_to"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if _, err := decimal.NewFromString(s.Price); err != nil {
+		return errors.New("price: must be a number")
+	}
+
+	if s.PriceSpecial != nil {
+		if _, err := decimal.NewFromString(*s.PriceSpecial); err != nil {
+			return errors.New("price_special: must be a number")
+		}
+	}
+
+	now := time.Now()
+	if s.ValidFrom != nil && s.ValidFrom.Before(now.Add(-time.Minute)) {
+		return errors.New("valid_from: cannot be in the past")
+	}
+
+	from := now
+	if s.ValidFrom != nil {
+		from = *s.ValidFrom
+	}
+
+	if s.ValidTo != nil && !s.ValidTo.After(from) {
+		return errors.New("valid_to: must be after valid_from")
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID           string     `json:"id"`
+	InventoryID  string     `json:"inventory_id"`
+	Price        string     `json:"price"`
+	PriceSpecial *string    `json:"price_special"`
+	ValidFrom    time.Time  `json:"valid_from"`
+	ValidTo      *time.Time `json:"valid_to"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// ParseFromEntities lists the history in the order the prices take effect, with their status at the time.
+func ParseFromEntities(data []Entity, at time.Time) (res []Response) {
+	Sort(data)
+	effective, _ := Effective(data, at)
+
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, Response{
+			ID:           object.ID,
+			InventoryID:  object.InventoryID,
+			Price:        object.Price,
+			PriceSpecial: object.PriceSpecial,
+			ValidFrom:    object.ValidFrom,
+			ValidTo:      object.ValidTo,
+			Status:       object.Status(effective, at),
+			CreatedAt:    object.CreatedAt,
+		})
+	}
+	return
+}
@@ -0,0 +1,76 @@
+package price
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	StatusScheduled  = "scheduled"
+	StatusCurrent    = "current"
+	StatusOverridden = "overridden"
+	StatusExpired    = "expired"
+)
+
+// Entity is a price of an inventory valid from ValidFrom until ValidTo, open
+// ended when ValidTo is nil. Once an inventory has price records they are the
+// history of its price and the stored price only follows the effective one.
+type Entity struct {
+	CreatedAt    time.Time  `db:"created_at"`
+	ValidFrom    time.Time  `db:"valid_from"`
+	ValidTo      *time.Time `db:"valid_to"`
+	ID           string     `db:"id"`
+	InventoryID  string     `db:"inventory_id"`
+	Price        string     `db:"price"`
+	PriceSpecial *string    `db:"price_special"`
+}
+
+// Covers reports whether the price is valid at the time.
+func (e Entity) Covers(at time.Time) bool {
+	return !e.ValidFrom.After(at) && (e.ValidTo == nil || e.ValidTo.After(at))
+}
+
+// Effective picks the price in effect at the time: of the prices covering it
+// the one starting last wins, so a promo overrides the regular price and a
+// later change overrides both.
+func Effective(data []Entity, at time.Time) (dest Entity, ok bool) {
+	for _, object := range data {
+		if !object.Covers(at) {
+			continue
+		}
+
+		if !ok || Less(dest, object) {
+			dest, ok = object, true
+		}
+	}
+	return
+}
+
+// Less orders the prices by start and then by creation, the last one wins a tie.
+func Less(a, b Entity) bool {
+	if !a.ValidFrom.Equal(b.ValidFrom) {
+		return a.ValidFrom.Before(b.ValidFrom)
+	}
+	return a.CreatedAt.Before(b.CreatedAt)
+}
+
+// Sort orders the prices the way they take effect.
+func Sort(data []Entity) {
+	sort.SliceStable(data, func(i, j int) bool {
+		return Less(data[i], data[j])
+	})
+}
+
+// Status tells where the price stands at the time against the effective one.
+func (e Entity) Status(effective Entity, at time.Time) string {
+	switch {
+	case e.ValidFrom.After(at):
+		return StatusScheduled
+	case e.ID == effective.ID:
+		return StatusCurrent
+	case e.Covers(at):
+		return StatusOverridden
+	default:
+		return StatusExpired
+	}
+}
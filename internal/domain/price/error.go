@@ -0,0 +1,9 @@
+package price
+
+import (
+	"errors"
+)
+
+var (
+	ErrorStarted = errors.New("price: only a price not yet in effect can be cancelled")
+)
@@ -0,0 +1,20 @@
+package price
+
+import (
+	"context"
+	"time"
+)
+
+// Repository keeps the price history of the inventories. Delete fails with
+// ErrorStarted for a price already in effect, history is never rewritten.
+// SelectBoundaries lists the inventories with a price starting or ending
+// after since and up to until. SelectMany gets the histories of several
+// inventories at once, ordered by inventory and then the way Select orders them.
+type Repository interface {
+	Select(ctx context.Context, inventoryID string) (dest []Entity, err error)
+	SelectMany(ctx context.Context, inventoryIDs []string) (dest []Entity, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Create(ctx context.Context, data Entity) (id string, err error)
+	Delete(ctx context.Context, id string) (err error)
+	SelectBoundaries(ctx context.Context, since, until time.Time) (dest []string, err error)
+}
@@ -0,0 +1,98 @@
+package rate
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"warehouse-service/internal/domain/currency"
+)
+
+// Columns are the columns of a rates file, in any order, other columns are ignored.
+var Columns = []string{"base", "quote", "rate", "valid_from"}
+
+// Read parses a rates file. A valid_from without a time starts at midnight UTC.
+// Unlike the inventory import one bad line fails the whole file, half a rate
+// table would convert some prices with stale rates.
+func Read(src io.Reader) (dest []Entity, err error) {
+	reader := csv.NewReader(src)
+	reader.FieldsPerRecord = -1
+
+	record, err := reader.Read()
+	if err == io.EOF {
+		err = ErrorInvalidHeader
+	}
+	if err != nil {
+		return
+	}
+
+	header := make(map[string]int)
+	for i, name := range record {
+		header[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+
+	for _, name := range Columns {
+		if _, ok := header[name]; !ok {
+			return nil, ErrorInvalidHeader
+		}
+	}
+
+	dest = make([]Entity, 0)
+	for {
+		record, err = reader.Read()
+		if err == io.EOF {
+			return dest, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		data, err := parseRecord(header, record)
+		if err != nil {
+			return nil, fmt.Errorf("rate: line %d: %w", line, err)
+		}
+		dest = append(dest, data)
+	}
+}
+
+func parseRecord(header map[string]int, record []string) (data Entity, err error) {
+	value := func(name string) string {
+		if i := header[name]; i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	data.Base = strings.ToUpper(value("base"))
+	if !currency.IsCode(data.Base) {
+		return data, errors.New("base: must be an ISO 4217 code")
+	}
+
+	data.Quote = strings.ToUpper(value("quote"))
+	if !currency.IsCode(data.Quote) {
+		return data, errors.New("quote: must be an ISO 4217 code")
+	}
+
+	if data.Base == data.Quote {
+		return data, errors.New("quote: must differ from base")
+	}
+
+	if data.Rate, err = decimal.NewFromString(value("rate")); err != nil || !data.Rate.IsPositive() {
+		return data, errors.New("rate: must be a positive number")
+	}
+
+	validFrom := value("valid_from")
+	if data.ValidFrom, err = time.Parse(time.RFC3339, validFrom); err != nil {
+		if data.ValidFrom, err = time.Parse("2006-01-02", validFrom); err != nil {
+			return data, errors.New("valid_from: must be a date or an RFC 3339 time")
+		}
+	}
+
+	return data, nil
+}
@@ -0,0 +1,38 @@
+package rate
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Filter narrows the rates down to a currency pair, a blank code matches any.
+type Filter struct {
+	Base  string
+	Quote string
+}
+
+type Response struct {
+	Base      string          `json:"base"`
+	Quote     string          `json:"quote"`
+	Rate      decimal.Decimal `json:"rate"`
+	ValidFrom time.Time       `json:"valid_from"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		Base:      data.Base,
+		Quote:     data.Quote,
+		Rate:      data.Rate,
+		ValidFrom: data.ValidFrom,
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
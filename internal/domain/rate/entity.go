@@ -0,0 +1,39 @@
+package rate
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Entity is the price of one unit of the Base currency in the Quote currency,
+// in effect from ValidFrom until the next rate of the pair starts.
+type Entity struct {
+	CreatedAt time.Time       `db:"created_at"`
+	ValidFrom time.Time       `db:"valid_from"`
+	Base      string          `db:"base"`
+	Quote     string          `db:"quote"`
+	Rate      decimal.Decimal `db:"rate"`
+}
+
+// Identity is the rate of a currency to itself.
+func Identity(code string, at time.Time) Entity {
+	return Entity{
+		ValidFrom: at,
+		Base:      code,
+		Quote:     code,
+		Rate:      decimal.NewFromInt(1),
+	}
+}
+
+// Invert returns the rate of the opposite pair, so only one direction has to be published.
+func (e Entity) Invert() Entity {
+	e.Base, e.Quote = e.Quote, e.Base
+	e.Rate = decimal.NewFromInt(1).Div(e.Rate)
+	return e
+}
+
+// Convert turns the amount in Base into Quote, rounded half away from zero to the decimals.
+func (e Entity) Convert(amount decimal.Decimal, decimals int32) decimal.Decimal {
+	return amount.Mul(e.Rate).Round(decimals)
+}
@@ -0,0 +1,12 @@
+package rate
+
+import (
+	"errors"
+)
+
+var (
+	ErrorUnknownCurrency = errors.New("rate: no currency has such code")
+	ErrorNoCurrency      = errors.New("rate: the store has no currency")
+	ErrorMissing         = errors.New("rate: no exchange rate between the currencies is in effect")
+	ErrorInvalidHeader   = errors.New("rate: the file must start with the base, quote, rate and valid_from columns")
+)
@@ -0,0 +1,15 @@
+package rate
+
+import (
+	"context"
+	"time"
+)
+
+// Repository keeps the exchange rates. Save replaces the rate of a pair starting
+// at the same time, so loading a file twice changes nothing. Get returns the rate
+// of the pair in effect at the time, storage.ErrorNotFound when there is none.
+type Repository interface {
+	Select(ctx context.Context, filter Filter) (dest []Entity, err error)
+	Get(ctx context.Context, base, quote string, at time.Time) (dest Entity, err error)
+	Save(ctx context.Context, data []Entity) (err error)
+}
@@ -0,0 +1,67 @@
+package reservation
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultTTL = 15 * 60
+	maxTTL     = 24 * 60 * 60
+)
+
+type Request struct {
+	Quantity int `json:"quantity"`
+	TTL      int `json:"ttl"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.Quantity <= 0 {
+		return errors.New("quantity: must be greater than zero")
+	}
+
+	if s.TTL < 0 || s.TTL > maxTTL {
+		return errors.New("ttl: must be between 0 and 86400 seconds")
+	}
+
+	if s.TTL == 0 {
+		s.TTL = defaultTTL
+	}
+
+	return nil
+}
+
+type Response struct {
+	ID          string    `json:"id"`
+	InventoryID string    `json:"inventory_id"`
+	Quantity    int       `json:"quantity"`
+	Status      string    `json:"status"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:          data.ID,
+		InventoryID: data.InventoryID,
+		Quantity:    data.Quantity,
+		Status:      data.Status,
+		ExpiresAt:   data.ExpiresAt,
+		CreatedAt:   data.CreatedAt,
+	}
+
+	// holds are swept in the background, so report them as expired right away
+	if data.Status == StatusHeld && !data.IsActive(time.Now()) {
+		res.Status = StatusExpired
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
@@ -0,0 +1,25 @@
+package reservation
+
+import "time"
+
+const (
+	StatusHeld      = "held"
+	StatusCommitted = "committed"
+	StatusReleased  = "released"
+	StatusExpired   = "expired"
+)
+
+type Entity struct {
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+	ExpiresAt   time.Time `db:"expires_at"`
+	ID          string    `db:"id"`
+	InventoryID string    `db:"inventory_id"`
+	Quantity    int       `db:"quantity"`
+	Status      string    `db:"status"`
+}
+
+// IsActive reports whether the reservation still holds stock at the given moment.
+func (e Entity) IsActive(now time.Time) bool {
+	return e.Status == StatusHeld && e.ExpiresAt.After(now)
+}
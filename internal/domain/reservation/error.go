@@ -0,0 +1,7 @@
+package reservation
+
+import (
+	"errors"
+)
+
+var ErrorNotHeld = errors.New("reservation: is not held")
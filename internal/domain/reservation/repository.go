@@ -0,0 +1,16 @@
+package reservation
+
+import (
+	"context"
+
+	"warehouse-service/internal/domain/movement"
+)
+
+type Repository interface {
+	Select(ctx context.Context, inventoryID string) (dest []Entity, err error)
+	Create(ctx context.Context, data Entity) (dest string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Commit(ctx context.Context, id string, change movement.Entity) (err error)
+	Release(ctx context.Context, id string) (err error)
+	Expire(ctx context.Context) (count int64, err error)
+}
@@ -1,6 +1,10 @@
 package schedule
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
 
 type Period struct {
 	Day  string `json:"day"`
@@ -13,6 +17,16 @@ type Request struct {
 	Periods  []Period `json:"periods" `
 }
 
+func (s *Request) Bind(r *http.Request) error {
+	for i, object := range s.Periods {
+		if err := object.Validate(); err != nil {
+			return fmt.Errorf("periods[%d].%w", i, err)
+		}
+	}
+
+	return nil
+}
+
 type Response struct {
 	IsActive bool     `json:"isActive" `
 	Periods  []Period `json:"periods" `
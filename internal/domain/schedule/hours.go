@@ -0,0 +1,180 @@
+package schedule
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Horizon is how many days ahead the next opening is looked for.
+const Horizon = 7
+
+var days = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// Status is whether the store is open at a time. ClosesAt is set while it is open,
+// NextOpenAt is the next time it opens after that time, nil when it does not open
+// within a week.
+type Status struct {
+	OpenNow    bool
+	ClosesAt   *time.Time
+	NextOpenAt *time.Time
+}
+
+// Hours is an opening on a date from From up to To, both HH:MM. When To is not
+// after From the opening runs past midnight into the next day.
+type Hours struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Validate checks the times of the hours.
+func (h Hours) Validate() error {
+	if _, ok := ParseClock(h.From); !ok {
+		return errors.New("from: must be a time between 00:00 and 24:00")
+	}
+
+	if _, ok := ParseClock(h.To); !ok {
+		return errors.New("to: must be a time between 00:00 and 24:00")
+	}
+
+	return nil
+}
+
+// Validate checks the day and the times of the period.
+func (p Period) Validate() error {
+	if _, ok := ParseDay(p.Day); !ok {
+		return errors.New("day: must be the English name of a weekday")
+	}
+
+	return Hours{From: p.From, To: p.To}.Validate()
+}
+
+// Weekly returns the hours of the periods falling on the weekday of a date.
+func Weekly(periods []Period) func(date time.Time) []Hours {
+	return func(date time.Time) (dest []Hours) {
+		for _, object := range periods {
+			if day, ok := ParseDay(object.Day); ok && day == date.Weekday() {
+				dest = append(dest, Hours{From: object.From, To: object.To})
+			}
+		}
+		return
+	}
+}
+
+// interval is an opening from start up to end.
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// ParseDay returns the weekday of a day name in any case, ok is false for an unknown name.
+func ParseDay(name string) (day time.Weekday, ok bool) {
+	day, ok = days[strings.ToLower(strings.TrimSpace(name))]
+	return
+}
+
+// ParseClock returns the minutes since midnight of an HH:MM time, 24:00 included.
+func ParseClock(value string) (minutes int, ok bool) {
+	hours, rest, found := strings.Cut(value, ":")
+	if !found || len(hours) != 2 || len(rest) != 2 {
+		return 0, false
+	}
+
+	h, err := strconv.Atoi(hours)
+	if err != nil {
+		return 0, false
+	}
+
+	m, err := strconv.Atoi(rest)
+	if err != nil || h < 0 || m < 0 || m > 59 || h > 24 || h == 24 && m > 0 {
+		return 0, false
+	}
+
+	return h*60 + m, true
+}
+
+// Evaluate tells whether the store is open at the time, with the hours of each date
+// given by hoursOn and read in the location of the time. Hours ending at or before
+// their start run past midnight into the next day, so 22:00-02:00 on Friday closes
+// on Saturday night and 00:00-00:00 is open all day. Hours that cannot be read are skipped.
+func Evaluate(hoursOn func(date time.Time) []Hours, at time.Time) (dest Status) {
+	intervals := openings(hoursOn, at)
+
+	for _, object := range intervals {
+		if object.end.After(at) && !object.start.After(at) {
+			dest.OpenNow = true
+			closesAt := object.end
+			dest.ClosesAt = &closesAt
+			continue
+		}
+
+		if object.start.After(at) {
+			nextOpenAt := object.start
+			dest.NextOpenAt = &nextOpenAt
+			break
+		}
+	}
+
+	return
+}
+
+// openings lays the hours out from the day before the time, for the hours of that day
+// may still run, up to the horizon, and merges the ones that overlap or touch.
+func openings(hoursOn func(date time.Time) []Hours, at time.Time) (dest []interval) {
+	year, month, day := at.Date()
+
+	intervals := make([]interval, 0)
+	for offset := -1; offset <= Horizon; offset++ {
+		date := time.Date(year, month, day+offset, 0, 0, 0, 0, at.Location())
+
+		for _, object := range hoursOn(date) {
+			from, ok := ParseClock(object.From)
+			if !ok {
+				continue
+			}
+
+			to, ok := ParseClock(object.To)
+			if !ok {
+				continue
+			}
+
+			end := time.Date(year, month, day+offset, 0, to, 0, 0, at.Location())
+			if to <= from {
+				end = time.Date(year, month, day+offset+1, 0, to, 0, 0, at.Location())
+			}
+
+			intervals = append(intervals, interval{
+				start: time.Date(year, month, day+offset, 0, from, 0, 0, at.Location()),
+				end:   end,
+			})
+		}
+	}
+
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].start.Before(intervals[j].start)
+	})
+
+	dest = make([]interval, 0, len(intervals))
+	for _, object := range intervals {
+		last := len(dest) - 1
+		if last >= 0 && !object.start.After(dest[last].end) {
+			if object.end.After(dest[last].end) {
+				dest[last].end = object.end
+			}
+			continue
+		}
+		dest = append(dest, object)
+	}
+
+	return
+}
@@ -2,7 +2,10 @@ package schedule
 
 import "context"
 
+// Repository keeps one schedule per store. SelectByStores reads the schedules
+// of several stores at once, a store without one has nothing in it.
 type Repository interface {
+	SelectByStores(ctx context.Context, storeIDs []string) (dest []Entity, err error)
 	Create(ctx context.Context, data Entity) (dest string, err error)
 	Get(ctx context.Context, storeID string) (dest *Entity, err error)
 	Update(ctx context.Context, id string, data *Entity) (err error)
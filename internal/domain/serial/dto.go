@@ -0,0 +1,79 @@
+package serial
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"warehouse-service/internal/domain/movement"
+)
+
+// Request registers units in stock, they count towards the inventory quantity right away.
+type Request struct {
+	Numbers []string `json:"numbers"`
+	movement.Request
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if len(s.Numbers) == 0 {
+		return errors.New("numbers: cannot be empty")
+	}
+
+	numbers := make(map[string]bool, len(s.Numbers))
+	for _, number := range s.Numbers {
+		if number == "" {
+			return errors.New("numbers: cannot contain a blank number")
+		}
+
+		if numbers[number] {
+			return errors.New("numbers: must be listed once")
+		}
+		numbers[number] = true
+	}
+
+	return s.Request.Bind(r)
+}
+
+type StatusRequest struct {
+	Status string `json:"status"`
+	movement.Request
+}
+
+func (s *StatusRequest) Bind(r *http.Request) error {
+	if !IsStatus(s.Status) {
+		return errors.New("status: must be in_stock, reserved, sold or returned")
+	}
+
+	return s.Request.Bind(r)
+}
+
+type Response struct {
+	Number      string    `json:"number"`
+	InventoryID string    `json:"inventory_id"`
+	StoreID     string    `json:"store_id"`
+	ProductID   string    `json:"product_id"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		Number:      data.Number,
+		InventoryID: data.InventoryID,
+		StoreID:     data.StoreID,
+		ProductID:   data.ProductID,
+		Status:      data.Status,
+		CreatedAt:   data.CreatedAt,
+		UpdatedAt:   data.UpdatedAt,
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
@@ -0,0 +1,44 @@
+package serial
+
+import "time"
+
+const (
+	StatusInStock  = "in_stock"
+	StatusReserved = "reserved"
+	StatusSold     = "sold"
+	StatusReturned = "returned"
+)
+
+// transitions lists the statuses a unit can move to from each status.
+var transitions = map[string][]string{
+	StatusInStock:  {StatusReserved, StatusSold},
+	StatusReserved: {StatusInStock, StatusSold},
+	StatusSold:     {StatusReturned},
+	StatusReturned: {StatusInStock},
+}
+
+// Entity is one unit of a serialized inventory, the number is unique per product.
+type Entity struct {
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+	InventoryID string    `db:"inventory_id"`
+	StoreID     string    `db:"store_id"`
+	ProductID   string    `db:"product_id"`
+	Number      string    `db:"number"`
+	Status      string    `db:"status"`
+}
+
+// CanMove reports whether a unit in the from status can move to the to status.
+func CanMove(from, to string) bool {
+	for _, status := range transitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+func IsStatus(status string) bool {
+	_, ok := transitions[status]
+	return ok
+}
@@ -0,0 +1,10 @@
+package serial
+
+import (
+	"errors"
+)
+
+var (
+	ErrorDuplicate  = errors.New("serial: the number is already registered for the product")
+	ErrorTransition = errors.New("serial: the unit cannot move to this status")
+)
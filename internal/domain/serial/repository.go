@@ -0,0 +1,19 @@
+package serial
+
+import (
+	"context"
+
+	"warehouse-service/internal/domain/movement"
+)
+
+// Repository keeps the units of serialized inventories. Create and SetStatus
+// mark the inventory as serialized and set its quantity to the number of units
+// in stock, journaling the difference as a movement; no other write can change
+// the quantity of a serialized inventory. SelectByNumber finds the units with
+// the number in every store.
+type Repository interface {
+	Select(ctx context.Context, inventoryID, status string) (dest []Entity, err error)
+	SelectByNumber(ctx context.Context, number string) (dest []Entity, err error)
+	Create(ctx context.Context, inventoryID string, numbers []string, change movement.Entity) (balance int, err error)
+	SetStatus(ctx context.Context, inventoryID, number, status string, change movement.Entity) (balance int, err error)
+}
@@ -0,0 +1,47 @@
+package slot
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+type Response struct {
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Capacity  int       `json:"capacity"`
+	Booked    int       `json:"booked"`
+	Available int       `json:"available"`
+}
+
+type BookingRequest struct {
+	StartsAt  *time.Time `json:"starts_at"`
+	Reference *string    `json:"reference"`
+}
+
+func (s *BookingRequest) Bind(r *http.Request) error {
+	if s.StartsAt == nil {
+		return errors.New("starts_at: cannot be blank")
+	}
+
+	return nil
+}
+
+type BookingResponse struct {
+	ID        string    `json:"id"`
+	StoreID   string    `json:"store_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Reference *string   `json:"reference,omitempty"`
+}
+
+func ParseFromBooking(data Booking) (res BookingResponse) {
+	res = BookingResponse{
+		ID:        data.ID,
+		StoreID:   data.StoreID,
+		StartsAt:  data.StartsAt,
+		EndsAt:    data.EndsAt,
+		Reference: data.Reference,
+	}
+	return
+}
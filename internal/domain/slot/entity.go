@@ -0,0 +1,88 @@
+package slot
+
+import (
+	"sort"
+	"time"
+
+	"warehouse-service/internal/domain/delivery"
+	"warehouse-service/internal/domain/schedule"
+)
+
+// Slot is a window a delivery can be booked for, from StartsAt up to EndsAt.
+type Slot struct {
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+// Count is how many bookings a slot of a store holds.
+type Count struct {
+	StartsAt time.Time `db:"starts_at"`
+	Booked   int       `db:"booked"`
+}
+
+// Booking is one place taken in a slot.
+type Booking struct {
+	CreatedAt time.Time `db:"created_at"`
+	ID        string    `db:"id"`
+	StoreID   string    `db:"store_id"`
+	StartsAt  time.Time `db:"starts_at"`
+	EndsAt    time.Time `db:"ends_at"`
+	Reference *string   `db:"reference"`
+}
+
+// Generate cuts the delivery periods into slots of the length over the days from the
+// date on, read in the location of the date. A period ending at or before its start
+// runs past midnight, the part of a period too short for a whole slot is left out.
+func Generate(periods []delivery.Period, minutes int, from time.Time, days int) (dest []Slot) {
+	dest = make([]Slot, 0)
+	if minutes <= 0 {
+		return
+	}
+
+	year, month, day := from.Date()
+	seen := make(map[int64]bool)
+
+	for offset := 0; offset < days; offset++ {
+		date := time.Date(year, month, day+offset, 0, 0, 0, 0, from.Location())
+
+		for _, object := range periods {
+			weekday, ok := schedule.ParseDay(object.Day)
+			if !ok || weekday != date.Weekday() {
+				continue
+			}
+
+			start, ok := schedule.ParseClock(object.From)
+			if !ok {
+				continue
+			}
+
+			end, ok := schedule.ParseClock(object.To)
+			if !ok {
+				continue
+			}
+
+			if end <= start {
+				end += 24 * 60
+			}
+
+			for ; start+minutes <= end; start += minutes {
+				startsAt := time.Date(year, month, day+offset, 0, start, 0, 0, from.Location())
+				if seen[startsAt.Unix()] {
+					continue
+				}
+				seen[startsAt.Unix()] = true
+
+				dest = append(dest, Slot{
+					StartsAt: startsAt,
+					EndsAt:   time.Date(year, month, day+offset, 0, start+minutes, 0, 0, from.Location()),
+				})
+			}
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].StartsAt.Before(dest[j].StartsAt)
+	})
+
+	return
+}
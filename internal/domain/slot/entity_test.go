@@ -0,0 +1,126 @@
+package slot
+
+import (
+	"testing"
+	"time"
+
+	"warehouse-service/internal/domain/delivery"
+)
+
+func TestGenerate(t *testing.T) {
+	almaty, err := time.LoadLocation("Asia/Almaty")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a Monday
+	monday := time.Date(2026, time.October, 19, 0, 0, 0, 0, almaty)
+
+	tests := []struct {
+		name    string
+		periods []delivery.Period
+		minutes int
+		from    time.Time
+		days    int
+		want    []string
+	}{
+		{
+			name:    "period cut into whole slots",
+			periods: []delivery.Period{{Day: "monday", From: "10:00", To: "12:00"}},
+			minutes: 60,
+			from:    monday,
+			days:    1,
+			want:    []string{"2026-10-19 10:00-11:00", "2026-10-19 11:00-12:00"},
+		},
+		{
+			name:    "part too short for a slot is left out",
+			periods: []delivery.Period{{Day: "monday", From: "10:00", To: "11:30"}},
+			minutes: 60,
+			from:    monday,
+			days:    1,
+			want:    []string{"2026-10-19 10:00-11:00"},
+		},
+		{
+			name:    "period running past midnight",
+			periods: []delivery.Period{{Day: "monday", From: "22:00", To: "01:00"}},
+			minutes: 60,
+			from:    monday,
+			days:    1,
+			want:    []string{"2026-10-19 22:00-23:00", "2026-10-19 23:00-00:00", "2026-10-20 00:00-01:00"},
+		},
+		{
+			name:    "slot crossing midnight",
+			periods: []delivery.Period{{Day: "monday", From: "23:30", To: "00:30"}},
+			minutes: 60,
+			from:    monday,
+			days:    1,
+			want:    []string{"2026-10-19 23:30-00:30"},
+		},
+		{
+			name:    "period ending at midnight",
+			periods: []delivery.Period{{Day: "monday", From: "22:00", To: "00:00"}},
+			minutes: 60,
+			from:    monday,
+			days:    1,
+			want:    []string{"2026-10-19 22:00-23:00", "2026-10-19 23:00-00:00"},
+		},
+		{
+			name: "night period overlapping the next day is not repeated",
+			periods: []delivery.Period{
+				{Day: "monday", From: "23:00", To: "01:00"},
+				{Day: "tuesday", From: "00:00", To: "01:00"},
+			},
+			minutes: 60,
+			from:    monday,
+			days:    2,
+			want:    []string{"2026-10-19 23:00-00:00", "2026-10-20 00:00-01:00"},
+		},
+		{
+			name:    "slots come in order across the days",
+			periods: []delivery.Period{{Day: "tuesday", From: "09:00", To: "10:00"}, {Day: "monday", From: "23:00", To: "00:00"}},
+			minutes: 60,
+			from:    monday,
+			days:    2,
+			want:    []string{"2026-10-19 23:00-00:00", "2026-10-20 09:00-10:00"},
+		},
+		{
+			name:    "days without a period",
+			periods: []delivery.Period{{Day: "sunday", From: "09:00", To: "10:00"}},
+			minutes: 60,
+			from:    monday,
+			days:    3,
+			want:    []string{},
+		},
+		{
+			name:    "no slot length",
+			periods: []delivery.Period{{Day: "monday", From: "09:00", To: "10:00"}},
+			minutes: 0,
+			from:    monday,
+			days:    1,
+			want:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slots := Generate(tt.periods, tt.minutes, tt.from, tt.days)
+
+			got := make([]string, len(slots))
+			for i, object := range slots {
+				if object.StartsAt.Location() != almaty {
+					t.Errorf("slot %d is in %s, want %s", i, object.StartsAt.Location(), almaty)
+				}
+				got[i] = object.StartsAt.Format("2006-01-02 15:04") + "-" + object.EndsAt.Format("15:04")
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("slots are %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("slots are %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package slot
+
+import (
+	"errors"
+)
+
+var (
+	ErrorFull    = errors.New("slot: no places left in the slot")
+	ErrorUnknown = errors.New("slot: the store delivers in no slot starting at that time")
+)
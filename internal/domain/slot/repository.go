@@ -0,0 +1,15 @@
+package slot
+
+import (
+	"context"
+	"time"
+)
+
+// Repository keeps the bookings of the delivery slots. Book takes a place in the slot
+// only while it holds fewer bookings than the capacity, failing with ErrorFull
+// otherwise, so two bookings racing for the last place never both succeed.
+type Repository interface {
+	SelectBooked(ctx context.Context, storeID string, from, to time.Time) (dest []Count, err error)
+	Book(ctx context.Context, data Booking, capacity int) (id string, err error)
+	Cancel(ctx context.Context, storeID, id string) (err error)
+}
@@ -2,13 +2,17 @@ package store
 
 import (
 	"errors"
+	"fmt"
 	"github.com/shopspring/decimal"
 	"net/http"
+	"time"
 	"warehouse-service/internal/domain/city"
 	"warehouse-service/internal/domain/country"
 	"warehouse-service/internal/domain/currency"
 	"warehouse-service/internal/domain/delivery"
+	"warehouse-service/internal/domain/rate"
 	"warehouse-service/internal/domain/schedule"
+	"warehouse-service/pkg/geo"
 )
 
 type Request struct {
@@ -18,6 +22,8 @@ type Request struct {
 	Name       string            `json:"name"`
 	Address    string            `json:"address"`
 	Location   string            `json:"location"`
+	Latitude   *float64          `json:"latitude"`
+	Longitude  *float64          `json:"longitude"`
 	Rating     decimal.Decimal   `json:"rating"`
 	IsActive   bool              `json:"isActive"`
 	City       city.Response     `json:"city"`
@@ -48,6 +54,35 @@ func (s *Request) Bind(r *http.Request) error {
 		return errors.New("location: cannot be blank")
 	}
 
+	if (s.Latitude == nil) != (s.Longitude == nil) {
+		return errors.New("latitude, longitude: must be set together")
+	}
+
+	if s.Latitude != nil {
+		if err := (geo.Point{Lat: *s.Latitude, Lng: *s.Longitude}).Validate(); err != nil {
+			return err
+		}
+	}
+
+	if s.Currency.Code != "" && !currency.IsCode(s.Currency.Code) {
+		return errors.New("currency.code: must be an ISO 4217 code")
+	}
+
+	if s.City.TimeZone != "" && !country.IsTimeZone(s.City.TimeZone) {
+		return errors.New("city.time_zone: must be a zone of the tz database")
+	}
+
+	// the schedule and the delivery are checked as their own requests would be
+	scheduleRequest := schedule.Request(s.Schedule)
+	if err := scheduleRequest.Bind(r); err != nil {
+		return fmt.Errorf("schedule.%w", err)
+	}
+
+	deliveryRequest := delivery.Request(s.Delivery)
+	if err := deliveryRequest.Bind(r); err != nil {
+		return fmt.Errorf("delivery.%w", err)
+	}
+
 	return nil
 }
 
@@ -57,6 +92,8 @@ type Response struct {
 	Name       string             `json:"name"`
 	Address    string             `json:"address"`
 	Location   string             `json:"location"`
+	Latitude   *float64           `json:"latitude,omitempty"`
+	Longitude  *float64           `json:"longitude,omitempty"`
 	Rating     decimal.Decimal    `json:"rating"`
 	IsActive   bool               `json:"isActive"`
 	City       *city.Response     `json:"city,omitempty"`
@@ -65,6 +102,26 @@ type Response struct {
 	Delivery   *delivery.Response `json:"delivery,omitempty"`
 	Currency   *currency.Response `json:"currency,omitempty"`
 	Area       *delivery.Area     `json:"area,omitempty"`
+
+	ExchangeRate *rate.Response `json:"exchange_rate,omitempty"`
+
+	OpenNow    *bool      `json:"open_now,omitempty"`
+	ClosesAt   *time.Time `json:"closes_at,omitempty"`
+	NextOpenAt *time.Time `json:"next_open_at,omitempty"`
+
+	// TimeZone is the zone the hours of the store are read in, UTCOffset its offset now.
+	TimeZone  string `json:"time_zone,omitempty"`
+	UTCOffset string `json:"utc_offset,omitempty"`
+
+	// DistanceKm is how far the store is from the point of a nearby search.
+	DistanceKm *float64 `json:"distance_km,omitempty"`
+}
+
+// Filter narrows the stores down, a nil OpenNow matches open and closed stores
+// and a nil DeliversTo stores delivering anywhere or nowhere.
+type Filter struct {
+	OpenNow    *bool
+	DeliversTo *geo.Point
 }
 
 func ParseFromEntity(data Entity) (res Response) {
@@ -74,6 +131,8 @@ func ParseFromEntity(data Entity) (res Response) {
 		Name:       *data.Name,
 		Address:    *data.Address,
 		Location:   *data.Location,
+		Latitude:   data.Latitude,
+		Longitude:  data.Longitude,
 		Rating:     *data.Rating,
 	}
 	return
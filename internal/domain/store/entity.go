@@ -3,6 +3,7 @@ package store
 import (
 	"github.com/shopspring/decimal"
 	"time"
+	"warehouse-service/pkg/geo"
 )
 
 type Entity struct {
@@ -16,4 +17,15 @@ type Entity struct {
 	Location   *string          `db:"location"`
 	Rating     *decimal.Decimal `db:"rating"`
 	IsActive   *bool            `db:"is_active"`
+	Latitude   *float64         `db:"latitude"`
+	Longitude  *float64         `db:"longitude"`
+}
+
+// Point returns the coordinates of the store, ok is false when it has none.
+func (e Entity) Point() (dest geo.Point, ok bool) {
+	if e.Latitude == nil || e.Longitude == nil {
+		return
+	}
+
+	return geo.Point{Lat: *e.Latitude, Lng: *e.Longitude}, true
 }
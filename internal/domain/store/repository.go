@@ -2,8 +2,11 @@ package store
 
 import "context"
 
+// Repository keeps the stores. SelectByIDs reads several stores at once and
+// leaves out the ids of no store.
 type Repository interface {
 	Select(ctx context.Context) (dest []Entity, err error)
+	SelectByIDs(ctx context.Context, ids []string) (dest []Entity, err error)
 	Create(ctx context.Context, data Entity) (dest string, err error)
 	Get(ctx context.Context, id string) (dest Entity, err error)
 	Update(ctx context.Context, id string, data Entity) (err error)
@@ -0,0 +1,123 @@
+package transfer
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"warehouse-service/internal/domain/movement"
+)
+
+type LineRequest struct {
+	ProductID string `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+}
+
+type Request struct {
+	SourceStoreID      string        `json:"source_store_id"`
+	DestinationStoreID string        `json:"destination_store_id"`
+	Lines              []LineRequest `json:"lines"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.SourceStoreID == "" {
+		return errors.New("source_store_id: cannot be blank")
+	}
+
+	if s.DestinationStoreID == "" {
+		return errors.New("destination_store_id: cannot be blank")
+	}
+
+	if s.SourceStoreID == s.DestinationStoreID {
+		return errors.New("destination_store_id: must differ from source_store_id")
+	}
+
+	if len(s.Lines) == 0 {
+		return errors.New("lines: cannot be empty")
+	}
+
+	return validateLines(s.Lines)
+}
+
+// ReceiveRequest lists the quantities that arrived, no lines receive everything still in transit.
+type ReceiveRequest struct {
+	Lines []LineRequest `json:"lines"`
+	movement.Request
+}
+
+func (s *ReceiveRequest) Bind(r *http.Request) error {
+	if err := validateLines(s.Lines); err != nil {
+		return err
+	}
+
+	return s.Request.Bind(r)
+}
+
+func validateLines(lines []LineRequest) error {
+	products := make(map[string]bool)
+	for _, line := range lines {
+		if line.ProductID == "" {
+			return errors.New("product_id: cannot be blank")
+		}
+
+		if line.Quantity <= 0 {
+			return errors.New("quantity: must be greater than zero")
+		}
+
+		if products[line.ProductID] {
+			return errors.New("product_id: must be listed once")
+		}
+		products[line.ProductID] = true
+	}
+
+	return nil
+}
+
+type LineResponse struct {
+	ProductID string `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+	InTransit int    `json:"in_transit"`
+	Received  int    `json:"received"`
+}
+
+type Response struct {
+	ID                 string         `json:"id"`
+	SourceStoreID      string         `json:"source_store_id"`
+	DestinationStoreID string         `json:"destination_store_id"`
+	Status             string         `json:"status"`
+	Lines              []LineResponse `json:"lines"`
+	ShippedAt          *time.Time     `json:"shipped_at"`
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ID:                 data.ID,
+		SourceStoreID:      data.SourceStoreID,
+		DestinationStoreID: data.DestinationStoreID,
+		Status:             data.Status,
+		Lines:              make([]LineResponse, 0, len(data.Lines)),
+		ShippedAt:          data.ShippedAt,
+		CreatedAt:          data.CreatedAt,
+		UpdatedAt:          data.UpdatedAt,
+	}
+
+	for _, line := range data.Lines {
+		res.Lines = append(res.Lines, LineResponse{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			InTransit: data.InTransit(line),
+			Received:  line.Received,
+		})
+	}
+	return
+}
+
+func ParseFromEntities(data []Entity) (res []Response) {
+	res = make([]Response, 0)
+	for _, object := range data {
+		res = append(res, ParseFromEntity(object))
+	}
+	return
+}
@@ -0,0 +1,44 @@
+package transfer
+
+import "time"
+
+const (
+	StatusCreated           = "created"
+	StatusInTransit         = "in_transit"
+	StatusPartiallyReceived = "partially_received"
+	StatusReceived          = "received"
+)
+
+type Entity struct {
+	CreatedAt          time.Time  `db:"created_at"`
+	UpdatedAt          time.Time  `db:"updated_at"`
+	ShippedAt          *time.Time `db:"shipped_at"`
+	ID                 string     `db:"id"`
+	SourceStoreID      string     `db:"source_store_id"`
+	DestinationStoreID string     `db:"destination_store_id"`
+	Status             string     `db:"status"`
+	Lines              []Line     `db:"-"`
+}
+
+// Line is the quantity of one product on the transfer, all of it leaves the
+// source on shipping and Received tells how much has reached the destination.
+type Line struct {
+	TransferID string `db:"transfer_id"`
+	ProductID  string `db:"product_id"`
+	Quantity   int    `db:"quantity"`
+	Received   int    `db:"received"`
+}
+
+// InTransit reports the quantity that has left the source but not reached the destination.
+func (e Entity) InTransit(line Line) int {
+	if e.Status == StatusCreated {
+		return 0
+	}
+	return line.Quantity - line.Received
+}
+
+// Filter narrows the listing down, a store matches either end of the transfer.
+type Filter struct {
+	StoreID string
+	Status  string
+}
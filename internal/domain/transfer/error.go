@@ -0,0 +1,12 @@
+package transfer
+
+import (
+	"errors"
+)
+
+var (
+	ErrorMerchantMismatch = errors.New("transfer: stores belong to different merchants")
+	ErrorNotCreated       = errors.New("transfer: has already been shipped")
+	ErrorNotInTransit     = errors.New("transfer: is not in transit")
+	ErrorOverReceipt      = errors.New("transfer: received quantity exceeds the quantity in transit")
+)
@@ -0,0 +1,21 @@
+package transfer
+
+import (
+	"context"
+
+	"warehouse-service/internal/domain/movement"
+)
+
+// Repository moves the stock of a transfer between the inventories of the two
+// stores. Ship takes every line out of the source at once and fails with
+// inventory.ErrorInsufficientStock when one of them is short. Receive adds the
+// given quantities to the destination, creating its inventory if needed, and
+// fails with ErrorOverReceipt for more than what is in transit. Both journal
+// a movement for every inventory they change.
+type Repository interface {
+	Select(ctx context.Context, filter Filter) (dest []Entity, err error)
+	Create(ctx context.Context, data Entity) (dest string, err error)
+	Get(ctx context.Context, id string) (dest Entity, err error)
+	Ship(ctx context.Context, id string, change movement.Entity) (err error)
+	Receive(ctx context.Context, id string, lines []Line, change movement.Entity) (err error)
+}
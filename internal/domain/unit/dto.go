@@ -0,0 +1,69 @@
+package unit
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/shopspring/decimal"
+)
+
+type Request struct {
+	BaseUnit string        `json:"base_unit"`
+	Units    []UnitRequest `json:"units"`
+}
+
+// UnitRequest is an alternate unit, e.g. {"code": "box", "factor": 12} or {"code": "kg", "factor": 1000} over grams.
+type UnitRequest struct {
+	Code   string          `json:"code"`
+	Factor decimal.Decimal `json:"factor"`
+}
+
+func (s *Request) Bind(r *http.Request) error {
+	if s.BaseUnit == "" {
+		return errors.New("base_unit: cannot be blank")
+	}
+
+	codes := map[string]bool{s.BaseUnit: true}
+	for _, object := range s.Units {
+		if object.Code == "" {
+			return errors.New("units: code cannot be blank")
+		}
+
+		if codes[object.Code] {
+			return errors.New("units: " + object.Code + " is listed twice or is the base unit")
+		}
+		codes[object.Code] = true
+
+		if !object.Factor.IsPositive() {
+			return errors.New("units: factor must be greater than zero")
+		}
+	}
+
+	return nil
+}
+
+type Response struct {
+	ProductID string         `json:"product_id"`
+	BaseUnit  string         `json:"base_unit"`
+	Units     []UnitResponse `json:"units"`
+}
+
+type UnitResponse struct {
+	Code   string          `json:"code"`
+	Factor decimal.Decimal `json:"factor"`
+}
+
+func ParseFromEntity(data Entity) (res Response) {
+	res = Response{
+		ProductID: data.ProductID,
+		BaseUnit:  data.BaseUnit,
+		Units:     make([]UnitResponse, 0),
+	}
+	for _, object := range data.Units {
+		res.Units = append(res.Units, UnitResponse{
+			Code:   object.Code,
+			Factor: object.Factor,
+		})
+	}
+	return
+}
@@ -0,0 +1,74 @@
+package unit
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PresentDecimals is the number of decimals a quantity is rounded to when shown in an alternate unit.
+const PresentDecimals = 3
+
+// Entity is the unit of measure model of a product. Quantities are kept in whole
+// base units, alternate units convert to them by a factor, so with grams as the
+// base unit and a kilogram of 1000 grams, 2.35 kg is kept as 2350. A product
+// without a model counts in plain whole units.
+type Entity struct {
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+	ProductID string    `db:"product_id"`
+	BaseUnit  string    `db:"base_unit"`
+	Units     []Unit    `db:"-"`
+}
+
+// Unit is an alternate unit of the product, Factor is the number of base units in one of it.
+type Unit struct {
+	ProductID string          `db:"product_id"`
+	Code      string          `db:"code"`
+	Factor    decimal.Decimal `db:"factor"`
+}
+
+// ToBase converts a quantity given in the unit into whole base units, a blank code stands for the base unit.
+func (e Entity) ToBase(quantity decimal.Decimal, code string) (int, error) {
+	factor, err := e.factor(code)
+	if err != nil {
+		return 0, err
+	}
+
+	base := quantity.Mul(factor)
+	if !base.IsInteger() {
+		return 0, ErrorFraction
+	}
+
+	return int(base.IntPart()), nil
+}
+
+// FromBase shows whole base units in the unit, rounded to PresentDecimals.
+func (e Entity) FromBase(quantity int, code string) (string, error) {
+	factor, err := e.factor(code)
+	if err != nil {
+		return "", err
+	}
+
+	return decimal.NewFromInt(int64(quantity)).DivRound(factor, PresentDecimals).String(), nil
+}
+
+// Has tells whether the product counts in the unit, a blank code stands for the base unit.
+func (e Entity) Has(code string) bool {
+	_, err := e.factor(code)
+	return err == nil
+}
+
+func (e Entity) factor(code string) (decimal.Decimal, error) {
+	if code == "" || code == e.BaseUnit {
+		return decimal.NewFromInt(1), nil
+	}
+
+	for _, object := range e.Units {
+		if object.Code == code {
+			return object.Factor, nil
+		}
+	}
+
+	return decimal.Decimal{}, ErrorUnknownUnit
+}
@@ -0,0 +1,10 @@
+package unit
+
+import (
+	"errors"
+)
+
+var (
+	ErrorUnknownUnit = errors.New("unit: the product has no such unit of measure")
+	ErrorFraction    = errors.New("unit: the quantity is not a whole number of base units")
+)
@@ -0,0 +1,13 @@
+package unit
+
+import (
+	"context"
+)
+
+// Repository keeps a unit of measure model per product, Save replaces the
+// alternate units of the product as a whole.
+type Repository interface {
+	Get(ctx context.Context, productID string) (dest Entity, err error)
+	Save(ctx context.Context, data Entity) (err error)
+	Delete(ctx context.Context, productID string) (err error)
+}
@@ -68,10 +68,28 @@ func WithHTTPHandler() Configuration {
 
 		storeHandler := http.NewStoreHandler(h.dependencies.WarehouseService)
 		inventoryHandler := http.NewInventoryHandler(h.dependencies.WarehouseService)
+		alertHandler := http.NewAlertHandler(h.dependencies.WarehouseService)
+		transferHandler := http.NewTransferHandler(h.dependencies.WarehouseService)
+		countHandler := http.NewCountHandler(h.dependencies.WarehouseService)
+		serialHandler := http.NewSerialHandler(h.dependencies.WarehouseService)
+		unitHandler := http.NewUnitHandler(h.dependencies.WarehouseService)
+		rateHandler := http.NewRateHandler(h.dependencies.WarehouseService)
+		countryHandler := http.NewCountryHandler(h.dependencies.WarehouseService)
+		cityHandler := http.NewCityHandler(h.dependencies.WarehouseService)
+		currencyHandler := http.NewCurrencyHandler(h.dependencies.WarehouseService)
 
 		h.HTTP.Route("/api/v1", func(r chi.Router) {
 			r.Mount("/stores", storeHandler.Routes())
 			r.Mount("/inventories", inventoryHandler.Routes())
+			r.Mount("/alerts", alertHandler.Routes())
+			r.Mount("/transfers", transferHandler.Routes())
+			r.Mount("/counts", countHandler.Routes())
+			r.Mount("/serials", serialHandler.LookupRoutes())
+			r.Mount("/products", unitHandler.Routes())
+			r.Mount("/rates", rateHandler.Routes())
+			r.Mount("/countries", countryHandler.Routes())
+			r.Mount("/cities", cityHandler.Routes())
+			r.Mount("/currencies", currencyHandler.Routes())
 		})
 
 		return
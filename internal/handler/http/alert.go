@@ -0,0 +1,92 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"warehouse-service/internal/domain/alert"
+	"warehouse-service/internal/service/warehouse"
+	"warehouse-service/pkg/server/response"
+	"warehouse-service/pkg/storage"
+)
+
+type alertHandler struct {
+	AlertService *warehouse.Service
+}
+
+func NewAlertHandler(s *warehouse.Service) *alertHandler {
+	return &alertHandler{AlertService: s}
+}
+
+func (h *alertHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Post("/acknowledge", h.acknowledge)
+	})
+
+	return r
+}
+
+// List of stock alerts
+//
+//	@Summary		List of stock alerts
+//	@Description	Alerts are raised in the background when the quantity falls below quantity_min or rises above quantity_max.
+//	@Tags			alerts
+//	@Accept			json
+//	@Produce		json
+//	@Param			state		query		string	false	"open, acknowledged or resolved"
+//	@Param			kind		query		string	false	"low_stock or overstock"
+//	@Param			store_id	query		string	false	"only the alerts of the store"
+//	@Success		200			{array}		response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/alerts [get]
+func (h *alertHandler) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter := alert.Filter{
+		State:   query.Get("state"),
+		Kind:    query.Get("kind"),
+		StoreID: query.Get("store_id"),
+	}
+
+	res, err := h.AlertService.ListAlerts(r.Context(), filter)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Acknowledge the open alert
+//
+//	@Summary	Acknowledge the open alert
+//	@Tags		alerts
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string	true	"path param"
+//	@Success	200	{object}	response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	409	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/alerts/{id}/acknowledge [post]
+func (h *alertHandler) acknowledge(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.AlertService.AcknowledgeAlert(r.Context(), id)
+	if err != nil {
+		switch err {
+		case storage.ErrorNotFound:
+			response.NotFound(w, r, err)
+		case alert.ErrorNotOpen:
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
@@ -0,0 +1,287 @@
+package http
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"strconv"
+	"time"
+	"warehouse-service/internal/domain/calendar"
+	"warehouse-service/internal/service/warehouse"
+	"warehouse-service/pkg/server/response"
+	"warehouse-service/pkg/storage"
+)
+
+type calendarHandler struct {
+	CalendarService *warehouse.Service
+}
+
+func NewCalendarHandler(s *warehouse.Service) *calendarHandler {
+	return &calendarHandler{CalendarService: s}
+}
+
+// SeasonRoutes serves the seasons of one store, it is mounted under /stores/{id}.
+func (h *calendarHandler) SeasonRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.listSeasons)
+	r.Post("/", h.addSeason)
+	r.Delete("/{seasonID}", h.deleteSeason)
+
+	return r
+}
+
+// ExceptionRoutes serves the exceptions of one store, it is mounted under /stores/{id}.
+func (h *calendarHandler) ExceptionRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.listExceptions)
+	r.Put("/{date}", h.saveException)
+	r.Delete("/{date}", h.deleteException)
+
+	return r
+}
+
+// List of seasonal schedules of the store
+//
+//	@Summary	List of seasonal schedules of the store
+//	@Tags		calendars
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string	true	"path param"
+//	@Success	200	{array}		response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/stores/{id}/seasons [get]
+func (h *calendarHandler) listSeasons(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.CalendarService.ListSeasons(r.Context(), id)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Add a seasonal schedule to the store
+//
+//	@Summary		Add a seasonal schedule to the store
+//	@Description	From valid_from through valid_to the weekly periods of the season replace the default schedule. Where seasons overlap the one starting last wins.
+//	@Tags			calendars
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"path param"
+//	@Param			request	body		calendar.SeasonRequest	true	"body param"
+//	@Success		200		{object}	response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/stores/{id}/seasons [post]
+func (h *calendarHandler) addSeason(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := calendar.SeasonRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.CalendarService.AddSeason(r.Context(), id, req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Delete a seasonal schedule of the store
+//
+//	@Summary	Delete a seasonal schedule of the store
+//	@Tags		calendars
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path	string	true	"path param"
+//	@Param		seasonID	path	string	true	"path param"
+//	@Success	200
+//	@Failure	404	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/stores/{id}/seasons/{seasonID} [delete]
+func (h *calendarHandler) deleteSeason(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	seasonID := chi.URLParam(r, "seasonID")
+
+	if err := h.CalendarService.DeleteSeason(r.Context(), id, seasonID); err != nil {
+		h.error(w, r, err)
+		return
+	}
+}
+
+// List of date exceptions of the store
+//
+//	@Summary	List of date exceptions of the store
+//	@Tags		calendars
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string	true	"path param"
+//	@Param		from	query		string	false	"first date, YYYY-MM-DD, today by default"
+//	@Param		to		query		string	false	"last date, YYYY-MM-DD, a year after from by default"
+//	@Success	200		{array}		response.Object
+//	@Failure	400		{object}	response.Object
+//	@Failure	404		{object}	response.Object
+//	@Failure	500		{object}	response.Object
+//	@Router		/stores/{id}/exceptions [get]
+func (h *calendarHandler) listExceptions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	from, err := calendar.ParseDate(time.Now().Format(calendar.DateLayout))
+	if value := r.URL.Query().Get("from"); value != "" {
+		if from, err = calendar.ParseDate(value); err != nil {
+			response.BadRequest(w, r, errors.New("from: must be a date written as YYYY-MM-DD"), nil)
+			return
+		}
+	}
+
+	to := from.AddDate(1, 0, 0)
+	if value := r.URL.Query().Get("to"); value != "" {
+		if to, err = calendar.ParseDate(value); err != nil {
+			response.BadRequest(w, r, errors.New("to: must be a date written as YYYY-MM-DD"), nil)
+			return
+		}
+	}
+
+	res, err := h.CalendarService.ListExceptions(r.Context(), id, from, to)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Set the hours of the store on a date
+//
+//	@Summary		Set the hours of the store on a date
+//	@Description	The exception replaces the seasonal and the default schedule on that date, the store is either closed or open for the hours given.
+//	@Tags			calendars
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"path param"
+//	@Param			date	path		string						true	"date, YYYY-MM-DD"
+//	@Param			request	body		calendar.ExceptionRequest	true	"body param"
+//	@Success		200		{object}	response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/stores/{id}/exceptions/{date} [put]
+func (h *calendarHandler) saveException(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	date, err := calendar.ParseDate(chi.URLParam(r, "date"))
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	req := calendar.ExceptionRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.CalendarService.SaveException(r.Context(), id, date, req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Delete the exception of the store on a date
+//
+//	@Summary	Delete the exception of the store on a date
+//	@Tags		calendars
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path	string	true	"path param"
+//	@Param		date	path	string	true	"date, YYYY-MM-DD"
+//	@Success	200
+//	@Failure	400	{object}	response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/stores/{id}/exceptions/{date} [delete]
+func (h *calendarHandler) deleteException(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	date, err := calendar.ParseDate(chi.URLParam(r, "date"))
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	if err = h.CalendarService.DeleteException(r.Context(), id, date); err != nil {
+		h.error(w, r, err)
+		return
+	}
+}
+
+// Effective hours of the store by date
+//
+//	@Summary		Effective hours of the store by date
+//	@Description	The hours of each date come from its exception, then from the season covering it, then from the default schedule. The source tells which one applied, it is blank when none did.
+//	@Tags			calendars
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"path param"
+//	@Param			date	query		string	false	"first date, YYYY-MM-DD, today in the time zone of the store by default"
+//	@Param			days	query		int		false	"number of days, 7 by default"
+//	@Success		200		{array}		response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/stores/{id}/hours [get]
+func (h *calendarHandler) hours(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var from *time.Time
+	if value := r.URL.Query().Get("date"); value != "" {
+		date, err := calendar.ParseDate(value)
+		if err != nil {
+			response.BadRequest(w, r, errors.New("date: must be a date written as YYYY-MM-DD"), nil)
+			return
+		}
+		from = &date
+	}
+
+	days := 7
+	if value := r.URL.Query().Get("days"); value != "" {
+		var err error
+		if days, err = strconv.Atoi(value); err != nil || days < 1 || days > 366 {
+			response.BadRequest(w, r, errors.New("days: must be a number between 1 and 366"), nil)
+			return
+		}
+	}
+
+	res, err := h.CalendarService.GetHours(r.Context(), id, from, days)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+func (h *calendarHandler) error(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case storage.ErrorNotFound:
+		response.NotFound(w, r, err)
+	case calendar.ErrorDate:
+		response.BadRequest(w, r, err, nil)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
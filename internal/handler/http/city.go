@@ -0,0 +1,170 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"warehouse-service/internal/domain/city"
+	"warehouse-service/internal/service/warehouse"
+	"warehouse-service/pkg/server/response"
+	"warehouse-service/pkg/storage"
+)
+
+type cityHandler struct {
+	CityService *warehouse.Service
+}
+
+func NewCityHandler(s *warehouse.Service) *cityHandler {
+	return &cityHandler{CityService: s}
+}
+
+func (h *cityHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.update)
+		r.Delete("/", h.delete)
+	})
+
+	return r
+}
+
+// List of cities from the database
+//
+//	@Summary	List of cities from the database
+//	@Tags		cities
+//	@Accept		json
+//	@Produce	json
+//	@Param		country_id	query		string	false	"only the cities of the country"
+//	@Success	200			{array}		response.Object
+//	@Failure	500			{object}	response.Object
+//	@Router		/cities [get]
+func (h *cityHandler) list(w http.ResponseWriter, r *http.Request) {
+	res, err := h.CityService.ListCities(r.Context(), r.URL.Query().Get("country_id"))
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Add a new city to the database
+//
+//	@Summary	Add a new city to the database
+//	@Tags		cities
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		city.Request	true	"body param"
+//	@Success	200		{object}	response.Object
+//	@Failure	400		{object}	response.Object
+//	@Failure	409		{object}	response.Object
+//	@Failure	500		{object}	response.Object
+//	@Router		/cities [post]
+func (h *cityHandler) add(w http.ResponseWriter, r *http.Request) {
+	req := city.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.CityService.AddCity(r.Context(), req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Read the city from the database
+//
+//	@Summary	Read the city from the database
+//	@Tags		cities
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string	true	"path param"
+//	@Success	200	{object}	response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/cities/{id} [get]
+func (h *cityHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.CityService.GetCity(r.Context(), id)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Update the city in the database
+//
+//	@Summary	Update the city in the database
+//	@Description	A blank time_zone keeps the zone stored.
+//	@Tags		cities
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path	string			true	"path param"
+//	@Param		request	body	city.Request	true	"body param"
+//	@Success	200
+//	@Failure	400	{object}	response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	409	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/cities/{id} [put]
+func (h *cityHandler) update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := city.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	if err := h.CityService.UpdateCity(r.Context(), id, req); err != nil {
+		h.error(w, r, err)
+		return
+	}
+}
+
+// Delete the city from the database
+//
+//	@Summary	Delete the city from the database
+//	@Description	A city still having stores cannot be deleted.
+//	@Tags		cities
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path	string	true	"path param"
+//	@Success	200
+//	@Failure	404	{object}	response.Object
+//	@Failure	409	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/cities/{id} [delete]
+func (h *cityHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.CityService.DeleteCity(r.Context(), id); err != nil {
+		h.error(w, r, err)
+		return
+	}
+}
+
+func (h *cityHandler) error(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case storage.ErrorNotFound:
+		response.NotFound(w, r, err)
+	case city.ErrorUnknownCountry:
+		response.BadRequest(w, r, err, nil)
+	case city.ErrorDuplicate, city.ErrorInUse:
+		response.Conflict(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,243 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"warehouse-service/internal/domain/count"
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/service/warehouse"
+	"warehouse-service/pkg/server/response"
+	"warehouse-service/pkg/storage"
+)
+
+type countHandler struct {
+	CountService *warehouse.Service
+}
+
+func NewCountHandler(s *warehouse.Service) *countHandler {
+	return &countHandler{CountService: s}
+}
+
+func (h *countHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Post("/entries", h.record)
+		r.Get("/variance", h.variance)
+		r.Post("/approve", h.approve)
+		r.Post("/cancel", h.cancel)
+	})
+
+	return r
+}
+
+// List of count sessions
+//
+//	@Summary	List of count sessions
+//	@Tags		counts
+//	@Accept		json
+//	@Produce	json
+//	@Param		store_id	query		string	false	"only the sessions of the store"
+//	@Param		status		query		string	false	"open, approved or cancelled"
+//	@Success	200			{array}		response.Object
+//	@Failure	500			{object}	response.Object
+//	@Router		/counts [get]
+func (h *countHandler) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter := count.Filter{
+		StoreID: query.Get("store_id"),
+		Status:  query.Get("status"),
+	}
+
+	res, err := h.CountService.ListCounts(r.Context(), filter)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Open a count session over a store or some of its products
+//
+//	@Summary		Open a count session over a store or some of its products
+//	@Description	The expected quantities are taken when the session opens and its inventories are marked with count_id until it is closed.
+//	@Tags			counts
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		count.Request	true	"body param"
+//	@Success		200		{object}	response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		409		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/counts [post]
+func (h *countHandler) add(w http.ResponseWriter, r *http.Request) {
+	req := count.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.CountService.AddCount(r.Context(), req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Read the count session
+//
+//	@Summary	Read the count session
+//	@Tags		counts
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string	true	"path param"
+//	@Success	200	{object}	response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/counts/{id} [get]
+func (h *countHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.CountService.GetCount(r.Context(), id)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Record a counted quantity
+//
+//	@Summary		Record a counted quantity
+//	@Description	The counted quantity of a product sums the latest entry of every counter, the counter defaults to the X-Actor-ID header.
+//	@Tags			counts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"path param"
+//	@Param			request	body		count.EntryRequest	true	"body param"
+//	@Success		200		{object}	response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		409		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/counts/{id}/entries [post]
+func (h *countHandler) record(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := count.EntryRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.CountService.RecordCount(r.Context(), id, req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Report the variances of the count session
+//
+//	@Summary		Report the variances of the count session
+//	@Description	The variance compares the counted quantity with the quantity on record at the time of the last count, moved is what sales and receipts changed between the opening of the session and that count.
+//	@Tags			counts
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"path param"
+//	@Success		200	{object}	response.Object
+//	@Failure		404	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/counts/{id}/variance [get]
+func (h *countHandler) variance(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.CountService.GetCountVariance(r.Context(), id)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Approve the count session and post its variances as adjustments
+//
+//	@Summary	Approve the count session and post its variances as adjustments
+//	@Tags		counts
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string	true	"path param"
+//	@Success	200	{object}	response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	409	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/counts/{id}/approve [post]
+func (h *countHandler) approve(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := movement.Request{}
+	if err := req.Bind(r); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.CountService.ApproveCount(r.Context(), id, req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Cancel the count session without adjusting anything
+//
+//	@Summary	Cancel the count session without adjusting anything
+//	@Tags		counts
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string	true	"path param"
+//	@Success	200	{object}	response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	409	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/counts/{id}/cancel [post]
+func (h *countHandler) cancel(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.CountService.CancelCount(r.Context(), id)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+func (h *countHandler) error(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case storage.ErrorNotFound:
+		response.NotFound(w, r, err)
+	case count.ErrorNotCounted:
+		response.BadRequest(w, r, err, nil)
+	case count.ErrorNotOpen, count.ErrorNothingToCount, count.ErrorAlreadyCounting, inventory.ErrorInsufficientStock, inventory.ErrorSerialized:
+		response.Conflict(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
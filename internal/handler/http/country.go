@@ -0,0 +1,166 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"warehouse-service/internal/domain/country"
+	"warehouse-service/internal/service/warehouse"
+	"warehouse-service/pkg/server/response"
+	"warehouse-service/pkg/storage"
+)
+
+type countryHandler struct {
+	CountryService *warehouse.Service
+}
+
+func NewCountryHandler(s *warehouse.Service) *countryHandler {
+	return &countryHandler{CountryService: s}
+}
+
+func (h *countryHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.update)
+		r.Delete("/", h.delete)
+	})
+
+	return r
+}
+
+// List of countries from the database
+//
+//	@Summary	List of countries from the database
+//	@Tags		countries
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{array}		response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/countries [get]
+func (h *countryHandler) list(w http.ResponseWriter, r *http.Request) {
+	res, err := h.CountryService.ListCountries(r.Context())
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Add a new country to the database
+//
+//	@Summary	Add a new country to the database
+//	@Tags		countries
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		country.Request	true	"body param"
+//	@Success	200		{object}	response.Object
+//	@Failure	400		{object}	response.Object
+//	@Failure	409		{object}	response.Object
+//	@Failure	500		{object}	response.Object
+//	@Router		/countries [post]
+func (h *countryHandler) add(w http.ResponseWriter, r *http.Request) {
+	req := country.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.CountryService.AddCountry(r.Context(), req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Read the country from the database
+//
+//	@Summary	Read the country from the database
+//	@Tags		countries
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string	true	"path param"
+//	@Success	200	{object}	response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/countries/{id} [get]
+func (h *countryHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.CountryService.GetCountry(r.Context(), id)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Update the country in the database
+//
+//	@Summary	Update the country in the database
+//	@Tags		countries
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path	string			true	"path param"
+//	@Param		request	body	country.Request	true	"body param"
+//	@Success	200
+//	@Failure	400	{object}	response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	409	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/countries/{id} [put]
+func (h *countryHandler) update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := country.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	if err := h.CountryService.UpdateCountry(r.Context(), id, req); err != nil {
+		h.error(w, r, err)
+		return
+	}
+}
+
+// Delete the country from the database
+//
+//	@Summary	Delete the country from the database
+//	@Description	A country still having cities or a currency cannot be deleted.
+//	@Tags		countries
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path	string	true	"path param"
+//	@Success	200
+//	@Failure	404	{object}	response.Object
+//	@Failure	409	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/countries/{id} [delete]
+func (h *countryHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.CountryService.DeleteCountry(r.Context(), id); err != nil {
+		h.error(w, r, err)
+		return
+	}
+}
+
+func (h *countryHandler) error(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case storage.ErrorNotFound:
+		response.NotFound(w, r, err)
+	case country.ErrorDuplicate, country.ErrorInUse:
+		response.Conflict(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
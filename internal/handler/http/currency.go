@@ -0,0 +1,168 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"warehouse-service/internal/domain/currency"
+	"warehouse-service/internal/service/warehouse"
+	"warehouse-service/pkg/server/response"
+	"warehouse-service/pkg/storage"
+)
+
+type currencyHandler struct {
+	CurrencyService *warehouse.Service
+}
+
+func NewCurrencyHandler(s *warehouse.Service) *currencyHandler {
+	return &currencyHandler{CurrencyService: s}
+}
+
+func (h *currencyHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.update)
+		r.Delete("/", h.delete)
+	})
+
+	return r
+}
+
+// List of currencies from the database
+//
+//	@Summary	List of currencies from the database
+//	@Tags		currencies
+//	@Accept		json
+//	@Produce	json
+//	@Success	200	{array}		response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/currencies [get]
+func (h *currencyHandler) list(w http.ResponseWriter, r *http.Request) {
+	res, err := h.CurrencyService.ListCurrencies(r.Context())
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Add a new currency to the database
+//
+//	@Summary	Add a new currency to the database
+//	@Description	A country has one currency, a second one for the same country fails with 409 like a taken sign or code.
+//	@Tags		currencies
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		currency.Request	true	"body param"
+//	@Success	200		{object}	response.Object
+//	@Failure	400		{object}	response.Object
+//	@Failure	409		{object}	response.Object
+//	@Failure	500		{object}	response.Object
+//	@Router		/currencies [post]
+func (h *currencyHandler) add(w http.ResponseWriter, r *http.Request) {
+	req := currency.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.CurrencyService.AddCurrency(r.Context(), req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Read the currency from the database
+//
+//	@Summary	Read the currency from the database
+//	@Tags		currencies
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string	true	"path param"
+//	@Success	200	{object}	response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/currencies/{id} [get]
+func (h *currencyHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.CurrencyService.GetCurrency(r.Context(), id)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Update the currency in the database
+//
+//	@Summary	Update the currency in the database
+//	@Description	A blank code keeps the code stored. Moving the currency to a country that already has one fails with 409 like a taken sign or code.
+//	@Tags		currencies
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path	string			true	"path param"
+//	@Param		request	body	currency.Request	true	"body param"
+//	@Success	200
+//	@Failure	400	{object}	response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	409	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/currencies/{id} [put]
+func (h *currencyHandler) update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := currency.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	if err := h.CurrencyService.UpdateCurrency(r.Context(), id, req); err != nil {
+		h.error(w, r, err)
+		return
+	}
+}
+
+// Delete the currency from the database
+//
+//	@Summary	Delete the currency from the database
+//	@Tags		currencies
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path	string	true	"path param"
+//	@Success	200
+//	@Failure	404	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/currencies/{id} [delete]
+func (h *currencyHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.CurrencyService.DeleteCurrency(r.Context(), id); err != nil {
+		h.error(w, r, err)
+		return
+	}
+}
+
+func (h *currencyHandler) error(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case storage.ErrorNotFound:
+		response.NotFound(w, r, err)
+	case currency.ErrorUnknownCountry:
+		response.BadRequest(w, r, err, nil)
+	case currency.ErrorDuplicate:
+		response.Conflict(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
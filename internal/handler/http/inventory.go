@@ -1,11 +1,19 @@
 package http
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"net/http"
 	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/location"
+	"warehouse-service/internal/domain/lot"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/rate"
+	"warehouse-service/internal/domain/unit"
 	"warehouse-service/internal/service/warehouse"
+	"warehouse-service/pkg/money"
 	"warehouse-service/pkg/server/response"
 	"warehouse-service/pkg/storage"
 )
@@ -23,11 +31,23 @@ func (h *inventoryHandler) Routes() chi.Router {
 
 	r.Get("/", h.list)
 	r.Post("/", h.add)
+	r.Post("/import", h.importCSV)
+	r.Get("/export", h.export)
 
 	r.Route("/{id}", func(r chi.Router) {
 		r.Get("/", h.get)
 		r.Put("/", h.update)
 		r.Delete("/", h.delete)
+
+		r.Post("/adjust", h.adjust)
+		r.Get("/movements", h.movements)
+		r.Get("/lots", h.lots)
+		r.Post("/lots", h.receiveLot)
+
+		r.Mount("/reservations", NewReservationHandler(h.InventoryService).Routes())
+		r.Mount("/serials", NewSerialHandler(h.InventoryService).Routes())
+		r.Mount("/bins", NewLocationHandler(h.InventoryService).BinRoutes())
+		r.Mount("/prices", NewPriceHandler(h.InventoryService).Routes())
 	})
 
 	return r
@@ -39,13 +59,31 @@ func (h *inventoryHandler) Routes() chi.Router {
 //	@Tags		inventories
 //	@Accept		json
 //	@Produce	json
+//	@Param		unit			query		string	false	"unit of measure of the quantities, an inventory of a product without it is shown in its base unit"
+//	@Param		currency		query		string	false	"ISO 4217 code of the currency of the prices, the currency of the store by default"
+//	@Param		Accept-Language	header		string	false	"language the price_display is written in, English by default"
 //	@Success	200				{array}		response.Object
+//	@Failure	400				{object}	response.Object
+//	@Failure	409				{object}	response.Object
 //	@Failure	500				{object}	response.Object
 //	@Router		/inventories 	[get]
 func (h *inventoryHandler) list(w http.ResponseWriter, r *http.Request) {
-	res, err := h.InventoryService.ListInventory(r.Context())
+	view := inventory.View{
+		Unit:     r.URL.Query().Get("unit"),
+		Currency: r.URL.Query().Get("currency"),
+		Locale:   money.ParseLocale(r.Header.Get("Accept-Language")),
+	}
+
+	res, err := h.InventoryService.ListInventory(r.Context(), view)
 	if err != nil {
-		response.InternalServerError(w, r, err)
+		switch err {
+		case rate.ErrorUnknownCurrency:
+			response.BadRequest(w, r, err, nil)
+		case rate.ErrorNoCurrency, rate.ErrorMissing:
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
 		return
 	}
 
@@ -61,6 +99,7 @@ func (h *inventoryHandler) list(w http.ResponseWriter, r *http.Request) {
 //	@Param		request	body		inventory.Request	true	"body param"
 //	@Success	200		{object}	response.Object
 //	@Failure	400		{object}	response.Object
+//	@Failure	409		{object}	response.Object
 //	@Failure	500		{object}	response.Object
 //	@Router		/inventories [post]
 func (h *inventoryHandler) add(w http.ResponseWriter, r *http.Request) {
@@ -72,35 +111,192 @@ func (h *inventoryHandler) add(w http.ResponseWriter, r *http.Request) {
 
 	res, err := h.InventoryService.AddInventory(r.Context(), req)
 	if err != nil {
+		switch err {
+		case unit.ErrorUnknownUnit, unit.ErrorFraction:
+			response.BadRequest(w, r, err, req)
+		case inventory.ErrorDuplicate:
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Import inventories from a CSV file
+//
+//	@Summary		Import inventories from a CSV file
+//	@Description	The header must name the store_id, product_id, quantity and price columns, quantity_min, quantity_max, price_special, price_previous, is_available and allow_backorder are optional.
+//	@Tags			inventories
+//	@Accept			text/csv
+//	@Produce		json
+//	@Param			reason	query		string	false	"movement reason, adjustment by default"
+//	@Param			request	body		string	true	"CSV file"
+//	@Success		200		{object}	response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/inventories/import [post]
+func (h *inventoryHandler) importCSV(w http.ResponseWriter, r *http.Request) {
+	req := movement.Request{Reason: r.URL.Query().Get("reason")}
+	if err := req.Bind(r); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.InventoryService.ImportInventory(r.Context(), r.Body, req)
+	if err != nil && err != inventory.ErrorInvalidHeader {
 		response.InternalServerError(w, r, err)
 		return
 	}
 
+	if err == inventory.ErrorInvalidHeader {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
 	response.OK(w, r, res)
 }
 
+// exportFlushRows is the number of exported rows sent to the client at once.
+const exportFlushRows = 500
+
+// Export inventories as CSV or NDJSON
+//
+//	@Summary		Export inventories as CSV or NDJSON
+//	@Description	Streams the inventories ordered by store and product. The CSV can be imported back, an error after the first row cuts the stream short.
+//	@Tags			inventories
+//	@Produce		text/csv
+//	@Produce		application/x-ndjson
+//	@Param			format		query		string	false	"csv (default) or ndjson"
+//	@Param			store_id	query		string	false	"only the inventories of the store"
+//	@Param			catalog_id	query		string	false	"only the inventories of the catalog"
+//	@Success		200			{string}	string
+//	@Failure		400			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/inventories/export [get]
+func (h *inventoryHandler) export(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	format := query.Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "ndjson" {
+		response.BadRequest(w, r, inventory.ErrorInvalidFormat, nil)
+		return
+	}
+
+	filter := inventory.Filter{
+		StoreID:   query.Get("store_id"),
+		CatalogID: query.Get("catalog_id"),
+	}
+
+	// the headers are sent with the first row, so a failing query still gets a JSON error
+	var (
+		rows    int
+		started bool
+	)
+	writer := csv.NewWriter(w)
+	encoder := json.NewEncoder(w)
+
+	start := func() error {
+		started = true
+		if format == "ndjson" {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+			return nil
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="inventories.csv"`)
+		w.WriteHeader(http.StatusOK)
+		return writer.Write(inventory.ExportColumns)
+	}
+
+	flush := func() error {
+		writer.Flush()
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		return writer.Error()
+	}
+
+	err := h.InventoryService.ExportInventory(r.Context(), filter, func(res inventory.Response) (err error) {
+		if !started {
+			if err = start(); err != nil {
+				return
+			}
+		}
+
+		if format == "ndjson" {
+			err = encoder.Encode(res)
+		} else {
+			err = writer.Write(res.Record())
+		}
+		if err != nil {
+			return
+		}
+
+		if rows++; rows%exportFlushRows == 0 {
+			err = flush()
+		}
+
+		return
+	})
+	if err != nil && !started {
+		response.InternalServerError(w, r, err)
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	if !started {
+		if err = start(); err != nil {
+			return
+		}
+	}
+	flush()
+}
+
 // Read the inventory from the database
 //
 //	@Summary	Read the inventory from the database
 //	@Tags		inventories
 //	@Accept		json
 //	@Produce	json
-//	@Param		id	path		string	true	"path param"
-//	@Success	200	{object}	response.Object
-//	@Failure	404	{object}	response.Object
-//	@Failure	500	{object}	response.Object
+//	@Param		id				path		string	true	"path param"
+//	@Param		unit			query		string	false	"unit of measure of the quantities, the base unit by default"
+//	@Param		currency		query		string	false	"ISO 4217 code of the currency of the prices, the currency of the store by default"
+//	@Param		Accept-Language	header		string	false	"language the price_display is written in, English by default"
+//	@Success	200				{object}	response.Object
+//	@Failure	400				{object}	response.Object
+//	@Failure	404				{object}	response.Object
+//	@Failure	409				{object}	response.Object
+//	@Failure	500				{object}	response.Object
 //	@Router		/inventories/{id} [get]
 func (h *inventoryHandler) get(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	res, err := h.InventoryService.GetInventory(r.Context(), id)
-	if err != nil && err != storage.ErrorNotFound {
-		response.InternalServerError(w, r, err)
-		return
+	view := inventory.View{
+		Unit:     r.URL.Query().Get("unit"),
+		Currency: r.URL.Query().Get("currency"),
+		Locale:   money.ParseLocale(r.Header.Get("Accept-Language")),
 	}
 
-	if err == storage.ErrorNotFound {
-		response.NotFound(w, r, err)
+	res, err := h.InventoryService.GetInventory(r.Context(), id, view)
+	if err != nil {
+		switch err {
+		case storage.ErrorNotFound:
+			response.NotFound(w, r, err)
+		case unit.ErrorUnknownUnit, rate.ErrorUnknownCurrency:
+			response.BadRequest(w, r, err, nil)
+		case rate.ErrorNoCurrency, rate.ErrorMissing:
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
 		return
 	}
 
@@ -118,6 +314,7 @@ func (h *inventoryHandler) get(w http.ResponseWriter, r *http.Request) {
 //	@Success	200
 //	@Failure	400	{object}	response.Object
 //	@Failure	404	{object}	response.Object
+//	@Failure	409	{object}	response.Object
 //	@Failure	500	{object}	response.Object
 //	@Router		/inventories/{id} [put]
 func (h *inventoryHandler) update(w http.ResponseWriter, r *http.Request) {
@@ -130,13 +327,17 @@ func (h *inventoryHandler) update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err := h.InventoryService.UpdateInventory(r.Context(), id, req)
-	if err != nil && err != storage.ErrorNotFound {
-		response.InternalServerError(w, r, err)
-		return
-	}
-
-	if err == storage.ErrorNotFound {
-		response.NotFound(w, r, err)
+	if err != nil {
+		switch err {
+		case storage.ErrorNotFound:
+			response.NotFound(w, r, err)
+		case unit.ErrorUnknownUnit, unit.ErrorFraction:
+			response.BadRequest(w, r, err, req)
+		case inventory.ErrorSerialized:
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
 		return
 	}
 }
@@ -166,3 +367,138 @@ func (h *inventoryHandler) delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// Adjust the inventory quantity by a signed delta
+//
+//	@Summary		Adjust the inventory quantity by a signed delta
+//	@Description	With a bin_id the stock is received into or picked from that bin.
+//	@Tags			inventories
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"path param"
+//	@Param			request	body		inventory.AdjustRequest	true	"body param"
+//	@Success		200		{object}	response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		409		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/inventories/{id}/adjust [post]
+func (h *inventoryHandler) adjust(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := inventory.AdjustRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.InventoryService.AdjustInventory(r.Context(), id, req)
+	switch err {
+	case nil:
+		response.OK(w, r, res)
+	case storage.ErrorNotFound:
+		response.NotFound(w, r, err)
+	case location.ErrorNotBin, unit.ErrorUnknownUnit, unit.ErrorFraction:
+		response.BadRequest(w, r, err, nil)
+	case inventory.ErrorInsufficientStock, inventory.ErrorSerialized:
+		response.Conflict(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
+
+// List of stock movements of the inventory
+//
+//	@Summary	List of stock movements of the inventory
+//	@Tags		inventories
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string	true	"path param"
+//	@Success	200	{array}		response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/inventories/{id}/movements [get]
+func (h *inventoryHandler) movements(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.InventoryService.ListMovements(r.Context(), id)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// List of lots of the inventory
+//
+//	@Summary		List of lots of the inventory
+//	@Description	Lots are listed first-expiry-first-out, the order sales, reservations and other decrements take stock from them.
+//	@Tags			inventories
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"path param"
+//	@Success		200	{array}		response.Object
+//	@Failure		404	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/inventories/{id}/lots [get]
+func (h *inventoryHandler) lots(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.InventoryService.ListLots(r.Context(), id)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Receive stock into a lot of the inventory
+//
+//	@Summary	Receive stock into a lot of the inventory
+//	@Tags		inventories
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string		true	"path param"
+//	@Param		request	body		lot.Request	true	"body param"
+//	@Success	200		{array}		response.Object
+//	@Failure	400		{object}	response.Object
+//	@Failure	404		{object}	response.Object
+//	@Failure	409		{object}	response.Object
+//	@Failure	500		{object}	response.Object
+//	@Router		/inventories/{id}/lots [post]
+func (h *inventoryHandler) receiveLot(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := lot.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.InventoryService.ReceiveLot(r.Context(), id, req)
+	if err != nil {
+		switch err {
+		case storage.ErrorNotFound:
+			response.NotFound(w, r, err)
+		case lot.ErrorExpiryMismatch, inventory.ErrorSerialized:
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
@@ -0,0 +1,199 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/location"
+	"warehouse-service/internal/service/warehouse"
+	"warehouse-service/pkg/server/response"
+	"warehouse-service/pkg/storage"
+)
+
+type locationHandler struct {
+	LocationService *warehouse.Service
+}
+
+func NewLocationHandler(s *warehouse.Service) *locationHandler {
+	return &locationHandler{LocationService: s}
+}
+
+// Routes serves the locations of one store, it is mounted under /stores/{id}.
+func (h *locationHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+	r.Delete("/{locationID}", h.delete)
+
+	return r
+}
+
+// BinRoutes serves the bin stock of one inventory, it is mounted under /inventories/{id}.
+func (h *locationHandler) BinRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.bins)
+	r.Post("/move", h.move)
+
+	return r
+}
+
+// List of zones, aisles and bins of the store
+//
+//	@Summary	List of zones, aisles and bins of the store
+//	@Tags		locations
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string	true	"path param"
+//	@Success	200	{array}		response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/stores/{id}/locations [get]
+func (h *locationHandler) list(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.LocationService.ListLocations(r.Context(), id)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Add a zone, an aisle or a bin to the store
+//
+//	@Summary		Add a zone, an aisle or a bin to the store
+//	@Description	Zones sit right under the store, aisles in a zone and bins in an aisle. Stock only goes into bins.
+//	@Tags			locations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"path param"
+//	@Param			request	body		location.Request	true	"body param"
+//	@Success		200		{object}	response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		409		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/stores/{id}/locations [post]
+func (h *locationHandler) add(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := location.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.LocationService.AddLocation(r.Context(), id, req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Delete an empty location of the store
+//
+//	@Summary	Delete an empty location of the store
+//	@Tags		locations
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path	string	true	"path param"
+//	@Param		locationID	path	string	true	"path param"
+//	@Success	200
+//	@Failure	404	{object}	response.Object
+//	@Failure	409	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/stores/{id}/locations/{locationID} [delete]
+func (h *locationHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	locationID := chi.URLParam(r, "locationID")
+
+	if err := h.LocationService.DeleteLocation(r.Context(), id, locationID); err != nil {
+		h.error(w, r, err)
+		return
+	}
+}
+
+// Split of the inventory quantity over its bins
+//
+//	@Summary	Split of the inventory quantity over its bins
+//	@Tags		locations
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string	true	"path param"
+//	@Success	200	{object}	response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/inventories/{id}/bins [get]
+func (h *locationHandler) bins(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.LocationService.ListBins(r.Context(), id)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Move stock of the inventory between bins
+//
+//	@Summary		Move stock of the inventory between bins
+//	@Description	A blank from_bin_id puts unassigned stock away, a blank to_bin_id takes stock out of its bin. The quantity does not change.
+//	@Tags			locations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"path param"
+//	@Param			request	body		location.MoveRequest	true	"body param"
+//	@Success		200		{object}	response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		409		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/inventories/{id}/bins/move [post]
+func (h *locationHandler) move(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := location.MoveRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.LocationService.MoveStock(r.Context(), id, req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+func (h *locationHandler) error(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case storage.ErrorNotFound:
+		response.NotFound(w, r, err)
+	case location.ErrorParent, location.ErrorNotBin:
+		response.BadRequest(w, r, err, nil)
+	case location.ErrorDuplicate, location.ErrorNotEmpty, location.ErrorUnassigned, inventory.ErrorInsufficientStock:
+		response.Conflict(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"warehouse-service/internal/domain/price"
+	"warehouse-service/internal/service/warehouse"
+	"warehouse-service/pkg/server/response"
+	"warehouse-service/pkg/storage"
+)
+
+type priceHandler struct {
+	PriceService *warehouse.Service
+}
+
+func NewPriceHandler(s *warehouse.Service) *priceHandler {
+	return &priceHandler{PriceService: s}
+}
+
+// Routes serves the price history of one inventory, it is mounted under /inventories/{id}.
+func (h *priceHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+	r.Delete("/{priceID}", h.delete)
+
+	return r
+}
+
+// Price history of the inventory
+//
+//	@Summary		Price history of the inventory
+//	@Description	Prices are listed in the order they take effect, each one scheduled, current, overridden by a later price or expired.
+//	@Tags			prices
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"path param"
+//	@Success		200	{array}		response.Object
+//	@Failure		404	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/inventories/{id}/prices [get]
+func (h *priceHandler) list(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.PriceService.ListPrices(r.Context(), id)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Schedule a price of the inventory
+//
+//	@Summary		Schedule a price of the inventory
+//	@Description	Of the prices valid at a time the one starting last is in effect, so a promo with valid_to overrides the regular price until it ends.
+//	@Tags			prices
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"path param"
+//	@Param			request	body		price.Request	true	"body param"
+//	@Success		200		{array}		response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/inventories/{id}/prices [post]
+func (h *priceHandler) add(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := price.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.PriceService.SchedulePrice(r.Context(), id, req)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Cancel a price not yet in effect
+//
+//	@Summary	Cancel a price not yet in effect
+//	@Tags		prices
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path	string	true	"path param"
+//	@Param		priceID	path	string	true	"path param"
+//	@Success	200
+//	@Failure	404	{object}	response.Object
+//	@Failure	409	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/inventories/{id}/prices/{priceID} [delete]
+func (h *priceHandler) delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	priceID := chi.URLParam(r, "priceID")
+
+	err := h.PriceService.CancelPrice(r.Context(), id, priceID)
+	if err != nil {
+		switch err {
+		case storage.ErrorNotFound:
+			response.NotFound(w, r, err)
+		case price.ErrorStarted:
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+}
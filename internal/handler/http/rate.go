@@ -0,0 +1,66 @@
+package http
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"strings"
+	"warehouse-service/internal/domain/currency"
+	"warehouse-service/internal/domain/rate"
+	"warehouse-service/internal/service/warehouse"
+	"warehouse-service/pkg/server/response"
+)
+
+type rateHandler struct {
+	RateService *warehouse.Service
+}
+
+func NewRateHandler(s *warehouse.Service) *rateHandler {
+	return &rateHandler{RateService: s}
+}
+
+func (h *rateHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+
+	return r
+}
+
+// List of exchange rates
+//
+//	@Summary		List of exchange rates
+//	@Description	The rates are loaded from the file set by RATES_FILE, one unit of the base currency costs rate units of the quote currency from valid_from on.
+//	@Tags			rates
+//	@Accept			json
+//	@Produce		json
+//	@Param			base	query		string	false	"ISO 4217 code of the base currency"
+//	@Param			quote	query		string	false	"ISO 4217 code of the quote currency"
+//	@Success		200		{array}		response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/rates [get]
+func (h *rateHandler) list(w http.ResponseWriter, r *http.Request) {
+	filter := rate.Filter{
+		Base:  strings.ToUpper(r.URL.Query().Get("base")),
+		Quote: strings.ToUpper(r.URL.Query().Get("quote")),
+	}
+
+	if filter.Base != "" && !currency.IsCode(filter.Base) {
+		response.BadRequest(w, r, errors.New("base: must be an ISO 4217 code"), nil)
+		return
+	}
+
+	if filter.Quote != "" && !currency.IsCode(filter.Quote) {
+		response.BadRequest(w, r, errors.New("quote: must be an ISO 4217 code"), nil)
+		return
+	}
+
+	res, err := h.RateService.ListRates(r.Context(), filter)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
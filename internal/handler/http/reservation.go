@@ -0,0 +1,195 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/reservation"
+	"warehouse-service/internal/service/warehouse"
+	"warehouse-service/pkg/server/response"
+	"warehouse-service/pkg/storage"
+)
+
+type reservationHandler struct {
+	ReservationService *warehouse.Service
+}
+
+func NewReservationHandler(s *warehouse.Service) *reservationHandler {
+	return &reservationHandler{ReservationService: s}
+}
+
+func (h *reservationHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+
+	r.Route("/{reservationID}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Post("/commit", h.commit)
+		r.Post("/release", h.release)
+	})
+
+	return r
+}
+
+// List of reservations held on the inventory
+//
+//	@Summary	List of reservations held on the inventory
+//	@Tags		reservations
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string	true	"path param"
+//	@Success	200	{array}		response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/inventories/{id}/reservations [get]
+func (h *reservationHandler) list(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.ReservationService.ListReservations(r.Context(), id)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Hold the inventory stock for a limited time
+//
+//	@Summary	Hold the inventory stock for a limited time
+//	@Tags		reservations
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string				true	"path param"
+//	@Param		request	body		reservation.Request	true	"body param"
+//	@Success	200		{object}	response.Object
+//	@Failure	400		{object}	response.Object
+//	@Failure	404		{object}	response.Object
+//	@Failure	409		{object}	response.Object
+//	@Failure	500		{object}	response.Object
+//	@Router		/inventories/{id}/reservations [post]
+func (h *reservationHandler) add(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := reservation.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.ReservationService.AddReservation(r.Context(), id, req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Read the reservation of the inventory
+//
+//	@Summary	Read the reservation of the inventory
+//	@Tags		reservations
+//	@Accept		json
+//	@Produce	json
+//	@Param		id				path		string	true	"path param"
+//	@Param		reservationID	path		string	true	"path param"
+//	@Success	200				{object}	response.Object
+//	@Failure	404				{object}	response.Object
+//	@Failure	500				{object}	response.Object
+//	@Router		/inventories/{id}/reservations/{reservationID} [get]
+func (h *reservationHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	reservationID := chi.URLParam(r, "reservationID")
+
+	res, err := h.ReservationService.GetReservation(r.Context(), id, reservationID)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Commit the reservation and take the stock out of the inventory
+//
+//	@Summary	Commit the reservation and take the stock out of the inventory
+//	@Tags		reservations
+//	@Accept		json
+//	@Produce	json
+//	@Param		id				path		string	true	"path param"
+//	@Param		reservationID	path		string	true	"path param"
+//	@Success	200				{object}	response.Object
+//	@Failure	404				{object}	response.Object
+//	@Failure	409				{object}	response.Object
+//	@Failure	500				{object}	response.Object
+//	@Router		/inventories/{id}/reservations/{reservationID}/commit [post]
+func (h *reservationHandler) commit(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	reservationID := chi.URLParam(r, "reservationID")
+
+	req := movement.Request{}
+	if err := req.Bind(r); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.ReservationService.CommitReservation(r.Context(), id, reservationID, req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Release the reservation and return the stock to the inventory
+//
+//	@Summary	Release the reservation and return the stock to the inventory
+//	@Tags		reservations
+//	@Accept		json
+//	@Produce	json
+//	@Param		id				path		string	true	"path param"
+//	@Param		reservationID	path		string	true	"path param"
+//	@Success	200				{object}	response.Object
+//	@Failure	404				{object}	response.Object
+//	@Failure	409				{object}	response.Object
+//	@Failure	500				{object}	response.Object
+//	@Router		/inventories/{id}/reservations/{reservationID}/release [post]
+func (h *reservationHandler) release(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	reservationID := chi.URLParam(r, "reservationID")
+
+	res, err := h.ReservationService.ReleaseReservation(r.Context(), id, reservationID)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+func (h *reservationHandler) error(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case storage.ErrorNotFound:
+		response.NotFound(w, r, err)
+	case inventory.ErrorInsufficientStock, inventory.ErrorSerialized, reservation.ErrorNotHeld:
+		response.Conflict(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
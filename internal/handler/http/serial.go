@@ -0,0 +1,169 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"warehouse-service/internal/domain/serial"
+	"warehouse-service/internal/service/warehouse"
+	"warehouse-service/pkg/server/response"
+	"warehouse-service/pkg/storage"
+)
+
+type serialHandler struct {
+	SerialService *warehouse.Service
+}
+
+func NewSerialHandler(s *warehouse.Service) *serialHandler {
+	return &serialHandler{SerialService: s}
+}
+
+// Routes serves the units of one inventory, it is mounted under /inventories/{id}.
+func (h *serialHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+	r.Put("/{number}", h.status)
+
+	return r
+}
+
+// LookupRoutes serves the search by number across the stores.
+func (h *serialHandler) LookupRoutes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/{number}", h.lookup)
+
+	return r
+}
+
+// List of serial numbers of the inventory
+//
+//	@Summary	List of serial numbers of the inventory
+//	@Tags		serials
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string	true	"path param"
+//	@Param		status	query		string	false	"in_stock, reserved, sold or returned"
+//	@Success	200		{array}		response.Object
+//	@Failure	404		{object}	response.Object
+//	@Failure	500		{object}	response.Object
+//	@Router		/inventories/{id}/serials [get]
+func (h *serialHandler) list(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.SerialService.ListSerials(r.Context(), id, r.URL.Query().Get("status"))
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Register serial numbers in stock
+//
+//	@Summary		Register serial numbers in stock
+//	@Description	The inventory becomes serialized, its quantity is the number of units in stock from then on.
+//	@Tags			serials
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"path param"
+//	@Param			request	body		serial.Request	true	"body param"
+//	@Success		200		{array}		response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		409		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/inventories/{id}/serials [post]
+func (h *serialHandler) add(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := serial.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.SerialService.AddSerials(r.Context(), id, req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Move the unit to another status
+//
+//	@Summary		Move the unit to another status
+//	@Description	in_stock moves to reserved or sold, reserved to in_stock or sold, sold to returned and returned to in_stock.
+//	@Tags			serials
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"path param"
+//	@Param			number	path		string				true	"path param"
+//	@Param			request	body		serial.StatusRequest	true	"body param"
+//	@Success		200		{object}	response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		409		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/inventories/{id}/serials/{number} [put]
+func (h *serialHandler) status(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	number := chi.URLParam(r, "number")
+
+	req := serial.StatusRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.SerialService.SetSerialStatus(r.Context(), id, number, req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Find the units with the serial number in every store
+//
+//	@Summary	Find the units with the serial number in every store
+//	@Tags		serials
+//	@Accept		json
+//	@Produce	json
+//	@Param		number	path		string	true	"path param"
+//	@Success	200		{array}		response.Object
+//	@Failure	500		{object}	response.Object
+//	@Router		/serials/{number} [get]
+func (h *serialHandler) lookup(w http.ResponseWriter, r *http.Request) {
+	number := chi.URLParam(r, "number")
+
+	res, err := h.SerialService.FindSerials(r.Context(), number)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+func (h *serialHandler) error(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case storage.ErrorNotFound:
+		response.NotFound(w, r, err)
+	case serial.ErrorDuplicate, serial.ErrorTransition:
+		response.Conflict(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,134 @@
+package http
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"strconv"
+	"warehouse-service/internal/domain/slot"
+	"warehouse-service/internal/service/warehouse"
+	"warehouse-service/pkg/server/response"
+	"warehouse-service/pkg/storage"
+)
+
+type slotHandler struct {
+	SlotService *warehouse.Service
+}
+
+func NewSlotHandler(s *warehouse.Service) *slotHandler {
+	return &slotHandler{SlotService: s}
+}
+
+// Routes serves the delivery slots of one store, it is mounted under /stores/{id}/delivery.
+func (h *slotHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/bookings", h.book)
+	r.Delete("/bookings/{bookingID}", h.cancel)
+
+	return r
+}
+
+// List of delivery slots of the store with places left
+//
+//	@Summary		List of delivery slots of the store with places left
+//	@Description	The delivery periods of the store are cut into slots of its slot length, each taking up to its slot capacity of orders.
+//	@Tags			slots
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"path param"
+//	@Param			days	query		int		false	"number of days from today, 7 by default"
+//	@Success		200		{array}		response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/stores/{id}/delivery/slots [get]
+func (h *slotHandler) list(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	days := 7
+	if value := r.URL.Query().Get("days"); value != "" {
+		var err error
+		if days, err = strconv.Atoi(value); err != nil || days < 1 || days > 31 {
+			response.BadRequest(w, r, errors.New("days: must be a number between 1 and 31"), nil)
+			return
+		}
+	}
+
+	res, err := h.SlotService.ListSlots(r.Context(), id, days)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Book a delivery slot of the store
+//
+//	@Summary	Book a delivery slot of the store
+//	@Tags		slots
+//	@Accept		json
+//	@Produce	json
+//	@Param		id		path		string				true	"path param"
+//	@Param		request	body		slot.BookingRequest	true	"body param"
+//	@Success	200		{object}	response.Object
+//	@Failure	400		{object}	response.Object
+//	@Failure	404		{object}	response.Object
+//	@Failure	409		{object}	response.Object
+//	@Failure	500		{object}	response.Object
+//	@Router		/stores/{id}/delivery/slots/bookings [post]
+func (h *slotHandler) book(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := slot.BookingRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.SlotService.BookSlot(r.Context(), id, req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Cancel a delivery slot booking of the store
+//
+//	@Summary	Cancel a delivery slot booking of the store
+//	@Tags		slots
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path	string	true	"path param"
+//	@Param		bookingID	path	string	true	"path param"
+//	@Success	200
+//	@Failure	404	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/stores/{id}/delivery/slots/bookings/{bookingID} [delete]
+func (h *slotHandler) cancel(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	bookingID := chi.URLParam(r, "bookingID")
+
+	if err := h.SlotService.CancelBooking(r.Context(), id, bookingID); err != nil {
+		h.error(w, r, err)
+		return
+	}
+}
+
+func (h *slotHandler) error(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case storage.ErrorNotFound:
+		response.NotFound(w, r, err)
+	case slot.ErrorUnknown:
+		response.BadRequest(w, r, err, nil)
+	case slot.ErrorFull:
+		response.Conflict(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
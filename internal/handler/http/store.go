@@ -1,10 +1,17 @@
 package http
 
 import (
+	"errors"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"net/http"
+	"strconv"
+	"warehouse-service/internal/domain/delivery"
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/rate"
+	"warehouse-service/internal/domain/schedule"
 	"warehouse-service/internal/domain/store"
+	"warehouse-service/internal/domain/unit"
 	"warehouse-service/internal/service/warehouse"
 	"warehouse-service/pkg/server/response"
 	"warehouse-service/pkg/storage"
@@ -23,11 +30,28 @@ func (h *storeHandler) Routes() chi.Router {
 
 	r.Get("/", h.list)
 	r.Post("/", h.add)
+	r.Get("/nearby", h.nearby)
 
 	r.Route("/{id}", func(r chi.Router) {
 		r.Get("/", h.get)
 		r.Put("/", h.update)
 		r.Delete("/", h.delete)
+
+		r.Put("/products/{productID}/inventory", h.upsertInventory)
+		r.Get("/expiring", h.expiring)
+		r.Get("/products/{productID}/locations", h.locate)
+
+		r.Mount("/locations", NewLocationHandler(h.StoreService).Routes())
+		r.Mount("/seasons", NewCalendarHandler(h.StoreService).SeasonRoutes())
+		r.Mount("/exceptions", NewCalendarHandler(h.StoreService).ExceptionRoutes())
+		r.Get("/hours", NewCalendarHandler(h.StoreService).hours)
+		r.Get("/schedule", h.getSchedule)
+		r.Put("/schedule", h.saveSchedule)
+		r.Get("/delivery", h.getDelivery)
+		r.Put("/delivery", h.saveDelivery)
+		r.Get("/delivery/check", h.checkDelivery)
+		r.Post("/delivery/quote", h.quoteDelivery)
+		r.Mount("/delivery/slots", NewSlotHandler(h.StoreService).Routes())
 	})
 
 	return r
@@ -39,13 +63,45 @@ func (h *storeHandler) Routes() chi.Router {
 //	@Tags		stores
 //	@Accept		json
 //	@Produce	json
+//	@Param		currency	query		string	false	"ISO 4217 code of the currency to add the exchange rate into"
+//	@Param		open_now	query		bool	false	"only the stores open now, or closed now when false"
+//	@Param		lat			query		number	false	"latitude of a point, only the stores delivering there"
+//	@Param		lng			query		number	false	"longitude of the point"
 //	@Success	200			{array}		response.Object
+//	@Failure	400			{object}	response.Object
+//	@Failure	409			{object}	response.Object
 //	@Failure	500			{object}	response.Object
 //	@Router		/stores 	[get]
 func (h *storeHandler) list(w http.ResponseWriter, r *http.Request) {
-	res, err := h.StoreService.ListStores(r.Context())
+	filter := store.Filter{}
+	if value := r.URL.Query().Get("open_now"); value != "" {
+		openNow, err := strconv.ParseBool(value)
+		if err != nil {
+			response.BadRequest(w, r, errors.New("open_now: must be true or false"), nil)
+			return
+		}
+		filter.OpenNow = &openNow
+	}
+
+	if lat, lng := r.URL.Query().Get("lat"), r.URL.Query().Get("lng"); lat != "" || lng != "" {
+		point, err := delivery.ParsePoint(lat, lng)
+		if err != nil {
+			response.BadRequest(w, r, err, nil)
+			return
+		}
+		filter.DeliversTo = &point
+	}
+
+	res, err := h.StoreService.ListStores(r.Context(), filter, r.URL.Query().Get("currency"))
 	if err != nil {
-		response.InternalServerError(w, r, err)
+		switch err {
+		case rate.ErrorUnknownCurrency:
+			response.BadRequest(w, r, err, nil)
+		case rate.ErrorNoCurrency, rate.ErrorMissing:
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
 		return
 	}
 
@@ -85,22 +141,29 @@ func (h *storeHandler) add(w http.ResponseWriter, r *http.Request) {
 //	@Tags		stores
 //	@Accept		json
 //	@Produce	json
-//	@Param		id	path		string	true	"path param"
-//	@Success	200	{object}	response.Object
-//	@Failure	404	{object}	response.Object
-//	@Failure	500	{object}	response.Object
+//	@Param		id			path		string	true	"path param"
+//	@Param		currency	query		string	false	"ISO 4217 code of the currency to add the exchange rate into"
+//	@Success	200			{object}	response.Object
+//	@Failure	400			{object}	response.Object
+//	@Failure	404			{object}	response.Object
+//	@Failure	409			{object}	response.Object
+//	@Failure	500			{object}	response.Object
 //	@Router		/stores/{id} [get]
 func (h *storeHandler) get(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	res, err := h.StoreService.GetStore(r.Context(), id)
-	if err != nil && err != storage.ErrorNotFound {
-		response.InternalServerError(w, r, err)
-		return
-	}
-
-	if err == storage.ErrorNotFound {
-		response.NotFound(w, r, err)
+	res, err := h.StoreService.GetStore(r.Context(), id, r.URL.Query().Get("currency"))
+	if err != nil {
+		switch err {
+		case storage.ErrorNotFound:
+			response.NotFound(w, r, err)
+		case rate.ErrorUnknownCurrency:
+			response.BadRequest(w, r, err, nil)
+		case rate.ErrorNoCurrency, rate.ErrorMissing:
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
 		return
 	}
 
@@ -110,6 +173,7 @@ func (h *storeHandler) get(w http.ResponseWriter, r *http.Request) {
 // Update the store in the database
 //
 //	@Summary	Update the store in the database
+//	@Description	The city and currency fields left out keep the ones stored. Delivery fees left out keep the ones stored, fees set to null remove them.
 //	@Tags		stores
 //	@Accept		json
 //	@Produce	json
@@ -166,3 +230,368 @@ func (h *storeHandler) delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// Create or update the inventory of the product in the store
+//
+//	@Summary	Create or update the inventory of the product in the store
+//	@Tags		stores
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path		string				true	"path param"
+//	@Param		productID	path		string				true	"path param"
+//	@Param		request		body		inventory.Request	true	"body param"
+//	@Success	200			{object}	response.Object
+//	@Failure	400			{object}	response.Object
+//	@Failure	404			{object}	response.Object
+//	@Failure	409			{object}	response.Object
+//	@Failure	500			{object}	response.Object
+//	@Router		/stores/{id}/products/{productID}/inventory [put]
+func (h *storeHandler) upsertInventory(w http.ResponseWriter, r *http.Request) {
+	req := inventory.Request{}
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	// the natural key comes from the path, the body only carries the stock
+	req.StoreID = chi.URLParam(r, "id")
+	req.ProductID = chi.URLParam(r, "productID")
+	if err := req.Bind(r); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.StoreService.UpsertInventory(r.Context(), req)
+	if err != nil {
+		switch err {
+		case storage.ErrorNotFound:
+			response.NotFound(w, r, err)
+		case unit.ErrorUnknownUnit, unit.ErrorFraction:
+			response.BadRequest(w, r, err, req)
+		case inventory.ErrorSerialized:
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// List of lots of the store expiring soon
+//
+//	@Summary		List of lots of the store expiring soon
+//	@Description	Lots expiring within the given number of days, today in the time zone of the store included, expired lots still in stock come first.
+//	@Tags			stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string	true	"path param"
+//	@Param			days	query		int		false	"number of days, 7 by default"
+//	@Success		200		{array}		response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/stores/{id}/expiring [get]
+func (h *storeHandler) expiring(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	days := 7
+	if value := r.URL.Query().Get("days"); value != "" {
+		var err error
+		if days, err = strconv.Atoi(value); err != nil || days < 0 || days > 3650 {
+			response.BadRequest(w, r, errors.New("days: must be a number between 0 and 3650"), nil)
+			return
+		}
+	}
+
+	res, err := h.StoreService.ListExpiringLots(r.Context(), id, days)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Where the product is put away in the store
+//
+//	@Summary	Where the product is put away in the store
+//	@Tags		stores
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path		string	true	"path param"
+//	@Param		productID	path		string	true	"path param"
+//	@Success	200			{array}		response.Object
+//	@Failure	404			{object}	response.Object
+//	@Failure	500			{object}	response.Object
+//	@Router		/stores/{id}/products/{productID}/locations [get]
+func (h *storeHandler) locate(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	productID := chi.URLParam(r, "productID")
+
+	res, err := h.StoreService.LocateProduct(r.Context(), id, productID)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Check whether the store delivers to a point
+//
+//	@Summary		Check whether the store delivers to a point
+//	@Description	The store delivers to a point inside one of its delivery areas, or on the border of one, while its delivery is active.
+//	@Tags			stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"path param"
+//	@Param			lat	query		number	true	"latitude of the point"
+//	@Param			lng	query		number	true	"longitude of the point"
+//	@Success		200	{object}	response.Object
+//	@Failure		400	{object}	response.Object
+//	@Failure		404	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/stores/{id}/delivery/check [get]
+func (h *storeHandler) checkDelivery(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	point, err := delivery.ParsePoint(r.URL.Query().Get("lat"), r.URL.Query().Get("lng"))
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	res, err := h.StoreService.CheckDelivery(r.Context(), id, point)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Quote the delivery fee of the store to a point
+//
+//	@Summary		Quote the delivery fee of the store to a point
+//	@Description	A zone covering the destination sets the fee, elsewhere the base fee adds to the per-km tiers charged by the distance from the store. An order of free_from or more is delivered free, minimum_order tells whether the basket is large enough.
+//	@Tags			stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"path param"
+//	@Param			request	body		delivery.QuoteRequest	true	"body param"
+//	@Success		200		{object}	response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		409		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/stores/{id}/delivery/quote [post]
+func (h *storeHandler) quoteDelivery(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := delivery.QuoteRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.StoreService.QuoteDelivery(r.Context(), id, req)
+	if err != nil {
+		switch err {
+		case storage.ErrorNotFound:
+			response.NotFound(w, r, err)
+		case delivery.ErrorUnavailable, delivery.ErrorNoFees, delivery.ErrorNoLocation, delivery.ErrorTooFar:
+			response.Conflict(w, r, err)
+		default:
+			response.InternalServerError(w, r, err)
+		}
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// List of stores near a point
+//
+//	@Summary	List of stores near a point
+//	@Tags		stores
+//	@Accept		json
+//	@Produce	json
+//	@Param		lat		query		number	true	"latitude of the point"
+//	@Param		lng		query		number	true	"longitude of the point"
+//	@Param		radius	query		number	false	"distance from the point in kilometres, 5 by default"
+//	@Success	200		{array}		response.Object
+//	@Failure	400		{object}	response.Object
+//	@Failure	500		{object}	response.Object
+//	@Router		/stores/nearby [get]
+func (h *storeHandler) nearby(w http.ResponseWriter, r *http.Request) {
+	point, err := delivery.ParsePoint(r.URL.Query().Get("lat"), r.URL.Query().Get("lng"))
+	if err != nil {
+		response.BadRequest(w, r, err, nil)
+		return
+	}
+
+	radius := 5.0
+	if value := r.URL.Query().Get("radius"); value != "" {
+		if radius, err = strconv.ParseFloat(value, 64); err != nil || radius <= 0 || radius > 1000 {
+			response.BadRequest(w, r, errors.New("radius: must be a number of kilometres above 0 and up to 1000"), nil)
+			return
+		}
+	}
+
+	res, err := h.StoreService.NearbyStores(r.Context(), point, radius)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Read the weekly schedule of the store
+//
+//	@Summary		Read the weekly schedule of the store
+//	@Description	A store without a schedule has an inactive one without periods.
+//	@Tags			stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"path param"
+//	@Success		200	{object}	response.Object
+//	@Failure		404	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/stores/{id}/schedule [get]
+func (h *storeHandler) getSchedule(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.StoreService.GetSchedule(r.Context(), id)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Replace the weekly schedule of the store
+//
+//	@Summary		Replace the weekly schedule of the store
+//	@Description	Each period opens on a day, named in English, from one HH:MM time to another, running past midnight when it ends at or before its start.
+//	@Tags			stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"path param"
+//	@Param			request	body		schedule.Request	true	"body param"
+//	@Success		200		{object}	response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/stores/{id}/schedule [put]
+func (h *storeHandler) saveSchedule(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := schedule.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.StoreService.SaveSchedule(r.Context(), id, req)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Read the delivery of the store
+//
+//	@Summary		Read the delivery of the store
+//	@Description	A store without a delivery has an inactive one without periods or areas.
+//	@Tags			stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"path param"
+//	@Success		200	{object}	response.Object
+//	@Failure		404	{object}	response.Object
+//	@Failure		500	{object}	response.Object
+//	@Router			/stores/{id}/delivery [get]
+func (h *storeHandler) getDelivery(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.StoreService.GetDelivery(r.Context(), id)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Replace the delivery of the store
+//
+//	@Summary		Replace the delivery of the store
+//	@Description	The periods and the areas are replaced. Zero slotMinutes and slotCapacity and fees left out keep the ones stored, fees set to null remove them, a new delivery takes 60 minute slots of 10 orders.
+//	@Tags			stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"path param"
+//	@Param			request	body		delivery.Request	true	"body param"
+//	@Success		200		{object}	response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/stores/{id}/delivery [put]
+func (h *storeHandler) saveDelivery(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := delivery.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.StoreService.SaveDelivery(r.Context(), id, req)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
@@ -0,0 +1,191 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/transfer"
+	"warehouse-service/internal/service/warehouse"
+	"warehouse-service/pkg/server/response"
+	"warehouse-service/pkg/storage"
+)
+
+type transferHandler struct {
+	TransferService *warehouse.Service
+}
+
+func NewTransferHandler(s *warehouse.Service) *transferHandler {
+	return &transferHandler{TransferService: s}
+}
+
+func (h *transferHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/", h.list)
+	r.Post("/", h.add)
+
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Post("/ship", h.ship)
+		r.Post("/receive", h.receive)
+	})
+
+	return r
+}
+
+// List of transfers between stores
+//
+//	@Summary	List of transfers between stores
+//	@Tags		transfers
+//	@Accept		json
+//	@Produce	json
+//	@Param		store_id	query		string	false	"only the transfers from or to the store"
+//	@Param		status		query		string	false	"created, in_transit, partially_received or received"
+//	@Success	200			{array}		response.Object
+//	@Failure	500			{object}	response.Object
+//	@Router		/transfers [get]
+func (h *transferHandler) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter := transfer.Filter{
+		StoreID: query.Get("store_id"),
+		Status:  query.Get("status"),
+	}
+
+	res, err := h.TransferService.ListTransfers(r.Context(), filter)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Create a transfer between two stores of the same merchant
+//
+//	@Summary	Create a transfer between two stores of the same merchant
+//	@Tags		transfers
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		transfer.Request	true	"body param"
+//	@Success	200		{object}	response.Object
+//	@Failure	400		{object}	response.Object
+//	@Failure	404		{object}	response.Object
+//	@Failure	500		{object}	response.Object
+//	@Router		/transfers [post]
+func (h *transferHandler) add(w http.ResponseWriter, r *http.Request) {
+	req := transfer.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.TransferService.AddTransfer(r.Context(), req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Read the transfer
+//
+//	@Summary	Read the transfer
+//	@Tags		transfers
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string	true	"path param"
+//	@Success	200	{object}	response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/transfers/{id} [get]
+func (h *transferHandler) get(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	res, err := h.TransferService.GetTransfer(r.Context(), id)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Ship the transfer and take the goods out of the source store
+//
+//	@Summary	Ship the transfer and take the goods out of the source store
+//	@Tags		transfers
+//	@Accept		json
+//	@Produce	json
+//	@Param		id	path		string	true	"path param"
+//	@Success	200	{object}	response.Object
+//	@Failure	404	{object}	response.Object
+//	@Failure	409	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/transfers/{id}/ship [post]
+func (h *transferHandler) ship(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := movement.Request{}
+	if err := req.Bind(r); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.TransferService.ShipTransfer(r.Context(), id, req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Receive the goods of the transfer in the destination store
+//
+//	@Summary		Receive the goods of the transfer in the destination store
+//	@Description	Lines may receive a part of the quantity in transit, no lines receive all of it.
+//	@Tags			transfers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"path param"
+//	@Param			request	body		transfer.ReceiveRequest	true	"body param"
+//	@Success		200		{object}	response.Object
+//	@Failure		400		{object}	response.Object
+//	@Failure		404		{object}	response.Object
+//	@Failure		409		{object}	response.Object
+//	@Failure		500		{object}	response.Object
+//	@Router			/transfers/{id}/receive [post]
+func (h *transferHandler) receive(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	req := transfer.ReceiveRequest{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.TransferService.ReceiveTransfer(r.Context(), id, req)
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+func (h *transferHandler) error(w http.ResponseWriter, r *http.Request, err error) {
+	switch err {
+	case storage.ErrorNotFound:
+		response.NotFound(w, r, err)
+	case transfer.ErrorMerchantMismatch:
+		response.BadRequest(w, r, err, nil)
+	case inventory.ErrorInsufficientStock, inventory.ErrorSerialized, transfer.ErrorNotCreated, transfer.ErrorNotInTransit, transfer.ErrorOverReceipt:
+		response.Conflict(w, r, err)
+	default:
+		response.InternalServerError(w, r, err)
+	}
+}
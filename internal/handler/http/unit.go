@@ -0,0 +1,116 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"net/http"
+	"warehouse-service/internal/domain/unit"
+	"warehouse-service/internal/service/warehouse"
+	"warehouse-service/pkg/server/response"
+	"warehouse-service/pkg/storage"
+)
+
+type unitHandler struct {
+	UnitService *warehouse.Service
+}
+
+func NewUnitHandler(s *warehouse.Service) *unitHandler {
+	return &unitHandler{UnitService: s}
+}
+
+func (h *unitHandler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Route("/{productID}/units", func(r chi.Router) {
+		r.Get("/", h.get)
+		r.Put("/", h.save)
+		r.Delete("/", h.delete)
+	})
+
+	return r
+}
+
+// Read the units of measure of the product
+//
+//	@Summary	Read the units of measure of the product
+//	@Tags		units
+//	@Accept		json
+//	@Produce	json
+//	@Param		productID	path		string	true	"path param"
+//	@Success	200			{object}	response.Object
+//	@Failure	404			{object}	response.Object
+//	@Failure	500			{object}	response.Object
+//	@Router		/products/{productID}/units [get]
+func (h *unitHandler) get(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "productID")
+
+	res, err := h.UnitService.GetUnits(r.Context(), productID)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Set the units of measure of the product
+//
+//	@Summary		Set the units of measure of the product
+//	@Description	Quantities are kept in whole base units, each alternate unit converts to them by its factor. The alternate units are replaced as a whole.
+//	@Tags			units
+//	@Accept			json
+//	@Produce		json
+//	@Param			productID	path		string			true	"path param"
+//	@Param			request		body		unit.Request	true	"body param"
+//	@Success		200			{object}	response.Object
+//	@Failure		400			{object}	response.Object
+//	@Failure		500			{object}	response.Object
+//	@Router			/products/{productID}/units [put]
+func (h *unitHandler) save(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "productID")
+
+	req := unit.Request{}
+	if err := render.Bind(r, &req); err != nil {
+		response.BadRequest(w, r, err, req)
+		return
+	}
+
+	res, err := h.UnitService.SaveUnits(r.Context(), productID, req)
+	if err != nil {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	response.OK(w, r, res)
+}
+
+// Delete the units of measure of the product
+//
+//	@Summary	Delete the units of measure of the product
+//	@Tags		units
+//	@Accept		json
+//	@Produce	json
+//	@Param		productID	path	string	true	"path param"
+//	@Success	200
+//	@Failure	404	{object}	response.Object
+//	@Failure	500	{object}	response.Object
+//	@Router		/products/{productID}/units [delete]
+func (h *unitHandler) delete(w http.ResponseWriter, r *http.Request) {
+	productID := chi.URLParam(r, "productID")
+
+	err := h.UnitService.DeleteUnits(r.Context(), productID)
+	if err != nil && err != storage.ErrorNotFound {
+		response.InternalServerError(w, r, err)
+		return
+	}
+
+	if err == storage.ErrorNotFound {
+		response.NotFound(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,157 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"warehouse-service/internal/domain/alert"
+	"warehouse-service/pkg/storage"
+)
+
+type AlertRepository struct {
+	db map[string]alert.Entity
+	sync.RWMutex
+}
+
+func NewAlertRepository() *AlertRepository {
+	return &AlertRepository{
+		db: make(map[string]alert.Entity),
+	}
+}
+
+func (r *AlertRepository) Select(ctx context.Context, filter alert.Filter) (dest []alert.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]alert.Entity, 0)
+	for _, data := range r.db {
+		if filter.State != "" && data.State != filter.State {
+			continue
+		}
+		if filter.Kind != "" && data.Kind != filter.Kind {
+			continue
+		}
+		if filter.StoreID != "" && data.StoreID != filter.StoreID {
+			continue
+		}
+		dest = append(dest, data)
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.After(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *AlertRepository) Get(ctx context.Context, id string) (dest alert.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = storage.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *AlertRepository) Raise(ctx context.Context, data alert.Entity, reopenSince time.Time) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	now := time.Now()
+
+	current, ok := r.active(data.InventoryID, data.Kind)
+	if !ok {
+		current, ok = r.resolved(data.InventoryID, data.Kind, reopenSince)
+		if ok {
+			current.State = alert.StateOpen
+			current.ResolvedAt = nil
+		}
+	}
+
+	if !ok {
+		current = data
+		current.ID = uuid.New().String()
+		current.State = alert.StateOpen
+		current.CreatedAt = now
+	}
+
+	current.Quantity = data.Quantity
+	current.Threshold = data.Threshold
+	current.UpdatedAt = now
+	r.db[current.ID] = current
+
+	return
+}
+
+func (r *AlertRepository) Resolve(ctx context.Context, inventoryID, kind string, quantity int) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.active(inventoryID, kind)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	current.State = alert.StateResolved
+	current.ResolvedAt = &now
+	current.Quantity = quantity
+	current.UpdatedAt = now
+	r.db[current.ID] = current
+
+	return
+}
+
+func (r *AlertRepository) Acknowledge(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.db[id]
+	if !ok {
+		return storage.ErrorNotFound
+	}
+
+	if current.State != alert.StateOpen {
+		return alert.ErrorNotOpen
+	}
+
+	current.State = alert.StateAcknowledged
+	current.UpdatedAt = time.Now()
+	r.db[id] = current
+
+	return
+}
+
+// active finds the open or acknowledged alert of the inventory.
+func (r *AlertRepository) active(inventoryID, kind string) (dest alert.Entity, ok bool) {
+	for _, data := range r.db {
+		if data.InventoryID == inventoryID && data.Kind == kind && data.State != alert.StateResolved {
+			return data, true
+		}
+	}
+
+	return
+}
+
+// resolved finds the latest alert of the inventory resolved since the given moment.
+func (r *AlertRepository) resolved(inventoryID, kind string, since time.Time) (dest alert.Entity, ok bool) {
+	for _, data := range r.db {
+		if data.InventoryID != inventoryID || data.Kind != kind || data.State != alert.StateResolved {
+			continue
+		}
+		if data.ResolvedAt.Before(since) || (ok && data.ResolvedAt.Before(*dest.ResolvedAt)) {
+			continue
+		}
+		dest, ok = data, true
+	}
+
+	return
+}
@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"warehouse-service/internal/domain/calendar"
+	"warehouse-service/pkg/storage"
+)
+
+type CalendarRepository struct {
+	seasons    map[string]calendar.Season
+	exceptions map[string]calendar.Exception
+	sync.RWMutex
+}
+
+func NewCalendarRepository() *CalendarRepository {
+	return &CalendarRepository{
+		seasons:    make(map[string]calendar.Season),
+		exceptions: make(map[string]calendar.Exception),
+	}
+}
+
+func (r *CalendarRepository) SelectSeasons(ctx context.Context, storeID string) (dest []calendar.Season, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]calendar.Season, 0)
+	for _, data := range r.seasons {
+		if data.StoreID == storeID {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		if !dest[i].ValidFrom.Equal(dest[j].ValidFrom) {
+			return dest[i].ValidFrom.Before(dest[j].ValidFrom)
+		}
+		return dest[i].CreatedAt.Before(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *CalendarRepository) SelectSeasonsByStores(ctx context.Context, storeIDs []string) (dest []calendar.Season, err error) {
+	dest = make([]calendar.Season, 0)
+	for _, storeID := range storeIDs {
+		seasons, _ := r.SelectSeasons(ctx, storeID)
+		dest = append(dest, seasons...)
+	}
+
+	return
+}
+
+func (r *CalendarRepository) CreateSeason(ctx context.Context, data calendar.Season) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id = uuid.New().String()
+	data.ID = id
+	data.CreatedAt = time.Now()
+	r.seasons[id] = data
+
+	return
+}
+
+func (r *CalendarRepository) DeleteSeason(ctx context.Context, storeID, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.seasons[id]
+	if !ok || data.StoreID != storeID {
+		return storage.ErrorNotFound
+	}
+	delete(r.seasons, id)
+
+	return
+}
+
+func (r *CalendarRepository) SelectExceptions(ctx context.Context, storeID string, from, to time.Time) (dest []calendar.Exception, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	first, last := from.Format(calendar.DateLayout), to.Format(calendar.DateLayout)
+
+	dest = make([]calendar.Exception, 0)
+	for _, data := range r.exceptions {
+		date := data.Date.Format(calendar.DateLayout)
+		if data.StoreID == storeID && date >= first && date <= last {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].Date.Before(dest[j].Date)
+	})
+
+	return
+}
+
+func (r *CalendarRepository) SelectExceptionsByStores(ctx context.Context, storeIDs []string, from, to time.Time) (dest []calendar.Exception, err error) {
+	dest = make([]calendar.Exception, 0)
+	for _, storeID := range storeIDs {
+		exceptions, _ := r.SelectExceptions(ctx, storeID, from, to)
+		dest = append(dest, exceptions...)
+	}
+
+	return
+}
+
+func (r *CalendarRepository) SaveException(ctx context.Context, data calendar.Exception) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	key := exceptionKey(data.StoreID, data.Date)
+
+	data.UpdatedAt = time.Now()
+	data.CreatedAt = data.UpdatedAt
+	if current, ok := r.exceptions[key]; ok {
+		data.CreatedAt = current.CreatedAt
+	}
+	r.exceptions[key] = data
+
+	return
+}
+
+func (r *CalendarRepository) DeleteException(ctx context.Context, storeID string, date time.Time) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	key := exceptionKey(storeID, date)
+	if _, ok := r.exceptions[key]; !ok {
+		return storage.ErrorNotFound
+	}
+	delete(r.exceptions, key)
+
+	return
+}
+
+func exceptionKey(storeID string, date time.Time) string {
+	return storeID + "/" + date.Format(calendar.DateLayout)
+}
@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"warehouse-service/internal/domain/city"
+	"warehouse-service/pkg/storage"
+)
+
+type CityRepository struct {
+	db map[string]city.Entity
+	sync.RWMutex
+}
+
+func NewCityRepository() *CityRepository {
+	return &CityRepository{
+		db: make(map[string]city.Entity),
+	}
+}
+
+func (r *CityRepository) Select(ctx context.Context) (dest []city.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]city.Entity, 0, len(r.db))
+	for _, data := range r.db {
+		dest = append(dest, data)
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return *dest[i].Name < *dest[j].Name
+	})
+
+	return
+}
+
+func (r *CityRepository) Create(ctx context.Context, data city.Entity) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.taken(data, "") {
+		return "", city.ErrorDuplicate
+	}
+
+	id = uuid.New().String()
+	data.ID = id
+	data.CreatedAt = time.Now()
+	data.UpdatedAt = data.CreatedAt
+	r.db[id] = data
+
+	return
+}
+
+// Get returns nil for a missing city, as the postgres repository does.
+func (r *CityRepository) Get(ctx context.Context, id string) (dest *city.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	data, ok := r.db[id]
+	if !ok {
+		return
+	}
+	dest = &data
+
+	return
+}
+
+func (r *CityRepository) Update(ctx context.Context, id string, data city.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	// a missing city is left alone, as the UPDATE of the postgres repository does
+	current, ok := r.db[id]
+	if !ok {
+		return
+	}
+
+	if r.taken(data, id) {
+		return city.ErrorDuplicate
+	}
+
+	if data.CountryID != "" {
+		current.CountryID = data.CountryID
+	}
+
+	if data.Name != nil {
+		current.Name = data.Name
+	}
+
+	if data.GeoCenter != nil {
+		current.GeoCenter = data.GeoCenter
+	}
+
+	if data.TimeZone != nil {
+		current.TimeZone = data.TimeZone
+	}
+	current.UpdatedAt = time.Now()
+	r.db[id] = current
+
+	return
+}
+
+func (r *CityRepository) Delete(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.db[id]; !ok {
+		return storage.ErrorNotFound
+	}
+	delete(r.db, id)
+
+	return
+}
+
+// taken tells whether another city than the one with the id has the name of the data.
+func (r *CityRepository) taken(data city.Entity, id string) bool {
+	if data.Name == nil {
+		return false
+	}
+
+	for _, object := range r.db {
+		if object.ID != id && *object.Name == *data.Name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,276 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"warehouse-service/internal/domain/count"
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/pkg/storage"
+)
+
+// CountRepository marks the inventories it counts and shares their lock,
+// so the snapshot, the entries and the adjustments see a consistent quantity.
+type CountRepository struct {
+	db          map[string]count.Entity
+	entries     map[string]map[countEntryKey]int
+	inventories *InventoryRepository
+}
+
+// countEntryKey keeps the latest entry of every counter of an item.
+type countEntryKey struct {
+	inventoryID string
+	counter     string
+}
+
+func NewCountRepository(inventories *InventoryRepository) *CountRepository {
+	return &CountRepository{
+		db:          make(map[string]count.Entity),
+		entries:     make(map[string]map[countEntryKey]int),
+		inventories: inventories,
+	}
+}
+
+func (r *CountRepository) Select(ctx context.Context, filter count.Filter) (dest []count.Entity, err error) {
+	r.inventories.RLock()
+	defer r.inventories.RUnlock()
+
+	dest = make([]count.Entity, 0)
+	for _, data := range r.db {
+		if filter.StoreID != "" && data.StoreID != filter.StoreID {
+			continue
+		}
+		if filter.Status != "" && data.Status != filter.Status {
+			continue
+		}
+		dest = append(dest, r.copy(data))
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.After(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *CountRepository) Create(ctx context.Context, data count.Entity, productIDs []string) (dest string, err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	products := make(map[string]bool, len(productIDs))
+	for _, productID := range productIDs {
+		products[productID] = true
+	}
+
+	items := make([]count.Item, 0)
+	for _, inventoryData := range r.inventories.db {
+		if inventoryData.StoreID != data.StoreID || (len(products) > 0 && !products[inventoryData.ProductID]) {
+			continue
+		}
+
+		if inventoryData.CountID != nil {
+			return "", count.ErrorAlreadyCounting
+		}
+
+		quantity, err := quantityOf(inventoryData)
+		if err != nil {
+			return "", err
+		}
+
+		items = append(items, count.Item{
+			InventoryID: inventoryData.ID,
+			ProductID:   inventoryData.ProductID,
+			Expected:    quantity,
+		})
+	}
+
+	if len(items) == 0 {
+		return "", count.ErrorNothingToCount
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].ProductID < items[j].ProductID
+	})
+
+	id := uuid.New().String()
+	for i := range items {
+		items[i].CountID = id
+		r.mark(items[i].InventoryID, &id)
+	}
+
+	data.ID = id
+	data.Status = count.StatusOpen
+	data.CreatedAt = time.Now()
+	data.UpdatedAt = data.CreatedAt
+	data.Items = items
+	r.db[id] = data
+	r.entries[id] = make(map[countEntryKey]int)
+
+	return id, nil
+}
+
+func (r *CountRepository) Get(ctx context.Context, id string) (dest count.Entity, err error) {
+	r.inventories.RLock()
+	defer r.inventories.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = storage.ErrorNotFound
+		return
+	}
+
+	return r.copy(dest), nil
+}
+
+func (r *CountRepository) Record(ctx context.Context, id string, entry count.Entry) (err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	data, ok := r.db[id]
+	if !ok {
+		return storage.ErrorNotFound
+	}
+
+	if data.Status != count.StatusOpen {
+		return count.ErrorNotOpen
+	}
+
+	data = r.copy(data)
+	for i, item := range data.Items {
+		if item.ProductID != entry.ProductID {
+			continue
+		}
+
+		balance, err := quantityOf(r.inventories.db[item.InventoryID])
+		if err != nil {
+			return err
+		}
+
+		r.entries[id][countEntryKey{item.InventoryID, entry.Counter}] = entry.Quantity
+
+		counted := 0
+		for key, quantity := range r.entries[id] {
+			if key.inventoryID == item.InventoryID {
+				counted += quantity
+			}
+		}
+
+		data.Items[i].Counted = &counted
+		data.Items[i].Balance = &balance
+		data.UpdatedAt = time.Now()
+		r.db[id] = data
+
+		return nil
+	}
+
+	return count.ErrorNotCounted
+}
+
+func (r *CountRepository) Approve(ctx context.Context, id string, change movement.Entity) (err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	data, ok := r.db[id]
+	if !ok {
+		return storage.ErrorNotFound
+	}
+
+	if data.Status != count.StatusOpen {
+		return count.ErrorNotOpen
+	}
+
+	// check every item up front, so a failing one leaves all of them unadjusted
+	balances := make([]int, len(data.Items))
+	for i, item := range data.Items {
+		variance := item.Variance()
+		if variance == nil || *variance == 0 {
+			continue
+		}
+
+		// an inventory deleted during the count has nothing left to adjust
+		inventoryData, ok := r.inventories.db[item.InventoryID]
+		if !ok {
+			continue
+		}
+
+		if r.inventories.serialized(item.InventoryID) {
+			return inventory.ErrorSerialized
+		}
+
+		quantity, err := quantityOf(inventoryData)
+		if err != nil {
+			return err
+		}
+
+		balances[i] = quantity + *variance
+		if balances[i] < 0 && (inventoryData.AllowBackorder == nil || !*inventoryData.AllowBackorder) {
+			return inventory.ErrorInsufficientStock
+		}
+	}
+
+	now := time.Now()
+	for i, item := range data.Items {
+		inventoryData, ok := r.inventories.db[item.InventoryID]
+		if variance := item.Variance(); ok && variance != nil && *variance != 0 {
+			value := strconv.Itoa(balances[i])
+			inventoryData.Quantity = &value
+			inventoryData.UpdatedAt = now
+			r.inventories.db[item.InventoryID] = inventoryData
+			r.inventories.record(item.InventoryID, *variance, balances[i], change)
+		}
+	}
+
+	r.close(data, count.StatusApproved, now)
+
+	return
+}
+
+func (r *CountRepository) Cancel(ctx context.Context, id string) (err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	data, ok := r.db[id]
+	if !ok {
+		return storage.ErrorNotFound
+	}
+
+	if data.Status != count.StatusOpen {
+		return count.ErrorNotOpen
+	}
+
+	r.close(data, count.StatusCancelled, time.Now())
+
+	return
+}
+
+// close ends the session and unmarks its inventories, the caller must hold the lock.
+func (r *CountRepository) close(data count.Entity, status string, now time.Time) {
+	for _, item := range data.Items {
+		r.mark(item.InventoryID, nil)
+	}
+
+	data.Status = status
+	data.ClosedAt = &now
+	data.UpdatedAt = now
+	r.db[data.ID] = data
+	delete(r.entries, data.ID)
+}
+
+// mark sets the session counting the inventory, the caller must hold the lock.
+func (r *CountRepository) mark(inventoryID string, countID *string) {
+	if inventoryData, ok := r.inventories.db[inventoryID]; ok {
+		inventoryData.CountID = countID
+		r.inventories.db[inventoryID] = inventoryData
+	}
+}
+
+// copy detaches the items, so the caller cannot change the stored session.
+func (r *CountRepository) copy(data count.Entity) count.Entity {
+	data.Items = append([]count.Item{}, data.Items...)
+	return data
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"warehouse-service/internal/domain/count"
+	"warehouse-service/internal/domain/movement"
+)
+
+func TestCountApproveKeepsSales(t *testing.T) {
+	tests := []struct {
+		name    string
+		before  int // sold between the opening and the count
+		counted int
+		after   int // sold between the count and the approval
+		want    int
+	}{
+		{"nothing sold", 0, 9, 0, 9},
+		{"sold before the count", 3, 7, 0, 7},
+		{"sold after the count", 0, 10, 3, 7},
+		{"sold before and after the count", 3, 6, 2, 4},
+		{"nothing missing", 3, 7, 2, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			sale := movement.Entity{Reason: movement.ReasonSale}
+
+			inventories := NewInventoryRepository()
+			counts := NewCountRepository(inventories)
+			inventoryID := newStocked(t, inventories, 10, false)
+
+			id, err := counts.Create(ctx, count.Entity{StoreID: "store"}, nil)
+			if err != nil {
+				t.Fatalf("open the session: %v", err)
+			}
+
+			if tt.before > 0 {
+				if _, err = inventories.Adjust(ctx, inventoryID, -tt.before, sale); err != nil {
+					t.Fatalf("sell before the count: %v", err)
+				}
+			}
+
+			if err = counts.Record(ctx, id, count.Entry{ProductID: "product", Counter: "counter", Quantity: tt.counted}); err != nil {
+				t.Fatalf("record: %v", err)
+			}
+
+			if tt.after > 0 {
+				if _, err = inventories.Adjust(ctx, inventoryID, -tt.after, sale); err != nil {
+					t.Fatalf("sell after the count: %v", err)
+				}
+			}
+
+			if err = counts.Approve(ctx, id, movement.Entity{Reason: movement.ReasonAdjustment}); err != nil {
+				t.Fatalf("approve: %v", err)
+			}
+
+			if quantity := quantityIn(t, inventories, inventoryID); quantity != tt.want {
+				t.Fatalf("quantity is %d, want %d", quantity, tt.want)
+			}
+		})
+	}
+}
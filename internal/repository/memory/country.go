@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"warehouse-service/internal/domain/country"
+	"warehouse-service/pkg/storage"
+)
+
+type CountryRepository struct {
+	db map[string]country.Entity
+	sync.RWMutex
+}
+
+func NewCountryRepository() *CountryRepository {
+	return &CountryRepository{
+		db: make(map[string]country.Entity),
+	}
+}
+
+func (r *CountryRepository) Select(ctx context.Context) (dest []country.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]country.Entity, 0, len(r.db))
+	for _, data := range r.db {
+		dest = append(dest, data)
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return *dest[i].Name < *dest[j].Name
+	})
+
+	return
+}
+
+func (r *CountryRepository) Create(ctx context.Context, data country.Entity) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.taken(data, "") {
+		return "", country.ErrorDuplicate
+	}
+
+	id = uuid.New().String()
+	data.ID = id
+	data.CreatedAt = time.Now()
+	data.UpdatedAt = data.CreatedAt
+	r.db[id] = data
+
+	return
+}
+
+func (r *CountryRepository) Get(ctx context.Context, id string) (dest country.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = storage.ErrorNotFound
+	}
+
+	return
+}
+
+func (r *CountryRepository) Update(ctx context.Context, id string, data country.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.db[id]
+	if !ok {
+		return storage.ErrorNotFound
+	}
+
+	if r.taken(data, id) {
+		return country.ErrorDuplicate
+	}
+
+	if data.Name != nil {
+		current.Name = data.Name
+	}
+
+	if data.TimeZone != nil {
+		current.TimeZone = data.TimeZone
+	}
+	current.UpdatedAt = time.Now()
+	r.db[id] = current
+
+	return
+}
+
+func (r *CountryRepository) Delete(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.db[id]; !ok {
+		return storage.ErrorNotFound
+	}
+	delete(r.db, id)
+
+	return
+}
+
+// taken tells whether another country than the one with the id has the name of the data.
+func (r *CountryRepository) taken(data country.Entity, id string) bool {
+	if data.Name == nil {
+		return false
+	}
+
+	for _, object := range r.db {
+		if object.ID != id && *object.Name == *data.Name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,165 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"warehouse-service/internal/domain/currency"
+	"warehouse-service/pkg/storage"
+)
+
+// CurrencyRepository keeps one currency per country, Get and Update find it by the
+// id of its country.
+type CurrencyRepository struct {
+	db map[string]currency.Entity
+	sync.RWMutex
+}
+
+func NewCurrencyRepository() *CurrencyRepository {
+	return &CurrencyRepository{
+		db: make(map[string]currency.Entity),
+	}
+}
+
+func (r *CurrencyRepository) Select(ctx context.Context) (dest []currency.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]currency.Entity, 0, len(r.db))
+	for _, data := range r.db {
+		dest = append(dest, data)
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return *dest[i].Sign < *dest[j].Sign
+	})
+
+	return
+}
+
+func (r *CurrencyRepository) Create(ctx context.Context, data currency.Entity) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.taken(data, "") {
+		return "", currency.ErrorDuplicate
+	}
+
+	id = uuid.New().String()
+	data.ID = id
+	data.CreatedAt = time.Now()
+	data.UpdatedAt = data.CreatedAt
+	r.db[id] = data
+
+	return
+}
+
+// Get returns the currency of the country, nil when it has none.
+func (r *CurrencyRepository) Get(ctx context.Context, countryID string) (dest *currency.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.find(func(data currency.Entity) bool {
+		return data.CountryID == countryID
+	}), nil
+}
+
+func (r *CurrencyRepository) GetByID(ctx context.Context, id string) (dest *currency.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.find(func(data currency.Entity) bool {
+		return data.ID == id
+	}), nil
+}
+
+func (r *CurrencyRepository) GetByCode(ctx context.Context, code string) (dest *currency.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.find(func(data currency.Entity) bool {
+		return data.Code != nil && *data.Code == code
+	}), nil
+}
+
+func (r *CurrencyRepository) Update(ctx context.Context, countryID string, data currency.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current := r.find(func(object currency.Entity) bool {
+		return object.CountryID == countryID
+	})
+	// a country without a currency is left alone, as the UPDATE of the postgres repository does
+	if current == nil {
+		return
+	}
+
+	if r.taken(data, current.ID) {
+		return currency.ErrorDuplicate
+	}
+
+	if data.CountryID != "" {
+		current.CountryID = data.CountryID
+	}
+
+	if data.Code != nil {
+		current.Code = data.Code
+	}
+
+	if data.Sign != nil {
+		current.Sign = data.Sign
+	}
+
+	if data.Decimals != nil {
+		current.Decimals = data.Decimals
+	}
+	current.Prefix = data.Prefix
+	current.UpdatedAt = time.Now()
+	r.db[current.ID] = *current
+
+	return
+}
+
+func (r *CurrencyRepository) Delete(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.db[id]; !ok {
+		return storage.ErrorNotFound
+	}
+	delete(r.db, id)
+
+	return
+}
+
+func (r *CurrencyRepository) find(match func(data currency.Entity) bool) *currency.Entity {
+	for _, data := range r.db {
+		if match(data) {
+			return &data
+		}
+	}
+
+	return nil
+}
+
+// taken tells whether another currency than the one with the id has the sign, the
+// code or the country of the data.
+func (r *CurrencyRepository) taken(data currency.Entity, id string) bool {
+	for _, object := range r.db {
+		if object.ID == id {
+			continue
+		}
+
+		if data.Sign != nil && *object.Sign == *data.Sign ||
+			data.Code != nil && object.Code != nil && *object.Code == *data.Code ||
+			data.CountryID != "" && object.CountryID == data.CountryID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"warehouse-service/internal/domain/delivery"
+	"warehouse-service/pkg/storage"
+)
+
+// DeliveryRepository keeps one delivery per store, keyed by the store id.
+type DeliveryRepository struct {
+	db map[string]delivery.Entity
+	sync.RWMutex
+}
+
+func NewDeliveryRepository() *DeliveryRepository {
+	return &DeliveryRepository{
+		db: make(map[string]delivery.Entity),
+	}
+}
+
+func (r *DeliveryRepository) SelectByStores(ctx context.Context, storeIDs []string) (dest []delivery.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]delivery.Entity, 0, len(storeIDs))
+	for _, storeID := range storeIDs {
+		if data, ok := r.db[storeID]; ok {
+			dest = append(dest, data)
+		}
+	}
+
+	return
+}
+
+func (r *DeliveryRepository) Create(ctx context.Context, data delivery.Entity) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id = uuid.New().String()
+	data.ID = id
+	data.CreatedAt = time.Now()
+	data.UpdatedAt = data.CreatedAt
+	r.db[data.StoreID] = data
+
+	return
+}
+
+// Get returns nil for a store without a delivery, as the postgres repository does.
+func (r *DeliveryRepository) Get(ctx context.Context, storeID string) (dest *delivery.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	data, ok := r.db[storeID]
+	if !ok {
+		return
+	}
+	dest = &data
+
+	return
+}
+
+// Update sets the fields given, zero slot settings and nil lists keep the ones stored.
+func (r *DeliveryRepository) Update(ctx context.Context, storeID string, data *delivery.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.db[storeID]
+	if !ok {
+		return storage.ErrorNotFound
+	}
+
+	current.IsActive = data.IsActive
+	if data.Periods != nil {
+		current.Periods = data.Periods
+	}
+
+	if data.Areas != nil {
+		current.Areas = data.Areas
+	}
+
+	if data.SlotMinutes > 0 {
+		current.SlotMinutes = data.SlotMinutes
+	}
+
+	if data.SlotCapacity > 0 {
+		current.SlotCapacity = data.SlotCapacity
+	}
+
+	// empty fees remove the stored ones
+	if data.Fees != nil {
+		current.Fees = nil
+		if len(data.Fees) > 0 {
+			current.Fees = data.Fees
+		}
+	}
+	current.UpdatedAt = time.Now()
+	r.db[storeID] = current
+
+	return
+}
+
+func (r *DeliveryRepository) Delete(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for storeID, object := range r.db {
+		if object.ID == id {
+			delete(r.db, storeID)
+			return
+		}
+	}
+
+	return storage.ErrorNotFound
+}
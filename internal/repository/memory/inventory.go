@@ -3,19 +3,39 @@ package memory
 import (
 	"context"
 	"github.com/google/uuid"
+	"sort"
+	"strconv"
 	"sync"
+	"time"
 	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/lot"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/serial"
 	"warehouse-service/pkg/storage"
 )
 
 type InventoryRepository struct {
-	db map[string]inventory.Entity
+	db        map[string]inventory.Entity
+	keys      map[string]string
+	movements []movement.Entity
+	lots      map[string][]lot.Entity
+	serials   map[string]serial.Entity
+	bins      map[string]map[string]int
+
+	// reservations is set by NewReservationRepository, the holds share the lock of the inventories
+	reservations *ReservationRepository
+	// prices is set by NewPriceRepository, a batch keeps the imported prices in the histories, if any
+	prices *PriceRepository
 	sync.RWMutex
 }
 
 func NewInventoryRepository() *InventoryRepository {
 	return &InventoryRepository{
-		db: make(map[string]inventory.Entity),
+		db:      make(map[string]inventory.Entity),
+		keys:    make(map[string]string),
+		lots:    make(map[string][]lot.Entity),
+		serials: make(map[string]serial.Entity),
+		bins:    make(map[string]map[string]int),
 	}
 }
 
@@ -31,16 +51,93 @@ func (r *InventoryRepository) Select(ctx context.Context) (dest []inventory.Enti
 	return
 }
 
-func (r *InventoryRepository) Create(ctx context.Context, data inventory.Entity) (dest string, err error) {
+// Each copies the matching items first, so a slow fn does not hold the lock.
+func (r *InventoryRepository) Each(ctx context.Context, filter inventory.Filter, fn func(data inventory.Entity) error) (err error) {
+	r.RLock()
+	dest := make([]inventory.Entity, 0)
+	for _, data := range r.db {
+		if filter.StoreID != "" && data.StoreID != filter.StoreID {
+			continue
+		}
+		if filter.CatalogID != "" && data.CatalogID != filter.CatalogID {
+			continue
+		}
+		dest = append(dest, data)
+	}
+	r.RUnlock()
+
+	sort.Slice(dest, func(i, j int) bool {
+		return naturalKey(dest[i]) < naturalKey(dest[j])
+	})
+
+	for _, data := range dest {
+		if err = fn(data); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (r *InventoryRepository) Create(ctx context.Context, data inventory.Entity, change movement.Entity) (dest string, err error) {
 	r.Lock()
 	defer r.Unlock()
 
-	id := r.generateID()
-	data.ID = id
-	r.db[id] = data
+	if _, ok := r.keys[naturalKey(data)]; ok {
+		return "", inventory.ErrorDuplicate
+	}
 
-	return id, nil
+	return r.create(data, change)
+}
 
+func (r *InventoryRepository) Upsert(ctx context.Context, data inventory.Entity, change movement.Entity) (dest string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id, ok := r.keys[naturalKey(data)]
+	if !ok {
+		return r.create(data, change)
+	}
+
+	return id, r.update(id, data, change)
+}
+
+func (r *InventoryRepository) UpsertBatch(ctx context.Context, data []inventory.Entity, change movement.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	// check every quantity up front, so a bad row leaves the batch unapplied
+	for _, object := range data {
+		quantity, err := quantityOf(object)
+		if err != nil {
+			return err
+		}
+
+		if id, ok := r.keys[naturalKey(object)]; ok && r.serialized(id) {
+			if current, _ := quantityOf(r.db[id]); current != quantity {
+				return inventory.ErrorSerialized
+			}
+		}
+	}
+
+	now := time.Now()
+	for _, object := range data {
+		id, ok := r.keys[naturalKey(object)]
+		if !ok {
+			id, err = r.create(object, change)
+		} else {
+			err = r.update(id, object, change)
+		}
+		if err != nil {
+			return
+		}
+
+		if r.prices != nil && object.Price != nil {
+			r.prices.follow(id, *object.Price, object.PriceSpecial, now)
+		}
+	}
+
+	return
 }
 
 func (r *InventoryRepository) Get(ctx context.Context, id string) (dest inventory.Entity, err error) {
@@ -56,14 +153,43 @@ func (r *InventoryRepository) Get(ctx context.Context, id string) (dest inventor
 	return
 }
 
-func (r *InventoryRepository) Update(ctx context.Context, id string, data inventory.Entity) (err error) {
+func (r *InventoryRepository) Update(ctx context.Context, id string, data inventory.Entity, change movement.Entity) (err error) {
 	r.Lock()
 	defer r.Unlock()
 
-	if _, ok := r.db[id]; !ok {
-		return storage.ErrorNotFound
+	return r.update(id, data, change)
+}
+
+func (r *InventoryRepository) Adjust(ctx context.Context, id string, delta int, change movement.Entity) (balance int, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.db[id]
+	if !ok {
+		return 0, storage.ErrorNotFound
 	}
-	r.db[id] = data
+
+	if r.serialized(id) {
+		return 0, inventory.ErrorSerialized
+	}
+
+	quantity, err := quantityOf(current)
+	if err != nil {
+		return
+	}
+
+	// stock held by active reservations cannot be taken
+	balance = quantity + delta
+	if delta < 0 && r.available(id, balance) < 0 && (current.AllowBackorder == nil || !*current.AllowBackorder) {
+		return 0, inventory.ErrorInsufficientStock
+	}
+
+	value := strconv.Itoa(balance)
+	current.Quantity = &value
+	current.UpdatedAt = time.Now()
+	r.db[id] = current
+
+	r.record(id, delta, balance, change)
 
 	return
 }
@@ -72,14 +198,202 @@ func (r *InventoryRepository) Delete(ctx context.Context, id string) (err error)
 	r.Lock()
 	defer r.Unlock()
 
-	if _, ok := r.db[id]; !ok {
+	data, ok := r.db[id]
+	if !ok {
 		return storage.ErrorNotFound
 	}
 	delete(r.db, id)
+	delete(r.keys, naturalKey(data))
+	delete(r.lots, id)
+	delete(r.bins, id)
+	for key, unit := range r.serials {
+		if unit.InventoryID == id {
+			delete(r.serials, key)
+		}
+	}
 
 	return
 }
 
+// create stores a new inventory, the caller must hold the lock.
+func (r *InventoryRepository) create(data inventory.Entity, change movement.Entity) (id string, err error) {
+	quantity, err := quantityOf(data)
+	if err != nil {
+		return
+	}
+
+	id = r.generateID()
+	data.ID = id
+	data.CreatedAt = time.Now()
+	data.UpdatedAt = data.CreatedAt
+	r.db[id] = data
+	r.keys[naturalKey(data)] = id
+
+	if quantity != 0 {
+		r.record(id, quantity, quantity, change)
+	}
+
+	return
+}
+
+// update merges the data into the stored inventory, the caller must hold the lock.
+func (r *InventoryRepository) update(id string, data inventory.Entity, change movement.Entity) (err error) {
+	current, ok := r.db[id]
+	if !ok {
+		return storage.ErrorNotFound
+	}
+
+	before, err := quantityOf(current)
+	if err != nil {
+		return
+	}
+
+	current = r.merge(current, data)
+
+	after, err := quantityOf(current)
+	if err != nil {
+		return
+	}
+
+	if after != before && r.serialized(id) {
+		return inventory.ErrorSerialized
+	}
+	r.db[id] = current
+
+	if after != before {
+		r.record(id, after-before, after, change)
+	}
+
+	return
+}
+
+// merge applies the fields that are set, the same way the postgres repository builds its SET clause.
+func (r *InventoryRepository) merge(current, data inventory.Entity) inventory.Entity {
+	if data.Quantity != nil {
+		current.Quantity = data.Quantity
+	}
+	if data.QuantityMin != nil {
+		current.QuantityMin = data.QuantityMin
+	}
+	if data.QuantityMax != nil {
+		current.QuantityMax = data.QuantityMax
+	}
+	if data.Price != nil {
+		current.Price = data.Price
+	}
+	if data.PriceSpecial != nil {
+		current.PriceSpecial = data.PriceSpecial
+	}
+	if data.PricePrevious != nil {
+		current.PricePrevious = data.PricePrevious
+	}
+	if data.IsAvailable != nil {
+		current.IsAvailable = data.IsAvailable
+	}
+	if data.AllowBackorder != nil {
+		current.AllowBackorder = data.AllowBackorder
+	}
+	current.UpdatedAt = time.Now()
+
+	return current
+}
+
+// record appends a movement to the journal and takes a decrement out of the lots,
+// the caller must hold the lock. Every quantity change passes through here.
+func (r *InventoryRepository) record(id string, delta, balance int, change movement.Entity) {
+	change.ID = r.generateID()
+	change.InventoryID = id
+	change.Delta = delta
+	change.Balance = balance
+	change.CreatedAt = time.Now()
+
+	r.movements = append(r.movements, change)
+
+	if delta < 0 {
+		r.consume(id, -delta)
+		r.unbin(id, balance)
+	}
+}
+
+// consume takes the quantity out of the lots first-expiry-first-out and drops the emptied ones.
+func (r *InventoryRepository) consume(id string, quantity int) {
+	lots := r.lots[id]
+	for len(lots) > 0 && quantity > 0 {
+		if lots[0].Quantity > quantity {
+			lots[0].Quantity -= quantity
+			lots[0].UpdatedAt = time.Now()
+			break
+		}
+		quantity -= lots[0].Quantity
+		lots = lots[1:]
+	}
+
+	if len(lots) == 0 {
+		delete(r.lots, id)
+		return
+	}
+	r.lots[id] = lots
+}
+
+// unbin takes the stock the bins hold over the balance out of the bins holding
+// the least, the unassigned stock having gone first.
+func (r *InventoryRepository) unbin(id string, balance int) {
+	bins := r.bins[id]
+	if balance < 0 {
+		balance = 0
+	}
+
+	excess := -balance
+	ids := make([]string, 0, len(bins))
+	for binID, quantity := range bins {
+		excess += quantity
+		ids = append(ids, binID)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		if bins[ids[i]] != bins[ids[j]] {
+			return bins[ids[i]] < bins[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	for _, binID := range ids {
+		if excess <= 0 {
+			break
+		}
+		if bins[binID] > excess {
+			bins[binID] -= excess
+			break
+		}
+		excess -= bins[binID]
+		delete(bins, binID)
+	}
+
+	if len(bins) == 0 {
+		delete(r.bins, id)
+	}
+}
+
+// available returns the part of the quantity not held by active reservations, the
+// caller must hold the lock.
+func (r *InventoryRepository) available(id string, quantity int) int {
+	if r.reservations == nil {
+		return quantity
+	}
+	return quantity - r.reservations.held(id, time.Now())
+}
+
+// serialized reports whether the quantity of the inventory follows its serial
+// numbers and cannot be changed directly, the caller must hold the lock.
+func (r *InventoryRepository) serialized(id string) bool {
+	data, ok := r.db[id]
+	return ok && data.IsSerialized != nil && *data.IsSerialized
+}
+
 func (r *InventoryRepository) generateID() string {
 	return uuid.New().String()
 }
+
+func naturalKey(data inventory.Entity) string {
+	return data.StoreID + "/" + data.ProductID
+}
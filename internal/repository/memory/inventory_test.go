@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/reservation"
+)
+
+func TestInventoryAdjustStopsAtZero(t *testing.T) {
+	const stock, sellers = 10, 50
+
+	inventories := NewInventoryRepository()
+	NewReservationRepository(inventories)
+	inventoryID := newStocked(t, inventories, stock, false)
+
+	succeeded := adjustConcurrently(t, inventories, inventoryID, sellers)
+	if succeeded != stock {
+		t.Fatalf("%d adjustments succeeded, want %d", succeeded, stock)
+	}
+
+	if quantity := quantityIn(t, inventories, inventoryID); quantity != 0 {
+		t.Fatalf("quantity is %d, want 0", quantity)
+	}
+}
+
+func TestInventoryAdjustLeavesHeldStock(t *testing.T) {
+	const stock, hold, sellers = 10, 4, 50
+
+	inventories := NewInventoryRepository()
+	reservations := NewReservationRepository(inventories)
+	inventoryID := newStocked(t, inventories, stock, false)
+
+	_, err := reservations.Create(context.Background(), reservation.Entity{
+		InventoryID: inventoryID,
+		Quantity:    hold,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+
+	succeeded := adjustConcurrently(t, inventories, inventoryID, sellers)
+	if succeeded != stock-hold {
+		t.Fatalf("%d adjustments succeeded, want %d", succeeded, stock-hold)
+	}
+
+	if quantity := quantityIn(t, inventories, inventoryID); quantity != hold {
+		t.Fatalf("quantity is %d, want the %d held", quantity, hold)
+	}
+}
+
+func TestInventoryAdjustRacingHolds(t *testing.T) {
+	const stock, workers = 10, 50
+
+	inventories := NewInventoryRepository()
+	reservations := NewReservationRepository(inventories)
+	inventoryID := newStocked(t, inventories, stock, false)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+
+			_, err := inventories.Adjust(context.Background(), inventoryID, -1, movement.Entity{Reason: movement.ReasonSale})
+			if err != nil && err != inventory.ErrorInsufficientStock {
+				t.Errorf("adjust: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+
+			_, err := reservations.Create(context.Background(), reservation.Entity{
+				InventoryID: inventoryID,
+				Quantity:    1,
+				ExpiresAt:   time.Now().Add(time.Hour),
+			})
+			if err != nil && err != inventory.ErrorInsufficientStock {
+				t.Errorf("create reservation: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	quantity := quantityIn(t, inventories, inventoryID)
+	if held := reservations.held(inventoryID, time.Now()); quantity != held {
+		t.Fatalf("quantity is %d with %d held, want the stock sold or held to the last unit", quantity, held)
+	}
+}
+
+func TestInventoryAdjustBackorder(t *testing.T) {
+	inventories := NewInventoryRepository()
+	NewReservationRepository(inventories)
+	inventoryID := newStocked(t, inventories, 1, true)
+
+	balance, err := inventories.Adjust(context.Background(), inventoryID, -3, movement.Entity{Reason: movement.ReasonSale})
+	if err != nil {
+		t.Fatalf("adjust: %v", err)
+	}
+
+	if balance != -2 {
+		t.Fatalf("balance is %d, want -2", balance)
+	}
+}
+
+// adjustConcurrently takes one unit at a time from as many goroutines and counts the successes.
+func adjustConcurrently(t *testing.T, inventories *InventoryRepository, inventoryID string, times int) (succeeded int) {
+	t.Helper()
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	for i := 0; i < times; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			balance, err := inventories.Adjust(context.Background(), inventoryID, -1, movement.Entity{Reason: movement.ReasonSale})
+			if err == inventory.ErrorInsufficientStock {
+				return
+			}
+			if err != nil {
+				t.Errorf("adjust: %v", err)
+				return
+			}
+
+			if balance < 0 {
+				t.Errorf("balance went down to %d", balance)
+			}
+
+			mu.Lock()
+			succeeded++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return
+}
+
+func quantityIn(t *testing.T, inventories *InventoryRepository, inventoryID string) int {
+	t.Helper()
+
+	data, err := inventories.Get(context.Background(), inventoryID)
+	if err != nil {
+		t.Fatalf("get inventory: %v", err)
+	}
+
+	quantity, err := strconv.Atoi(*data.Quantity)
+	if err != nil {
+		t.Fatalf("quantity: %v", err)
+	}
+
+	return quantity
+}
+
+func TestInventoryUpsertBatchWithoutPrices(t *testing.T) {
+	inventories := NewInventoryRepository()
+
+	quantity, price := "3", "10"
+	err := inventories.UpsertBatch(context.Background(), []inventory.Entity{{
+		StoreID:   "store",
+		ProductID: "product",
+		Quantity:  &quantity,
+		Price:     &price,
+	}}, movement.Entity{Reason: movement.ReasonReceipt})
+	if err != nil {
+		t.Fatalf("upsert batch: %v", err)
+	}
+}
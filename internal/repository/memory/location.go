@@ -0,0 +1,289 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/location"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/pkg/storage"
+)
+
+// LocationRepository keeps the locations of the stores while the stock put away
+// in bins is kept by the inventory repository, under its lock.
+type LocationRepository struct {
+	db          map[string]location.Entity
+	inventories *InventoryRepository
+}
+
+func NewLocationRepository(inventories *InventoryRepository) *LocationRepository {
+	return &LocationRepository{
+		db:          make(map[string]location.Entity),
+		inventories: inventories,
+	}
+}
+
+func (r *LocationRepository) Select(ctx context.Context, storeID string) (dest []location.Entity, err error) {
+	r.inventories.RLock()
+	defer r.inventories.RUnlock()
+
+	dest = make([]location.Entity, 0)
+	for _, data := range r.db {
+		if data.StoreID == storeID {
+			dest = append(dest, data)
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return r.path(dest[i].ID) < r.path(dest[j].ID)
+	})
+
+	return
+}
+
+func (r *LocationRepository) Get(ctx context.Context, id string) (dest location.Entity, err error) {
+	r.inventories.RLock()
+	defer r.inventories.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = storage.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *LocationRepository) Create(ctx context.Context, data location.Entity) (id string, err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	parentID := ""
+	if data.ParentID != nil {
+		parentID = *data.ParentID
+	}
+
+	if parentID != "" {
+		parent, ok := r.db[parentID]
+		if !ok || parent.StoreID != data.StoreID || parent.Kind != location.ParentKind(data.Kind) {
+			return "", location.ErrorParent
+		}
+	}
+
+	for _, current := range r.db {
+		if current.StoreID == data.StoreID && current.Code == data.Code &&
+			(current.ParentID == nil && parentID == "" || current.ParentID != nil && *current.ParentID == parentID) {
+			return "", location.ErrorDuplicate
+		}
+	}
+
+	id = r.inventories.generateID()
+	data.ID = id
+	data.CreatedAt = time.Now()
+	r.db[id] = data
+
+	return
+}
+
+func (r *LocationRepository) Delete(ctx context.Context, id string) (err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	if _, ok := r.db[id]; !ok {
+		return storage.ErrorNotFound
+	}
+
+	for _, data := range r.db {
+		if data.ParentID != nil && *data.ParentID == id {
+			return location.ErrorNotEmpty
+		}
+	}
+
+	for _, bins := range r.inventories.bins {
+		if bins[id] > 0 {
+			return location.ErrorNotEmpty
+		}
+	}
+	delete(r.db, id)
+
+	return
+}
+
+func (r *LocationRepository) SelectStock(ctx context.Context, filter location.Filter) (dest []location.Stock, err error) {
+	r.inventories.RLock()
+	defer r.inventories.RUnlock()
+
+	dest = make([]location.Stock, 0)
+	for inventoryID, bins := range r.inventories.bins {
+		inventoryData := r.inventories.db[inventoryID]
+		if filter.InventoryID != "" && filter.InventoryID != inventoryID ||
+			filter.StoreID != "" && filter.StoreID != inventoryData.StoreID ||
+			filter.ProductID != "" && filter.ProductID != inventoryData.ProductID {
+			continue
+		}
+
+		for binID, quantity := range bins {
+			dest = append(dest, location.Stock{
+				LocationID:  binID,
+				InventoryID: inventoryID,
+				StoreID:     inventoryData.StoreID,
+				ProductID:   inventoryData.ProductID,
+				Path:        r.path(binID),
+				Quantity:    quantity,
+			})
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		if dest[i].Path != dest[j].Path {
+			return dest[i].Path < dest[j].Path
+		}
+		return dest[i].InventoryID < dest[j].InventoryID
+	})
+
+	return
+}
+
+func (r *LocationRepository) Move(ctx context.Context, inventoryID, fromID, toID string, quantity int) (err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	inventoryData, ok := r.inventories.db[inventoryID]
+	if !ok {
+		return storage.ErrorNotFound
+	}
+
+	for _, id := range []string{fromID, toID} {
+		if id != "" && !r.isBin(id, inventoryData.StoreID) {
+			return location.ErrorNotBin
+		}
+	}
+
+	bins := r.inventories.bins[inventoryID]
+	if bins == nil {
+		bins = make(map[string]int)
+	}
+
+	if fromID == "" {
+		total, err := quantityOf(inventoryData)
+		if err != nil {
+			return err
+		}
+
+		for _, held := range bins {
+			total -= held
+		}
+		if total < quantity {
+			return location.ErrorUnassigned
+		}
+	} else {
+		if bins[fromID] < quantity {
+			return inventory.ErrorInsufficientStock
+		}
+
+		if bins[fromID] -= quantity; bins[fromID] == 0 {
+			delete(bins, fromID)
+		}
+	}
+
+	if toID != "" {
+		bins[toID] += quantity
+	}
+
+	if len(bins) == 0 {
+		delete(r.inventories.bins, inventoryID)
+		return
+	}
+	r.inventories.bins[inventoryID] = bins
+
+	return
+}
+
+func (r *LocationRepository) Adjust(ctx context.Context, inventoryID, binID string, delta int, change movement.Entity) (balance int, err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	current, ok := r.inventories.db[inventoryID]
+	if !ok {
+		return 0, storage.ErrorNotFound
+	}
+
+	if r.inventories.serialized(inventoryID) {
+		return 0, inventory.ErrorSerialized
+	}
+
+	if !r.isBin(binID, current.StoreID) {
+		return 0, location.ErrorNotBin
+	}
+
+	bins := r.inventories.bins[inventoryID]
+	if bins == nil {
+		bins = make(map[string]int)
+	}
+
+	if bins[binID]+delta < 0 {
+		return 0, inventory.ErrorInsufficientStock
+	}
+
+	quantity, err := quantityOf(current)
+	if err != nil {
+		return
+	}
+	balance = quantity + delta
+
+	// a pick cannot take stock held by active reservations
+	if delta < 0 && r.inventories.available(inventoryID, balance) < 0 {
+		return 0, inventory.ErrorInsufficientStock
+	}
+
+	// stock filling backorders leaves right away, only what remains is put away
+	put := delta
+	if delta > 0 && balance < put {
+		put = balance
+	}
+
+	if put > 0 || delta < 0 {
+		bins[binID] += put
+	}
+	if bins[binID] <= 0 {
+		delete(bins, binID)
+	}
+
+	if len(bins) == 0 {
+		delete(r.inventories.bins, inventoryID)
+	} else {
+		r.inventories.bins[inventoryID] = bins
+	}
+
+	value := strconv.Itoa(balance)
+	current.Quantity = &value
+	current.UpdatedAt = time.Now()
+	r.inventories.db[inventoryID] = current
+
+	r.inventories.record(inventoryID, delta, balance, change)
+
+	return
+}
+
+// isBin reports whether the location is a bin of the store, the caller must hold the lock.
+func (r *LocationRepository) isBin(id, storeID string) bool {
+	data, ok := r.db[id]
+	return ok && data.Kind == location.KindBin && data.StoreID == storeID
+}
+
+// path joins the codes from the zone down to the location, the caller must hold the lock.
+func (r *LocationRepository) path(id string) string {
+	codes := make([]string, 0, 3)
+	for data, ok := r.db[id]; ok; {
+		codes = append([]string{data.Code}, codes...)
+		if data.ParentID == nil {
+			break
+		}
+		data, ok = r.db[*data.ParentID]
+	}
+	return strings.Join(codes, "/")
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/location"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/reservation"
+)
+
+func TestLocationAdjustLeavesHeldStock(t *testing.T) {
+	const stock, hold, pickers = 10, 4, 50
+
+	inventories := NewInventoryRepository()
+	reservations := NewReservationRepository(inventories)
+	locations := NewLocationRepository(inventories)
+	inventoryID := newStocked(t, inventories, 0, false)
+
+	binID, err := locations.Create(context.Background(), location.Entity{StoreID: "store", Kind: location.KindBin, Code: "A-1"})
+	if err != nil {
+		t.Fatalf("create bin: %v", err)
+	}
+
+	if _, err = locations.Adjust(context.Background(), inventoryID, binID, stock, movement.Entity{Reason: movement.ReasonReceipt}); err != nil {
+		t.Fatalf("put away: %v", err)
+	}
+
+	_, err = reservations.Create(context.Background(), reservation.Entity{
+		InventoryID: inventoryID,
+		Quantity:    hold,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("create reservation: %v", err)
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		picked int
+	)
+
+	for i := 0; i < pickers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := locations.Adjust(context.Background(), inventoryID, binID, -1, movement.Entity{Reason: movement.ReasonSale})
+			if err == inventory.ErrorInsufficientStock {
+				return
+			}
+			if err != nil {
+				t.Errorf("pick: %v", err)
+				return
+			}
+
+			mu.Lock()
+			picked++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if picked != stock-hold {
+		t.Fatalf("%d picks succeeded, want %d", picked, stock-hold)
+	}
+
+	if quantity := quantityIn(t, inventories, inventoryID); quantity != hold {
+		t.Fatalf("quantity is %d, want the %d held", quantity, hold)
+	}
+}
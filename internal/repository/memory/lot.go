@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/lot"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/pkg/storage"
+)
+
+// LotRepository reads and fills the lots kept by the inventory repository,
+// which takes every decrement out of them.
+type LotRepository struct {
+	inventories *InventoryRepository
+}
+
+func NewLotRepository(inventories *InventoryRepository) *LotRepository {
+	return &LotRepository{
+		inventories: inventories,
+	}
+}
+
+func (r *LotRepository) Select(ctx context.Context, inventoryID string) (dest []lot.Entity, err error) {
+	r.inventories.RLock()
+	defer r.inventories.RUnlock()
+
+	dest = append(make([]lot.Entity, 0), r.inventories.lots[inventoryID]...)
+
+	return
+}
+
+func (r *LotRepository) SelectExpiring(ctx context.Context, storeID string, before time.Time) (dest []lot.Entity, err error) {
+	r.inventories.RLock()
+	defer r.inventories.RUnlock()
+
+	dest = make([]lot.Entity, 0)
+	for id, lots := range r.inventories.lots {
+		if r.inventories.db[id].StoreID != storeID {
+			continue
+		}
+		for _, data := range lots {
+			if data.ExpiresAt.Before(before) {
+				dest = append(dest, data)
+			}
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return lot.Less(dest[i], dest[j])
+	})
+
+	return
+}
+
+func (r *LotRepository) Receive(ctx context.Context, data lot.Entity, change movement.Entity) (balance int, err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	inventoryData, ok := r.inventories.db[data.InventoryID]
+	if !ok {
+		return 0, storage.ErrorNotFound
+	}
+
+	if r.inventories.serialized(data.InventoryID) {
+		return 0, inventory.ErrorSerialized
+	}
+
+	quantity, err := quantityOf(inventoryData)
+	if err != nil {
+		return
+	}
+
+	lots := r.inventories.lots[data.InventoryID]
+	index := -1
+	for i, current := range lots {
+		if current.Number == data.Number {
+			if !current.ExpiresAt.Equal(data.ExpiresAt) {
+				return 0, lot.ErrorExpiryMismatch
+			}
+			index = i
+		}
+	}
+
+	// stock filling backorders leaves right away, only what remains goes into the lot
+	received := data.Quantity
+	balance = quantity + data.Quantity
+	if balance < received {
+		received = balance
+	}
+
+	now := time.Now()
+	switch {
+	case received <= 0:
+	case index < 0:
+		data.StoreID = inventoryData.StoreID
+		data.ProductID = inventoryData.ProductID
+		data.Quantity = received
+		data.CreatedAt = now
+		data.UpdatedAt = now
+		lots = append(lots, data)
+		sort.Slice(lots, func(i, j int) bool {
+			return lot.Less(lots[i], lots[j])
+		})
+		r.inventories.lots[data.InventoryID] = lots
+	default:
+		lots[index].Quantity += received
+		lots[index].UpdatedAt = now
+	}
+
+	value := strconv.Itoa(balance)
+	inventoryData.Quantity = &value
+	inventoryData.UpdatedAt = now
+	r.inventories.db[data.InventoryID] = inventoryData
+	r.inventories.record(data.InventoryID, balance-quantity, balance, change)
+
+	return
+}
@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"warehouse-service/internal/domain/movement"
+)
+
+// MovementRepository reads the journal kept by the inventory repository.
+type MovementRepository struct {
+	inventories *InventoryRepository
+}
+
+func NewMovementRepository(inventories *InventoryRepository) *MovementRepository {
+	return &MovementRepository{
+		inventories: inventories,
+	}
+}
+
+func (r *MovementRepository) Select(ctx context.Context, inventoryID string) (dest []movement.Entity, err error) {
+	r.inventories.RLock()
+	defer r.inventories.RUnlock()
+
+	dest = make([]movement.Entity, 0)
+	for _, data := range r.inventories.movements {
+		if data.InventoryID == inventoryID {
+			dest = append(dest, data)
+		}
+	}
+
+	return
+}
+
+func (r *MovementRepository) SelectChanged(ctx context.Context, since time.Time) (dest []string, err error) {
+	r.inventories.RLock()
+	defer r.inventories.RUnlock()
+
+	seen := make(map[string]bool)
+	dest = make([]string, 0)
+	for _, data := range r.inventories.movements {
+		if data.CreatedAt.Before(since) || seen[data.InventoryID] {
+			continue
+		}
+		seen[data.InventoryID] = true
+		dest = append(dest, data.InventoryID)
+	}
+
+	return
+}
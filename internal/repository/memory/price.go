@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"warehouse-service/internal/domain/price"
+	"warehouse-service/pkg/storage"
+)
+
+type PriceRepository struct {
+	db map[string]price.Entity
+	sync.RWMutex
+}
+
+func NewPriceRepository(inventories *InventoryRepository) *PriceRepository {
+	r := &PriceRepository{
+		db: make(map[string]price.Entity),
+	}
+	inventories.prices = r
+
+	return r
+}
+
+func (r *PriceRepository) Select(ctx context.Context, inventoryID string) (dest []price.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]price.Entity, 0)
+	for _, data := range r.db {
+		if data.InventoryID == inventoryID {
+			dest = append(dest, data)
+		}
+	}
+	price.Sort(dest)
+
+	return
+}
+
+func (r *PriceRepository) SelectMany(ctx context.Context, inventoryIDs []string) (dest []price.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	wanted := make(map[string]bool, len(inventoryIDs))
+	for _, id := range inventoryIDs {
+		wanted[id] = true
+	}
+
+	dest = make([]price.Entity, 0)
+	for _, data := range r.db {
+		if wanted[data.InventoryID] {
+			dest = append(dest, data)
+		}
+	}
+	price.Sort(dest)
+	sort.SliceStable(dest, func(i, j int) bool {
+		return dest[i].InventoryID < dest[j].InventoryID
+	})
+
+	return
+}
+
+func (r *PriceRepository) Get(ctx context.Context, id string) (dest price.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = storage.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *PriceRepository) Create(ctx context.Context, data price.Entity) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id = uuid.New().String()
+	data.ID = id
+	data.CreatedAt = time.Now()
+	r.db[id] = data
+
+	return
+}
+
+func (r *PriceRepository) Delete(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.db[id]
+	if !ok {
+		return storage.ErrorNotFound
+	}
+
+	if !data.ValidFrom.After(time.Now()) {
+		return price.ErrorStarted
+	}
+	delete(r.db, id)
+
+	return
+}
+
+func (r *PriceRepository) SelectBoundaries(ctx context.Context, since, until time.Time) (dest []string, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	within := func(at time.Time) bool {
+		return at.After(since) && !at.After(until)
+	}
+
+	ids := make(map[string]bool)
+	for _, data := range r.db {
+		if within(data.ValidFrom) || data.ValidTo != nil && within(*data.ValidTo) {
+			ids[data.InventoryID] = true
+		}
+	}
+
+	dest = make([]string, 0, len(ids))
+	for id := range ids {
+		dest = append(dest, id)
+	}
+	sort.Strings(dest)
+
+	return
+}
+
+// follow adds the price to the history of an inventory that has one, unless it is
+// the one in effect, the way UpsertBatch of the postgres repository does.
+func (r *PriceRepository) follow(inventoryID, value string, special *string, at time.Time) {
+	r.Lock()
+	defer r.Unlock()
+
+	history := make([]price.Entity, 0)
+	for _, data := range r.db {
+		if data.InventoryID == inventoryID {
+			history = append(history, data)
+		}
+	}
+	if len(history) == 0 {
+		return
+	}
+
+	effective, ok := price.Effective(history, at)
+	if ok && sameDecimal(effective.Price, value) && sameDecimal(valueOrZero(effective.PriceSpecial), valueOrZero(special)) {
+		return
+	}
+
+	id := uuid.New().String()
+	r.db[id] = price.Entity{
+		CreatedAt:    at,
+		ValidFrom:    at,
+		ID:           id,
+		InventoryID:  inventoryID,
+		Price:        value,
+		PriceSpecial: special,
+	}
+}
+
+func sameDecimal(a, b string) bool {
+	x, errX := decimal.NewFromString(a)
+	y, errY := decimal.NewFromString(b)
+	if errX != nil || errY != nil {
+		return a == b
+	}
+	return x.Equal(y)
+}
+
+func valueOrZero(value *string) string {
+	if value == nil {
+		return "0"
+	}
+	return *value
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"warehouse-service/internal/domain/rate"
+	"warehouse-service/pkg/storage"
+)
+
+// RateRepository keeps the rates of every pair ordered by the time they start.
+type RateRepository struct {
+	db map[string][]rate.Entity
+	sync.RWMutex
+}
+
+func NewRateRepository() *RateRepository {
+	return &RateRepository{
+		db: make(map[string][]rate.Entity),
+	}
+}
+
+func (r *RateRepository) Select(ctx context.Context, filter rate.Filter) (dest []rate.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]rate.Entity, 0)
+	for _, data := range r.db {
+		for _, object := range data {
+			if (filter.Base == "" || object.Base == filter.Base) && (filter.Quote == "" || object.Quote == filter.Quote) {
+				dest = append(dest, object)
+			}
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		if dest[i].Base != dest[j].Base {
+			return dest[i].Base < dest[j].Base
+		}
+		if dest[i].Quote != dest[j].Quote {
+			return dest[i].Quote < dest[j].Quote
+		}
+		return dest[i].ValidFrom.Before(dest[j].ValidFrom)
+	})
+
+	return
+}
+
+func (r *RateRepository) Get(ctx context.Context, base, quote string, at time.Time) (dest rate.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	data := r.db[pairKey(base, quote)]
+	for i := len(data) - 1; i >= 0; i-- {
+		if !data[i].ValidFrom.After(at) {
+			return data[i], nil
+		}
+	}
+
+	return dest, storage.ErrorNotFound
+}
+
+func (r *RateRepository) Save(ctx context.Context, data []rate.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, object := range data {
+		key := pairKey(object.Base, object.Quote)
+		rates := r.db[key]
+
+		i := sort.Search(len(rates), func(i int) bool {
+			return !rates[i].ValidFrom.Before(object.ValidFrom)
+		})
+
+		if i < len(rates) && rates[i].ValidFrom.Equal(object.ValidFrom) {
+			object.CreatedAt = rates[i].CreatedAt
+			rates[i] = object
+			continue
+		}
+
+		object.CreatedAt = time.Now()
+		rates = append(rates, rate.Entity{})
+		copy(rates[i+1:], rates[i:])
+		rates[i] = object
+		r.db[key] = rates
+	}
+
+	return
+}
+
+func pairKey(base, quote string) string {
+	return base + "/" + quote
+}
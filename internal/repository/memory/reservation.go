@@ -0,0 +1,187 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/reservation"
+	"warehouse-service/pkg/storage"
+)
+
+// ReservationRepository keeps holds next to the inventory they belong to
+// and shares its lock, so checking availability and placing a hold is atomic.
+type ReservationRepository struct {
+	db          map[string]reservation.Entity
+	inventories *InventoryRepository
+}
+
+func NewReservationRepository(inventories *InventoryRepository) *ReservationRepository {
+	r := &ReservationRepository{
+		db:          make(map[string]reservation.Entity),
+		inventories: inventories,
+	}
+	inventories.reservations = r
+
+	return r
+}
+
+func (r *ReservationRepository) Select(ctx context.Context, inventoryID string) (dest []reservation.Entity, err error) {
+	r.inventories.RLock()
+	defer r.inventories.RUnlock()
+
+	dest = make([]reservation.Entity, 0)
+	for _, data := range r.db {
+		if data.InventoryID == inventoryID {
+			dest = append(dest, data)
+		}
+	}
+
+	return
+}
+
+func (r *ReservationRepository) Create(ctx context.Context, data reservation.Entity) (dest string, err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	inventoryData, ok := r.inventories.db[data.InventoryID]
+	if !ok {
+		return "", storage.ErrorNotFound
+	}
+
+	quantity, err := quantityOf(inventoryData)
+	if err != nil {
+		return
+	}
+
+	if quantity-r.held(data.InventoryID, time.Now()) < data.Quantity {
+		return "", inventory.ErrorInsufficientStock
+	}
+
+	id := r.generateID()
+	data.ID = id
+	data.Status = reservation.StatusHeld
+	data.CreatedAt = time.Now()
+	data.UpdatedAt = data.CreatedAt
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *ReservationRepository) Get(ctx context.Context, id string) (dest reservation.Entity, err error) {
+	r.inventories.RLock()
+	defer r.inventories.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = storage.ErrorNotFound
+		return
+	}
+
+	return
+}
+
+func (r *ReservationRepository) Commit(ctx context.Context, id string, change movement.Entity) (err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	data, ok := r.db[id]
+	if !ok {
+		return storage.ErrorNotFound
+	}
+
+	if !data.IsActive(time.Now()) {
+		return reservation.ErrorNotHeld
+	}
+
+	inventoryData, ok := r.inventories.db[data.InventoryID]
+	if !ok {
+		return storage.ErrorNotFound
+	}
+
+	if r.inventories.serialized(data.InventoryID) {
+		return inventory.ErrorSerialized
+	}
+
+	quantity, err := quantityOf(inventoryData)
+	if err != nil {
+		return
+	}
+
+	if quantity < data.Quantity {
+		return inventory.ErrorInsufficientStock
+	}
+
+	balance := strconv.Itoa(quantity - data.Quantity)
+	inventoryData.Quantity = &balance
+	r.inventories.db[data.InventoryID] = inventoryData
+	r.inventories.record(data.InventoryID, -data.Quantity, quantity-data.Quantity, change)
+
+	data.Status = reservation.StatusCommitted
+	data.UpdatedAt = time.Now()
+	r.db[id] = data
+
+	return
+}
+
+func (r *ReservationRepository) Release(ctx context.Context, id string) (err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	data, ok := r.db[id]
+	if !ok {
+		return storage.ErrorNotFound
+	}
+
+	if !data.IsActive(time.Now()) {
+		return reservation.ErrorNotHeld
+	}
+
+	data.Status = reservation.StatusReleased
+	data.UpdatedAt = time.Now()
+	r.db[id] = data
+
+	return
+}
+
+func (r *ReservationRepository) Expire(ctx context.Context) (count int64, err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	now := time.Now()
+	for id, data := range r.db {
+		if data.Status == reservation.StatusHeld && !data.IsActive(now) {
+			data.Status = reservation.StatusExpired
+			data.UpdatedAt = now
+			r.db[id] = data
+			count++
+		}
+	}
+
+	return
+}
+
+// held sums the quantity of active holds, the caller must hold the inventory lock.
+func (r *ReservationRepository) held(inventoryID string, now time.Time) (sum int) {
+	for _, data := range r.db {
+		if data.InventoryID == inventoryID && data.IsActive(now) {
+			sum += data.Quantity
+		}
+	}
+	return
+}
+
+func (r *ReservationRepository) generateID() string {
+	return uuid.New().String()
+}
+
+func quantityOf(data inventory.Entity) (int, error) {
+	if data.Quantity == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(*data.Quantity)
+}
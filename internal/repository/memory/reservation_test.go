@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/reservation"
+)
+
+// newStocked creates an inventory holding the quantity and returns its id.
+func newStocked(t *testing.T, inventories *InventoryRepository, quantity int, backorder bool) string {
+	t.Helper()
+
+	value, price := strconv.Itoa(quantity), "1"
+	id, err := inventories.Create(context.Background(), inventory.Entity{
+		StoreID:        "store",
+		ProductID:      "product",
+		Quantity:       &value,
+		Price:          &price,
+		AllowBackorder: &backorder,
+	}, movement.Entity{Reason: movement.ReasonReceipt})
+	if err != nil {
+		t.Fatalf("create inventory: %v", err)
+	}
+
+	return id
+}
+
+func TestReservationCreateNeverOversells(t *testing.T) {
+	const stock, buyers = 10, 50
+
+	inventories := NewInventoryRepository()
+	reservations := NewReservationRepository(inventories)
+	inventoryID := newStocked(t, inventories, stock, false)
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		held   int
+		failed int
+	)
+
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := reservations.Create(context.Background(), reservation.Entity{
+				InventoryID: inventoryID,
+				Quantity:    1,
+				ExpiresAt:   time.Now().Add(time.Hour),
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch err {
+			case nil:
+				held++
+			case inventory.ErrorInsufficientStock:
+				failed++
+			default:
+				t.Errorf("create reservation: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if held != stock || failed != buyers-stock {
+		t.Fatalf("held %d and failed %d, want %d and %d", held, failed, stock, buyers-stock)
+	}
+
+	if sum := reservations.held(inventoryID, time.Now()); sum != stock {
+		t.Fatalf("holds sum to %d, want %d", sum, stock)
+	}
+}
+
+func TestReservationCommitNeverOversells(t *testing.T) {
+	const stock, buyers = 10, 50
+
+	inventories := NewInventoryRepository()
+	reservations := NewReservationRepository(inventories)
+	inventoryID := newStocked(t, inventories, stock, false)
+
+	var wg sync.WaitGroup
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			id, err := reservations.Create(context.Background(), reservation.Entity{
+				InventoryID: inventoryID,
+				Quantity:    1,
+				ExpiresAt:   time.Now().Add(time.Hour),
+			})
+			if err == inventory.ErrorInsufficientStock {
+				return
+			}
+			if err != nil {
+				t.Errorf("create reservation: %v", err)
+				return
+			}
+
+			if err = reservations.Commit(context.Background(), id, movement.Entity{Reason: movement.ReasonSale}); err != nil {
+				t.Errorf("commit reservation: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	data, err := inventories.Get(context.Background(), inventoryID)
+	if err != nil {
+		t.Fatalf("get inventory: %v", err)
+	}
+
+	if *data.Quantity != "0" {
+		t.Fatalf("quantity is %s after selling out, want 0", *data.Quantity)
+	}
+}
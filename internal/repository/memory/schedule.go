@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"warehouse-service/internal/domain/schedule"
+	"warehouse-service/pkg/storage"
+)
+
+// ScheduleRepository keeps one schedule per store, keyed by the store id.
+type ScheduleRepository struct {
+	db map[string]schedule.Entity
+	sync.RWMutex
+}
+
+func NewScheduleRepository() *ScheduleRepository {
+	return &ScheduleRepository{
+		db: make(map[string]schedule.Entity),
+	}
+}
+
+func (r *ScheduleRepository) SelectByStores(ctx context.Context, storeIDs []string) (dest []schedule.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]schedule.Entity, 0, len(storeIDs))
+	for _, storeID := range storeIDs {
+		if data, ok := r.db[storeID]; ok {
+			dest = append(dest, data)
+		}
+	}
+
+	return
+}
+
+func (r *ScheduleRepository) Create(ctx context.Context, data schedule.Entity) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	id = uuid.New().String()
+	data.ID = id
+	data.CreatedAt = time.Now()
+	data.UpdatedAt = data.CreatedAt
+	r.db[data.StoreID] = data
+
+	return
+}
+
+// Get returns nil for a store without a schedule, as the postgres repository does.
+func (r *ScheduleRepository) Get(ctx context.Context, storeID string) (dest *schedule.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	data, ok := r.db[storeID]
+	if !ok {
+		return
+	}
+	dest = &data
+
+	return
+}
+
+func (r *ScheduleRepository) Update(ctx context.Context, storeID string, data *schedule.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.db[storeID]
+	if !ok {
+		return storage.ErrorNotFound
+	}
+
+	current.IsActive = data.IsActive
+	if data.Periods != nil {
+		current.Periods = data.Periods
+	}
+	current.UpdatedAt = time.Now()
+	r.db[storeID] = current
+
+	return
+}
+
+func (r *ScheduleRepository) Delete(ctx context.Context, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for storeID, object := range r.db {
+		if object.ID == id {
+			delete(r.db, storeID)
+			return
+		}
+	}
+
+	return storage.ErrorNotFound
+}
@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/serial"
+	"warehouse-service/pkg/storage"
+)
+
+// SerialRepository reads and writes the units kept by the inventory repository
+// under its lock, so a status change and the recount of the quantity are atomic.
+type SerialRepository struct {
+	inventories *InventoryRepository
+}
+
+func NewSerialRepository(inventories *InventoryRepository) *SerialRepository {
+	return &SerialRepository{
+		inventories: inventories,
+	}
+}
+
+func (r *SerialRepository) Select(ctx context.Context, inventoryID, status string) (dest []serial.Entity, err error) {
+	r.inventories.RLock()
+	defer r.inventories.RUnlock()
+
+	dest = make([]serial.Entity, 0)
+	for _, data := range r.inventories.serials {
+		if data.InventoryID == inventoryID && (status == "" || data.Status == status) {
+			dest = append(dest, r.withStore(data))
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].Number < dest[j].Number
+	})
+
+	return
+}
+
+func (r *SerialRepository) SelectByNumber(ctx context.Context, number string) (dest []serial.Entity, err error) {
+	r.inventories.RLock()
+	defer r.inventories.RUnlock()
+
+	dest = make([]serial.Entity, 0)
+	for _, data := range r.inventories.serials {
+		if data.Number == number {
+			dest = append(dest, r.withStore(data))
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].ProductID < dest[j].ProductID
+	})
+
+	return
+}
+
+func (r *SerialRepository) Create(ctx context.Context, inventoryID string, numbers []string, change movement.Entity) (balance int, err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	inventoryData, ok := r.inventories.db[inventoryID]
+	if !ok {
+		return 0, storage.ErrorNotFound
+	}
+
+	for _, number := range numbers {
+		if _, ok := r.inventories.serials[serialKey(inventoryData.ProductID, number)]; ok {
+			return 0, serial.ErrorDuplicate
+		}
+	}
+
+	for _, number := range numbers {
+		data := serial.Entity{
+			InventoryID: inventoryID,
+			ProductID:   inventoryData.ProductID,
+			Number:      number,
+			Status:      serial.StatusInStock,
+			CreatedAt:   time.Now(),
+		}
+		data.UpdatedAt = data.CreatedAt
+		r.inventories.serials[serialKey(data.ProductID, number)] = data
+	}
+
+	return r.recount(inventoryID, change)
+}
+
+func (r *SerialRepository) SetStatus(ctx context.Context, inventoryID, number, status string, change movement.Entity) (balance int, err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	inventoryData, ok := r.inventories.db[inventoryID]
+	if !ok {
+		return 0, storage.ErrorNotFound
+	}
+
+	key := serialKey(inventoryData.ProductID, number)
+	data, ok := r.inventories.serials[key]
+	if !ok || data.InventoryID != inventoryID {
+		return 0, storage.ErrorNotFound
+	}
+
+	if !serial.CanMove(data.Status, status) {
+		return 0, serial.ErrorTransition
+	}
+
+	data.Status = status
+	data.UpdatedAt = time.Now()
+	r.inventories.serials[key] = data
+
+	return r.recount(inventoryID, change)
+}
+
+// recount marks the inventory as serialized and sets its quantity to the units
+// in stock, the caller must hold the lock.
+func (r *SerialRepository) recount(inventoryID string, change movement.Entity) (balance int, err error) {
+	current := r.inventories.db[inventoryID]
+
+	before, err := quantityOf(current)
+	if err != nil {
+		return
+	}
+
+	for _, data := range r.inventories.serials {
+		if data.InventoryID == inventoryID && data.Status == serial.StatusInStock {
+			balance++
+		}
+	}
+
+	isSerialized, value := true, strconv.Itoa(balance)
+	current.IsSerialized = &isSerialized
+	current.Quantity = &value
+	current.UpdatedAt = time.Now()
+	r.inventories.db[inventoryID] = current
+
+	if balance != before {
+		r.inventories.record(inventoryID, balance-before, balance, change)
+	}
+
+	return
+}
+
+// withStore fills the store from the inventory, the caller must hold the lock.
+func (r *SerialRepository) withStore(data serial.Entity) serial.Entity {
+	data.StoreID = r.inventories.db[data.InventoryID].StoreID
+	return data
+}
+
+func serialKey(productID, number string) string {
+	return productID + "/" + number
+}
@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"warehouse-service/internal/domain/slot"
+	"warehouse-service/pkg/storage"
+)
+
+type SlotRepository struct {
+	booked   map[string]map[int64]int
+	bookings map[string]slot.Booking
+	sync.RWMutex
+}
+
+func NewSlotRepository() *SlotRepository {
+	return &SlotRepository{
+		booked:   make(map[string]map[int64]int),
+		bookings: make(map[string]slot.Booking),
+	}
+}
+
+func (r *SlotRepository) SelectBooked(ctx context.Context, storeID string, from, to time.Time) (dest []slot.Count, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]slot.Count, 0)
+	for startsAt, booked := range r.booked[storeID] {
+		at := time.Unix(startsAt, 0)
+		if booked > 0 && !at.Before(from) && at.Before(to) {
+			dest = append(dest, slot.Count{StartsAt: at, Booked: booked})
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].StartsAt.Before(dest[j].StartsAt)
+	})
+
+	return
+}
+
+func (r *SlotRepository) Book(ctx context.Context, data slot.Booking, capacity int) (id string, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	slots, ok := r.booked[data.StoreID]
+	if !ok {
+		slots = make(map[int64]int)
+		r.booked[data.StoreID] = slots
+	}
+
+	if slots[data.StartsAt.Unix()] >= capacity {
+		return "", slot.ErrorFull
+	}
+	slots[data.StartsAt.Unix()]++
+
+	id = uuid.New().String()
+	data.ID = id
+	data.CreatedAt = time.Now()
+	r.bookings[id] = data
+
+	return
+}
+
+func (r *SlotRepository) Cancel(ctx context.Context, storeID, id string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data, ok := r.bookings[id]
+	if !ok || data.StoreID != storeID {
+		return storage.ErrorNotFound
+	}
+	delete(r.bookings, id)
+	r.booked[storeID][data.StartsAt.Unix()]--
+
+	return
+}
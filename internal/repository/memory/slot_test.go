@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"warehouse-service/internal/domain/slot"
+)
+
+func TestSlotBookLastPlace(t *testing.T) {
+	const capacity, customers = 3, 50
+
+	slots := NewSlotRepository()
+	startsAt := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		booked int
+	)
+
+	for i := 0; i < customers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := slots.Book(context.Background(), slot.Booking{
+				StoreID:  "store",
+				StartsAt: startsAt,
+				EndsAt:   startsAt.Add(time.Hour),
+			}, capacity)
+			if err == slot.ErrorFull {
+				return
+			}
+			if err != nil {
+				t.Errorf("book: %v", err)
+				return
+			}
+
+			mu.Lock()
+			booked++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if booked != capacity {
+		t.Fatalf("%d bookings succeeded, want %d", booked, capacity)
+	}
+
+	counts, err := slots.SelectBooked(context.Background(), "store", startsAt, startsAt.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("select booked: %v", err)
+	}
+
+	if len(counts) != 1 || counts[0].Booked != capacity {
+		t.Fatalf("counts are %+v, want one slot with %d booked", counts, capacity)
+	}
+}
+
+func TestSlotCancelFreesPlace(t *testing.T) {
+	slots := NewSlotRepository()
+	startsAt := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	booking := slot.Booking{StoreID: "store", StartsAt: startsAt, EndsAt: startsAt.Add(time.Hour)}
+
+	id, err := slots.Book(context.Background(), booking, 1)
+	if err != nil {
+		t.Fatalf("book: %v", err)
+	}
+
+	if _, err = slots.Book(context.Background(), booking, 1); err != slot.ErrorFull {
+		t.Fatalf("booking a full slot gave %v, want %v", err, slot.ErrorFull)
+	}
+
+	if err = slots.Cancel(context.Background(), "store", id); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+
+	if _, err = slots.Book(context.Background(), booking, 1); err != nil {
+		t.Fatalf("book after cancelling: %v", err)
+	}
+}
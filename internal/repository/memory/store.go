@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"sync"
+	"time"
 	"warehouse-service/internal/domain/store"
 	"warehouse-service/pkg/storage"
 )
@@ -31,6 +32,20 @@ func (r *StoreRepository) Select(ctx context.Context) (dest []store.Entity, err
 	return
 }
 
+func (r *StoreRepository) SelectByIDs(ctx context.Context, ids []string) (dest []store.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest = make([]store.Entity, 0, len(ids))
+	for _, id := range ids {
+		if data, ok := r.db[id]; ok {
+			dest = append(dest, data)
+		}
+	}
+
+	return
+}
+
 func (r *StoreRepository) Create(ctx context.Context, data store.Entity) (dest string, err error) {
 	r.Lock()
 	defer r.Unlock()
@@ -60,10 +75,30 @@ func (r *StoreRepository) Update(ctx context.Context, id string, data store.Enti
 	r.Lock()
 	defer r.Unlock()
 
-	if _, ok := r.db[id]; !ok {
+	current, ok := r.db[id]
+	if !ok {
 		return storage.ErrorNotFound
 	}
-	r.db[id] = data
+
+	// only the fields set change, like the columns the postgres repository writes
+	if data.Name != nil {
+		current.Name = data.Name
+	}
+	if data.Address != nil {
+		current.Address = data.Address
+	}
+	if data.Location != nil {
+		current.Location = data.Location
+	}
+	if data.Latitude != nil && data.Longitude != nil {
+		current.Latitude = data.Latitude
+		current.Longitude = data.Longitude
+	}
+	if data.Rating != nil {
+		current.Rating = data.Rating
+	}
+	current.UpdatedAt = time.Now()
+	r.db[id] = current
 
 	return
 }
@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"warehouse-service/internal/domain/store"
+	"warehouse-service/pkg/storage"
+)
+
+func TestStoreUpdate(t *testing.T) {
+	text := func(value string) *string {
+		return &value
+	}
+	coordinate := func(value float64) *float64 {
+		return &value
+	}
+	rating := decimal.RequireFromString("4.5")
+
+	stored := store.Entity{
+		MerchantID: "merchant",
+		CityID:     "city",
+		Name:       text("Central"),
+		Address:    text("Abay 1"),
+		Location:   text("mall"),
+		Rating:     &rating,
+		Latitude:   coordinate(43.24),
+		Longitude:  coordinate(76.89),
+	}
+
+	tests := []struct {
+		name   string
+		update store.Entity
+		want   func(data *store.Entity)
+	}{
+		{
+			name:   "nothing set keeps everything",
+			update: store.Entity{},
+			want:   func(data *store.Entity) {},
+		},
+		{
+			name:   "set fields replace the stored ones",
+			update: store.Entity{Name: text("Downtown"), Address: text("Abay 2")},
+			want: func(data *store.Entity) {
+				data.Name = text("Downtown")
+				data.Address = text("Abay 2")
+			},
+		},
+		{
+			name:   "coordinates left out keep the ones stored",
+			update: store.Entity{Location: text("street")},
+			want: func(data *store.Entity) {
+				data.Location = text("street")
+			},
+		},
+		{
+			name:   "coordinates given together replace the stored ones",
+			update: store.Entity{Latitude: coordinate(51.17), Longitude: coordinate(71.45)},
+			want: func(data *store.Entity) {
+				data.Latitude = coordinate(51.17)
+				data.Longitude = coordinate(71.45)
+			},
+		},
+		{
+			name:   "half of the coordinates is ignored",
+			update: store.Entity{Latitude: coordinate(51.17)},
+			want:   func(data *store.Entity) {},
+		},
+		{
+			name:   "identity is never overwritten",
+			update: store.Entity{ID: "other", MerchantID: "other", Name: text("Downtown")},
+			want: func(data *store.Entity) {
+				data.Name = text("Downtown")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stores := NewStoreRepository()
+
+			id, err := stores.Create(context.Background(), stored)
+			if err != nil {
+				t.Fatalf("create: %v", err)
+			}
+
+			if err = stores.Update(context.Background(), id, tt.update); err != nil {
+				t.Fatalf("update: %v", err)
+			}
+
+			got, err := stores.Get(context.Background(), id)
+			if err != nil {
+				t.Fatalf("get: %v", err)
+			}
+
+			want := stored
+			want.ID = id
+			want.UpdatedAt = got.UpdatedAt
+			tt.want(&want)
+
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("store is %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestStoreUpdateMissing(t *testing.T) {
+	stores := NewStoreRepository()
+
+	if err := stores.Update(context.Background(), "missing", store.Entity{}); err != storage.ErrorNotFound {
+		t.Fatalf("error is %v, want %v", err, storage.ErrorNotFound)
+	}
+}
@@ -0,0 +1,240 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/transfer"
+	"warehouse-service/pkg/storage"
+)
+
+// TransferRepository shares the lock of the inventories it moves the stock
+// between, so a transfer changes both stores and its own state atomically.
+type TransferRepository struct {
+	db          map[string]transfer.Entity
+	inventories *InventoryRepository
+}
+
+func NewTransferRepository(inventories *InventoryRepository) *TransferRepository {
+	return &TransferRepository{
+		db:          make(map[string]transfer.Entity),
+		inventories: inventories,
+	}
+}
+
+func (r *TransferRepository) Select(ctx context.Context, filter transfer.Filter) (dest []transfer.Entity, err error) {
+	r.inventories.RLock()
+	defer r.inventories.RUnlock()
+
+	dest = make([]transfer.Entity, 0)
+	for _, data := range r.db {
+		if filter.StoreID != "" && data.SourceStoreID != filter.StoreID && data.DestinationStoreID != filter.StoreID {
+			continue
+		}
+		if filter.Status != "" && data.Status != filter.Status {
+			continue
+		}
+		dest = append(dest, r.copy(data))
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		return dest[i].CreatedAt.After(dest[j].CreatedAt)
+	})
+
+	return
+}
+
+func (r *TransferRepository) Create(ctx context.Context, data transfer.Entity) (dest string, err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	id := uuid.New().String()
+	data.ID = id
+	data.Status = transfer.StatusCreated
+	data.CreatedAt = time.Now()
+	data.UpdatedAt = data.CreatedAt
+	data.Lines = append([]transfer.Line{}, data.Lines...)
+	for i := range data.Lines {
+		data.Lines[i].TransferID = id
+		data.Lines[i].Received = 0
+	}
+	r.db[id] = data
+
+	return id, nil
+}
+
+func (r *TransferRepository) Get(ctx context.Context, id string) (dest transfer.Entity, err error) {
+	r.inventories.RLock()
+	defer r.inventories.RUnlock()
+
+	dest, ok := r.db[id]
+	if !ok {
+		err = storage.ErrorNotFound
+		return
+	}
+
+	return r.copy(dest), nil
+}
+
+func (r *TransferRepository) Ship(ctx context.Context, id string, change movement.Entity) (err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	data, ok := r.db[id]
+	if !ok {
+		return storage.ErrorNotFound
+	}
+
+	if data.Status != transfer.StatusCreated {
+		return transfer.ErrorNotCreated
+	}
+
+	// check every line up front, so a short one leaves the source untouched
+	balances := make([]int, len(data.Lines))
+	for i, line := range data.Lines {
+		inventoryID, ok := r.inventoryID(data.SourceStoreID, line.ProductID)
+		if !ok {
+			return inventory.ErrorInsufficientStock
+		}
+
+		if r.inventories.serialized(inventoryID) {
+			return inventory.ErrorSerialized
+		}
+
+		quantity, err := quantityOf(r.inventories.db[inventoryID])
+		if err != nil {
+			return err
+		}
+
+		// stock held by active reservations cannot be shipped
+		balances[i] = quantity - line.Quantity
+		if r.inventories.available(inventoryID, balances[i]) < 0 {
+			return inventory.ErrorInsufficientStock
+		}
+	}
+
+	now := time.Now()
+	for i, line := range data.Lines {
+		inventoryID, _ := r.inventoryID(data.SourceStoreID, line.ProductID)
+		r.setQuantity(inventoryID, balances[i], now)
+		r.inventories.record(inventoryID, -line.Quantity, balances[i], change)
+	}
+
+	data.Status = transfer.StatusInTransit
+	data.ShippedAt = &now
+	data.UpdatedAt = now
+	r.db[id] = data
+
+	return
+}
+
+func (r *TransferRepository) Receive(ctx context.Context, id string, lines []transfer.Line, change movement.Entity) (err error) {
+	r.inventories.Lock()
+	defer r.inventories.Unlock()
+
+	data, ok := r.db[id]
+	if !ok {
+		return storage.ErrorNotFound
+	}
+
+	if data.Status != transfer.StatusInTransit && data.Status != transfer.StatusPartiallyReceived {
+		return transfer.ErrorNotInTransit
+	}
+
+	data.Lines = append([]transfer.Line{}, data.Lines...)
+	index := make(map[string]int, len(data.Lines))
+	for i, line := range data.Lines {
+		index[line.ProductID] = i
+	}
+
+	// check every line up front, so an over-receipt leaves the destination untouched
+	for _, line := range lines {
+		i, ok := index[line.ProductID]
+		if !ok || data.Lines[i].Received+line.Quantity > data.Lines[i].Quantity {
+			return transfer.ErrorOverReceipt
+		}
+
+		if inventoryID, ok := r.inventoryID(data.DestinationStoreID, line.ProductID); ok && r.inventories.serialized(inventoryID) {
+			return inventory.ErrorSerialized
+		}
+	}
+
+	now := time.Now()
+	for _, line := range lines {
+		if err = r.receive(data, line, change, now); err != nil {
+			return
+		}
+		data.Lines[index[line.ProductID]].Received += line.Quantity
+	}
+
+	data.Status = transfer.StatusReceived
+	for _, line := range data.Lines {
+		if line.Received < line.Quantity {
+			data.Status = transfer.StatusPartiallyReceived
+		}
+	}
+	data.UpdatedAt = now
+	r.db[id] = data
+
+	return
+}
+
+// receive adds the line to the destination inventory, a product new to the
+// destination gets the price it has in the source.
+func (r *TransferRepository) receive(data transfer.Entity, line transfer.Line, change movement.Entity, now time.Time) (err error) {
+	inventoryID, ok := r.inventoryID(data.DestinationStoreID, line.ProductID)
+	if !ok {
+		price := "0"
+		if sourceID, ok := r.inventoryID(data.SourceStoreID, line.ProductID); ok {
+			if source := r.inventories.db[sourceID]; source.Price != nil {
+				price = *source.Price
+			}
+		}
+
+		quantity := strconv.Itoa(line.Quantity)
+		_, err = r.inventories.create(inventory.Entity{
+			StoreID:   data.DestinationStoreID,
+			ProductID: line.ProductID,
+			Quantity:  &quantity,
+			Price:     &price,
+		}, change)
+		return
+	}
+
+	quantity, err := quantityOf(r.inventories.db[inventoryID])
+	if err != nil {
+		return
+	}
+
+	r.setQuantity(inventoryID, quantity+line.Quantity, now)
+	r.inventories.record(inventoryID, line.Quantity, quantity+line.Quantity, change)
+
+	return
+}
+
+// inventoryID looks up the inventory of the product in the store, the caller must hold the lock.
+func (r *TransferRepository) inventoryID(storeID, productID string) (id string, ok bool) {
+	id, ok = r.inventories.keys[naturalKey(inventory.Entity{StoreID: storeID, ProductID: productID})]
+	return
+}
+
+// setQuantity stores the balance of the inventory, the caller must hold the lock.
+func (r *TransferRepository) setQuantity(inventoryID string, balance int, now time.Time) {
+	data := r.inventories.db[inventoryID]
+	value := strconv.Itoa(balance)
+	data.Quantity = &value
+	data.UpdatedAt = now
+	r.inventories.db[inventoryID] = data
+}
+
+// copy detaches the lines, so the caller cannot change the stored transfer.
+func (r *TransferRepository) copy(data transfer.Entity) transfer.Entity {
+	data.Lines = append([]transfer.Line{}, data.Lines...)
+	return data
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"warehouse-service/internal/domain/unit"
+	"warehouse-service/pkg/storage"
+)
+
+type UnitRepository struct {
+	db map[string]unit.Entity
+	sync.RWMutex
+}
+
+func NewUnitRepository() *UnitRepository {
+	return &UnitRepository{
+		db: make(map[string]unit.Entity),
+	}
+}
+
+func (r *UnitRepository) Get(ctx context.Context, productID string) (dest unit.Entity, err error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	dest, ok := r.db[productID]
+	if !ok {
+		err = storage.ErrorNotFound
+		return
+	}
+	dest.Units = append(make([]unit.Unit, 0, len(dest.Units)), dest.Units...)
+
+	return
+}
+
+func (r *UnitRepository) Save(ctx context.Context, data unit.Entity) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	data.UpdatedAt = time.Now()
+	data.CreatedAt = data.UpdatedAt
+	if current, ok := r.db[data.ProductID]; ok {
+		data.CreatedAt = current.CreatedAt
+	}
+	r.db[data.ProductID] = data
+
+	return
+}
+
+func (r *UnitRepository) Delete(ctx context.Context, productID string) (err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.db[productID]; !ok {
+		return storage.ErrorNotFound
+	}
+	delete(r.db, productID)
+
+	return
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"warehouse-service/internal/domain/alert"
+	"warehouse-service/pkg/storage"
+)
+
+type AlertRepository struct {
+	db *sqlx.DB
+}
+
+func NewAlertRepository(db *sqlx.DB) *AlertRepository {
+	return &AlertRepository{
+		db: db,
+	}
+}
+
+func (s *AlertRepository) Select(ctx context.Context, filter alert.Filter) (dest []alert.Entity, err error) {
+	query := `
+        SELECT created_at, updated_at, resolved_at, id, inventory_id, store_id, product_id, kind, state, quantity, threshold
+        FROM alerts
+        WHERE ($1='' OR state=$1) AND ($2='' OR kind=$2) AND ($3='' OR store_id=$3)
+        ORDER BY created_at DESC`
+
+	args := []interface{}{filter.State, filter.Kind, filter.StoreID}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (s *AlertRepository) Get(ctx context.Context, id string) (dest alert.Entity, err error) {
+	query := `
+        SELECT created_at, updated_at, resolved_at, id, inventory_id, store_id, product_id, kind, state, quantity, threshold
+        FROM alerts
+        WHERE id=$1`
+
+	args := []interface{}{id}
+
+	if err = s.db.GetContext(ctx, &dest, query, args...); err != nil && err != sql.ErrNoRows {
+		return
+	}
+
+	if err == sql.ErrNoRows {
+		err = storage.ErrorNotFound
+	}
+
+	return
+}
+
+// Raise refreshes the unresolved alert, otherwise reopens the latest one resolved
+// since reopenSince, otherwise opens a new one. The partial unique index keeps a
+// concurrent evaluator from opening a second alert for the same inventory and kind.
+func (s *AlertRepository) Raise(ctx context.Context, data alert.Entity, reopenSince time.Time) (err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	query := `
+        UPDATE alerts
+        SET quantity=$3, threshold=$4, updated_at=CURRENT_TIMESTAMP
+        WHERE inventory_id=$1 AND kind=$2 AND state<>$5`
+
+	args := []interface{}{data.InventoryID, data.Kind, data.Quantity, data.Threshold, alert.StateResolved}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+
+	if affected, _ := result.RowsAffected(); affected > 0 {
+		return tx.Commit()
+	}
+
+	query = `
+        UPDATE alerts
+        SET state=$6, resolved_at=NULL, quantity=$3, threshold=$4, updated_at=CURRENT_TIMESTAMP
+        WHERE id=(
+            SELECT id
+            FROM alerts
+            WHERE inventory_id=$1 AND kind=$2 AND state=$5 AND resolved_at >= $7::timestamptz
+            ORDER BY resolved_at DESC
+            LIMIT 1
+        )`
+
+	args = append(args, alert.StateOpen, reopenSince)
+
+	if result, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return
+	}
+
+	if affected, _ := result.RowsAffected(); affected > 0 {
+		return tx.Commit()
+	}
+
+	query = `
+        INSERT INTO alerts (inventory_id, store_id, product_id, kind, state, quantity, threshold)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (inventory_id, kind) WHERE state <> 'resolved' DO NOTHING`
+
+	args = []interface{}{data.InventoryID, data.StoreID, data.ProductID, data.Kind, alert.StateOpen, data.Quantity, data.Threshold}
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+func (s *AlertRepository) Resolve(ctx context.Context, inventoryID, kind string, quantity int) (err error) {
+	query := `
+        UPDATE alerts
+        SET state=$4, resolved_at=CURRENT_TIMESTAMP, quantity=$3, updated_at=CURRENT_TIMESTAMP
+        WHERE inventory_id=$1 AND kind=$2 AND state<>$4`
+
+	args := []interface{}{inventoryID, kind, quantity, alert.StateResolved}
+
+	_, err = s.db.ExecContext(ctx, query, args...)
+
+	return
+}
+
+func (s *AlertRepository) Acknowledge(ctx context.Context, id string) (err error) {
+	query := `
+        UPDATE alerts
+        SET state=$1, updated_at=CURRENT_TIMESTAMP
+        WHERE id=$2 AND state=$3`
+
+	args := []interface{}{alert.StateAcknowledged, id, alert.StateOpen}
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		if _, err = s.Get(ctx, id); err != nil {
+			return
+		}
+		err = alert.ErrorNotOpen
+	}
+
+	return
+}
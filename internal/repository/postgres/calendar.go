@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"warehouse-service/internal/domain/calendar"
+	"warehouse-service/pkg/storage"
+)
+
+type CalendarRepository struct {
+	db *sqlx.DB
+}
+
+func NewCalendarRepository(db *sqlx.DB) *CalendarRepository {
+	return &CalendarRepository{
+		db: db,
+	}
+}
+
+func (s *CalendarRepository) SelectSeasons(ctx context.Context, storeID string) (dest []calendar.Season, err error) {
+	query := `
+        SELECT created_at, id, store_id, name, valid_from, valid_to, periods
+        FROM schedule_seasons
+        WHERE store_id=$1
+        ORDER BY valid_from, created_at`
+
+	args := []interface{}{storeID}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (s *CalendarRepository) SelectSeasonsByStores(ctx context.Context, storeIDs []string) (dest []calendar.Season, err error) {
+	query := `
+        SELECT created_at, id, store_id, name, valid_from, valid_to, periods
+        FROM schedule_seasons
+        WHERE store_id = ANY($1::uuid[])
+        ORDER BY store_id, valid_from, created_at`
+
+	args := []interface{}{pq.Array(storeIDs)}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (s *CalendarRepository) CreateSeason(ctx context.Context, data calendar.Season) (id string, err error) {
+	query := `
+        INSERT INTO schedule_seasons (store_id, name, valid_from, valid_to, periods)
+        VALUES ($1, $2, $3::date, $4::date, $5)
+        RETURNING id`
+
+	// the dates go as text, a time would shift by the time zone of the session
+	var validTo *string
+	if data.ValidTo != nil {
+		value := data.ValidTo.Format(calendar.DateLayout)
+		validTo = &value
+	}
+
+	args := []interface{}{data.StoreID, data.Name, data.ValidFrom.Format(calendar.DateLayout), validTo, data.Periods}
+
+	err = s.db.QueryRowContext(ctx, query, args...).Scan(&id)
+
+	return
+}
+
+func (s *CalendarRepository) DeleteSeason(ctx context.Context, storeID, id string) (err error) {
+	query := `
+        DELETE FROM schedule_seasons
+        WHERE store_id=$1 AND id=$2
+        RETURNING id`
+
+	args := []interface{}{storeID, id}
+
+	if err = s.db.QueryRowContext(ctx, query, args...).Scan(&id); err == sql.ErrNoRows {
+		err = storage.ErrorNotFound
+	}
+
+	return
+}
+
+func (s *CalendarRepository) SelectExceptions(ctx context.Context, storeID string, from, to time.Time) (dest []calendar.Exception, err error) {
+	query := `
+        SELECT created_at, updated_at, store_id, date, closed, hours, note
+        FROM schedule_exceptions
+        WHERE store_id=$1 AND date BETWEEN $2::date AND $3::date
+        ORDER BY date`
+
+	args := []interface{}{storeID, from.Format(calendar.DateLayout), to.Format(calendar.DateLayout)}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (s *CalendarRepository) SelectExceptionsByStores(ctx context.Context, storeIDs []string, from, to time.Time) (dest []calendar.Exception, err error) {
+	query := `
+        SELECT created_at, updated_at, store_id, date, closed, hours, note
+        FROM schedule_exceptions
+        WHERE store_id = ANY($1::uuid[]) AND date BETWEEN $2::date AND $3::date
+        ORDER BY store_id, date`
+
+	args := []interface{}{pq.Array(storeIDs), from.Format(calendar.DateLayout), to.Format(calendar.DateLayout)}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (s *CalendarRepository) SaveException(ctx context.Context, data calendar.Exception) (err error) {
+	query := `
+        INSERT INTO schedule_exceptions (store_id, date, closed, hours, note)
+        VALUES ($1, $2::date, $3, $4, $5)
+        ON CONFLICT (store_id, date) DO UPDATE
+        SET closed=EXCLUDED.closed, hours=EXCLUDED.hours, note=EXCLUDED.note, updated_at=CURRENT_TIMESTAMP`
+
+	args := []interface{}{data.StoreID, data.Date.Format(calendar.DateLayout), data.Closed, data.Hours, data.Note}
+
+	_, err = s.db.ExecContext(ctx, query, args...)
+
+	return
+}
+
+func (s *CalendarRepository) DeleteException(ctx context.Context, storeID string, date time.Time) (err error) {
+	query := `
+        DELETE FROM schedule_exceptions
+        WHERE store_id=$1 AND date=$2::date
+        RETURNING store_id`
+
+	args := []interface{}{storeID, date.Format(calendar.DateLayout)}
+
+	if err = s.db.QueryRowContext(ctx, query, args...).Scan(&storeID); err == sql.ErrNoRows {
+		err = storage.ErrorNotFound
+	}
+
+	return
+}
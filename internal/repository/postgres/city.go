@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"strings"
 
 	"warehouse-service/internal/domain/city"
@@ -23,7 +24,7 @@ func NewCityRepository(db *sqlx.DB) *CityRepository {
 
 func (s *CityRepository) Select(ctx context.Context) (dest []city.Entity, err error) {
 	query := `
-        SELECT id, country_id, name, geocenter
+        SELECT id, country_id, name, geocenter, time_zone
         FROM cities`
 
 	err = s.db.SelectContext(ctx, &dest, query)
@@ -33,20 +34,20 @@ func (s *CityRepository) Select(ctx context.Context) (dest []city.Entity, err er
 
 func (s *CityRepository) Create(ctx context.Context, data city.Entity) (id string, err error) {
 	query := `
-        INSERT INTO cities (country_id, name, geocenter)
-        VALUES ($1, $2, $3)
+        INSERT INTO cities (country_id, name, geocenter, time_zone)
+        VALUES ($1, $2, $3, $4)
         RETURNING id`
 
-	args := []interface{}{data.CountryID, data.Name, data.GeoCenter}
+	args := []interface{}{data.CountryID, data.Name, data.GeoCenter, data.TimeZone}
 
-	err = s.db.QueryRowContext(ctx, query, args...).Scan(&id)
+	err = cityError(s.db.QueryRowContext(ctx, query, args...).Scan(&id), city.ErrorUnknownCountry)
 
 	return
 }
 
 func (s *CityRepository) Get(ctx context.Context, id string) (dest *city.Entity, err error) {
 	query := `
-        SELECT id, country_id, name, geocenter
+        SELECT id, country_id, name, geocenter, time_zone
         FROM cities
         WHERE id=$1`
 
@@ -71,6 +72,7 @@ func (s *CityRepository) Update(ctx context.Context, id string, data city.Entity
 
 		query := fmt.Sprintf("UPDATE cities SET %s WHERE id=$%d", strings.Join(sets, ", "), len(args))
 		_, err = s.db.ExecContext(ctx, query, args...)
+		err = cityError(err, city.ErrorUnknownCountry)
 		if err != nil && err != sql.ErrNoRows {
 			return
 		}
@@ -84,6 +86,11 @@ func (s *CityRepository) Update(ctx context.Context, id string, data city.Entity
 }
 
 func (s *CityRepository) prepareArgs(data city.Entity) (sets []string, args []interface{}) {
+	if data.CountryID != "" {
+		args = append(args, data.CountryID)
+		sets = append(sets, fmt.Sprintf("country_id=$%d", len(args)))
+	}
+
 	if data.Name != nil {
 		args = append(args, *data.Name)
 		sets = append(sets, fmt.Sprintf("name=$%d", len(args)))
@@ -94,6 +101,11 @@ func (s *CityRepository) prepareArgs(data city.Entity) (sets []string, args []in
 		sets = append(sets, fmt.Sprintf("geocenter=$%d", len(args)))
 	}
 
+	if data.TimeZone != nil {
+		args = append(args, *data.TimeZone)
+		sets = append(sets, fmt.Sprintf("time_zone=$%d", len(args)))
+	}
+
 	return
 }
 
@@ -106,6 +118,7 @@ func (s *CityRepository) Delete(ctx context.Context, id string) (err error) {
 	args := []interface{}{id}
 
 	_, err = s.db.ExecContext(ctx, query, args...)
+	err = cityError(err, city.ErrorInUse)
 	if err != nil && err != sql.ErrNoRows {
 		return
 	}
@@ -116,3 +129,17 @@ func (s *CityRepository) Delete(ctx context.Context, id string) (err error) {
 
 	return
 }
+
+// cityError reports a name already taken as ErrorDuplicate and a broken reference
+// as the error given, a missing country on writes or remaining stores on delete.
+func cityError(err, reference error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code {
+		case uniqueViolation:
+			return city.ErrorDuplicate
+		case foreignKeyViolation:
+			return reference
+		}
+	}
+	return err
+}
@@ -0,0 +1,316 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"warehouse-service/internal/domain/count"
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/pkg/storage"
+)
+
+type CountRepository struct {
+	db *sqlx.DB
+}
+
+func NewCountRepository(db *sqlx.DB) *CountRepository {
+	return &CountRepository{
+		db: db,
+	}
+}
+
+func (s *CountRepository) Select(ctx context.Context, filter count.Filter) (dest []count.Entity, err error) {
+	query := `
+        SELECT created_at, updated_at, closed_at, id, store_id, status
+        FROM counts
+        WHERE ($1='' OR store_id=$1) AND ($2='' OR status=$2)
+        ORDER BY created_at DESC`
+
+	args := []interface{}{filter.StoreID, filter.Status}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+// Create locks the inventories in the order of their ids, so it cannot deadlock
+// with another session or transfer, and marks them with the new session.
+func (s *CountRepository) Create(ctx context.Context, data count.Entity, productIDs []string) (id string, err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	var inventories []inventory.Entity
+
+	query := `
+        SELECT id, store_id, COALESCE(product_id, '') AS product_id, quantity, count_id
+        FROM inventories
+        WHERE store_id=$1 AND (COALESCE(CARDINALITY($2::varchar[]), 0)=0 OR product_id = ANY($2::varchar[]))
+        ORDER BY id
+        FOR UPDATE`
+
+	if err = tx.SelectContext(ctx, &inventories, query, data.StoreID, pq.Array(productIDs)); err != nil {
+		return
+	}
+
+	if len(inventories) == 0 {
+		err = count.ErrorNothingToCount
+		return
+	}
+
+	inventoryIDs := make([]string, len(inventories))
+	for i, inventoryData := range inventories {
+		if inventoryData.CountID != nil {
+			err = count.ErrorAlreadyCounting
+			return
+		}
+		inventoryIDs[i] = inventoryData.ID
+	}
+
+	query = `
+        INSERT INTO counts (store_id, status)
+        VALUES ($1, $2)
+        RETURNING id`
+
+	if err = tx.QueryRowContext(ctx, query, data.StoreID, count.StatusOpen).Scan(&id); err != nil {
+		return
+	}
+
+	query = `
+        INSERT INTO count_items (count_id, inventory_id, product_id, expected)
+        SELECT $1, id, COALESCE(product_id, ''), quantity
+        FROM inventories
+        WHERE id = ANY($2::uuid[])`
+
+	if _, err = tx.ExecContext(ctx, query, id, pq.Array(inventoryIDs)); err != nil {
+		return
+	}
+
+	query = `
+        UPDATE inventories
+        SET count_id=$1
+        WHERE id = ANY($2::uuid[])`
+
+	if _, err = tx.ExecContext(ctx, query, id, pq.Array(inventoryIDs)); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+func (s *CountRepository) Get(ctx context.Context, id string) (dest count.Entity, err error) {
+	query := `
+        SELECT created_at, updated_at, closed_at, id, store_id, status
+        FROM counts
+        WHERE id=$1`
+
+	if err = s.db.GetContext(ctx, &dest, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
+		}
+		return
+	}
+
+	query = `
+        SELECT count_id, inventory_id, product_id, expected, counted, balance
+        FROM count_items
+        WHERE count_id=$1
+        ORDER BY product_id`
+
+	err = s.db.SelectContext(ctx, &dest.Items, query, id)
+
+	return
+}
+
+// Record keeps the latest entry of every counter and recomputes the item from
+// them, together with the quantity on record at this moment.
+func (s *CountRepository) Record(ctx context.Context, id string, entry count.Entry) (err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	if err = s.lockOpen(ctx, tx, id); err != nil {
+		return
+	}
+
+	query := `
+        SELECT inventory_id
+        FROM count_items
+        WHERE count_id=$1 AND product_id=$2`
+
+	if err = tx.QueryRowContext(ctx, query, id, entry.ProductID).Scan(&entry.InventoryID); err != nil {
+		if err == sql.ErrNoRows {
+			err = count.ErrorNotCounted
+		}
+		return
+	}
+
+	query = `
+        INSERT INTO count_entries (count_id, inventory_id, counter, quantity)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (count_id, inventory_id, counter) DO UPDATE
+        SET quantity=EXCLUDED.quantity, created_at=CURRENT_TIMESTAMP`
+
+	args := []interface{}{id, entry.InventoryID, entry.Counter, entry.Quantity}
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return
+	}
+
+	query = `
+        UPDATE count_items
+        SET counted=(SELECT SUM(quantity) FROM count_entries WHERE count_id=$1 AND inventory_id=$2),
+            balance=(SELECT quantity FROM inventories WHERE id=$2)
+        WHERE count_id=$1 AND inventory_id=$2`
+
+	if _, err = tx.ExecContext(ctx, query, id, entry.InventoryID); err != nil {
+		return
+	}
+
+	query = `
+        UPDATE counts
+        SET updated_at=CURRENT_TIMESTAMP
+        WHERE id=$1`
+
+	if _, err = tx.ExecContext(ctx, query, id); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+// Approve applies the variances relative to the current quantities, so sales made
+// after the count stay in place and sales made before it are not taken twice, and
+// journals them in the same statement.
+func (s *CountRepository) Approve(ctx context.Context, id string, change movement.Entity) (err error) {
+	defer func() {
+		err = serializedError(err)
+	}()
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	if err = s.lockOpen(ctx, tx, id); err != nil {
+		return
+	}
+
+	var expected, adjusted int
+
+	query := `
+        WITH variances AS (
+            SELECT c.inventory_id, c.counted-c.balance AS delta
+            FROM count_items AS c
+            JOIN inventories AS i ON i.id=c.inventory_id
+            WHERE c.count_id=$1 AND c.counted IS NOT NULL AND c.counted <> c.balance
+        ), adjusted AS (
+            UPDATE inventories AS i
+            SET quantity=i.quantity+v.delta, updated_at=CURRENT_TIMESTAMP
+            FROM variances AS v
+            WHERE i.id=v.inventory_id AND (i.quantity+v.delta >= 0 OR i.allow_backorder)
+            RETURNING i.id, v.delta, i.quantity
+        ), journal AS (
+            INSERT INTO movements (inventory_id, reason, delta, balance, actor, correlation_id)
+            SELECT id, $2, delta, quantity, $3, $4
+            FROM adjusted
+        )
+        SELECT (SELECT COUNT(*) FROM variances), (SELECT COUNT(*) FROM adjusted)`
+
+	args := []interface{}{id, change.Reason, change.Actor, change.CorrelationID}
+
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&expected, &adjusted); err != nil {
+		return
+	}
+
+	if adjusted != expected {
+		err = inventory.ErrorInsufficientStock
+		return
+	}
+
+	if err = s.close(ctx, tx, id, count.StatusApproved); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+func (s *CountRepository) Cancel(ctx context.Context, id string) (err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	if err = s.lockOpen(ctx, tx, id); err != nil {
+		return
+	}
+
+	if err = s.close(ctx, tx, id, count.StatusCancelled); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+// lockOpen locks the session until the transaction ends and makes sure it is still open.
+func (s *CountRepository) lockOpen(ctx context.Context, tx *sqlx.Tx, id string) (err error) {
+	var status string
+
+	query := `
+        SELECT status
+        FROM counts
+        WHERE id=$1
+        FOR UPDATE`
+
+	if err = tx.QueryRowContext(ctx, query, id).Scan(&status); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
+		}
+		return
+	}
+
+	if status != count.StatusOpen {
+		err = count.ErrorNotOpen
+	}
+
+	return
+}
+
+// close ends the session and unmarks its inventories.
+func (s *CountRepository) close(ctx context.Context, tx *sqlx.Tx, id, status string) (err error) {
+	query := `
+        UPDATE inventories
+        SET count_id=NULL
+        WHERE count_id=$1`
+
+	if _, err = tx.ExecContext(ctx, query, id); err != nil {
+		return
+	}
+
+	query = `
+        UPDATE counts
+        SET status=$1, closed_at=CURRENT_TIMESTAMP, updated_at=CURRENT_TIMESTAMP
+        WHERE id=$2`
+
+	_, err = tx.ExecContext(ctx, query, status, id)
+
+	return
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"warehouse-service/internal/domain/country"
 	"warehouse-service/pkg/storage"
@@ -24,7 +25,7 @@ func NewCountryRepository(db *sqlx.DB) *CountryRepository {
 
 func (s *CountryRepository) Select(ctx context.Context) (dest []country.Entity, err error) {
 	query := `
-        SELECT id,name
+        SELECT id,name,time_zone
         FROM countries`
 
 	err = s.db.SelectContext(ctx, &dest, query)
@@ -34,20 +35,20 @@ func (s *CountryRepository) Select(ctx context.Context) (dest []country.Entity,
 
 func (s *CountryRepository) Create(ctx context.Context, data country.Entity) (id string, err error) {
 	query := `
-        INSERT INTO countries (name)
-        VALUES ($1)
+        INSERT INTO countries (name, time_zone)
+        VALUES ($1, $2)
         RETURNING id`
 
-	args := []interface{}{data.Name}
+	args := []interface{}{data.Name, data.TimeZone}
 
-	err = s.db.QueryRowContext(ctx, query, args...).Scan(&id)
+	err = countryError(s.db.QueryRowContext(ctx, query, args...).Scan(&id))
 
 	return
 }
 
 func (s *CountryRepository) Get(ctx context.Context, id string) (dest country.Entity, err error) {
 	query := `
-        SELECT id,name
+        SELECT id,name,time_zone
         FROM countries
         WHERE id=$1`
 
@@ -72,6 +73,7 @@ func (s *CountryRepository) Update(ctx context.Context, id string, data country.
 
 		query := fmt.Sprintf("UPDATE countries SET %s WHERE id=$%d", strings.Join(sets, ", "), len(args))
 		_, err = s.db.ExecContext(ctx, query, args...)
+		err = countryError(err)
 		if err != nil && err != sql.ErrNoRows {
 			return
 		}
@@ -91,6 +93,11 @@ func (s *CountryRepository) prepareArgs(data country.Entity) (sets []string, arg
 		sets = append(sets, fmt.Sprintf("name=$%d", len(args)))
 	}
 
+	if data.TimeZone != nil {
+		args = append(args, data.TimeZone)
+		sets = append(sets, fmt.Sprintf("time_zone=$%d", len(args)))
+	}
+
 	return
 }
 
@@ -103,6 +110,7 @@ func (s *CountryRepository) Delete(ctx context.Context, id string) (err error) {
 	args := []interface{}{id}
 
 	_, err = s.db.ExecContext(ctx, query, args...)
+	err = countryError(err)
 	if err != nil && err != sql.ErrNoRows {
 		return
 	}
@@ -113,3 +121,17 @@ func (s *CountryRepository) Delete(ctx context.Context, id string) (err error) {
 
 	return
 }
+
+// countryError reports a name already taken as ErrorDuplicate and a country still
+// referred to by cities or currencies as ErrorInUse.
+func countryError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code {
+		case uniqueViolation:
+			return country.ErrorDuplicate
+		case foreignKeyViolation:
+			return country.ErrorInUse
+		}
+	}
+	return err
+}
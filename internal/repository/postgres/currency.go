@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"strings"
 
 	"warehouse-service/internal/domain/currency"
@@ -23,7 +24,7 @@ func NewCurrencyRepository(db *sqlx.DB) *CurrencyRepository {
 
 func (s *CurrencyRepository) Select(ctx context.Context) (dest []currency.Entity, err error) {
 	query := `
-        SELECT id, country_id, sign, decimals,prefix
+        SELECT id, country_id, code, sign, decimals, prefix
         FROM currencies`
 
 	err = s.db.SelectContext(ctx, &dest, query)
@@ -33,20 +34,20 @@ func (s *CurrencyRepository) Select(ctx context.Context) (dest []currency.Entity
 
 func (s *CurrencyRepository) Create(ctx context.Context, data currency.Entity) (id string, err error) {
 	query := `
-        INSERT INTO currencies (country_id, sign, decimals,prefix)
-        VALUES ($1, $2, $3)
+        INSERT INTO currencies (country_id, code, sign, decimals, prefix)
+        VALUES ($1, $2, $3, $4, $5)
         RETURNING id`
 
-	args := []interface{}{data.CountryID, data.Sign, data.Decimals}
+	args := []interface{}{data.CountryID, data.Code, data.Sign, data.Decimals, data.Prefix}
 
-	err = s.db.QueryRowContext(ctx, query, args...).Scan(&id)
+	err = currencyError(s.db.QueryRowContext(ctx, query, args...).Scan(&id))
 
 	return
 }
 
 func (s *CurrencyRepository) Get(ctx context.Context, countryID string) (dest *currency.Entity, err error) {
 	query := `
-        SELECT id, country_id, sign, decimals,prefix
+        SELECT id, country_id, code, sign, decimals, prefix
         FROM currencies
         WHERE country_id=$1`
 
@@ -63,6 +64,44 @@ func (s *CurrencyRepository) Get(ctx context.Context, countryID string) (dest *c
 	return
 }
 
+func (s *CurrencyRepository) GetByID(ctx context.Context, id string) (dest *currency.Entity, err error) {
+	query := `
+        SELECT id, country_id, code, sign, decimals, prefix
+        FROM currencies
+        WHERE id=$1`
+
+	args := []interface{}{id}
+
+	dest = new(currency.Entity)
+	if err = s.db.GetContext(ctx, dest, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			dest, err = nil, nil
+		}
+		return
+	}
+
+	return
+}
+
+func (s *CurrencyRepository) GetByCode(ctx context.Context, code string) (dest *currency.Entity, err error) {
+	query := `
+        SELECT id, country_id, code, sign, decimals, prefix
+        FROM currencies
+        WHERE code=$1`
+
+	args := []interface{}{code}
+
+	dest = new(currency.Entity)
+	if err = s.db.GetContext(ctx, dest, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			dest, err = nil, nil
+		}
+		return
+	}
+
+	return
+}
+
 func (s *CurrencyRepository) Update(ctx context.Context, countryID string, data currency.Entity) (err error) {
 	sets, args := s.prepareArgs(data)
 	if len(args) > 0 {
@@ -71,6 +110,7 @@ func (s *CurrencyRepository) Update(ctx context.Context, countryID string, data
 
 		query := fmt.Sprintf("UPDATE currencies SET %s WHERE country_id=$%d", strings.Join(sets, ", "), len(args))
 		_, err = s.db.ExecContext(ctx, query, args...)
+		err = currencyError(err)
 		if err != nil && err != sql.ErrNoRows {
 			return
 		}
@@ -84,6 +124,15 @@ func (s *CurrencyRepository) Update(ctx context.Context, countryID string, data
 }
 
 func (s *CurrencyRepository) prepareArgs(data currency.Entity) (sets []string, args []any) {
+	if data.CountryID != "" {
+		args = append(args, data.CountryID)
+		sets = append(sets, fmt.Sprintf("country_id=$%d", len(args)))
+	}
+
+	if data.Code != nil {
+		args = append(args, *data.Code)
+		sets = append(sets, fmt.Sprintf("code=$%d", len(args)))
+	}
 
 	if data.Sign != nil {
 		args = append(args, *data.Sign)
@@ -95,6 +144,9 @@ func (s *CurrencyRepository) prepareArgs(data currency.Entity) (sets []string, a
 		sets = append(sets, fmt.Sprintf("decimals=$%d", len(args)))
 	}
 
+	args = append(args, data.Prefix)
+	sets = append(sets, fmt.Sprintf("prefix=$%d", len(args)))
+
 	return
 }
 
@@ -117,3 +169,17 @@ func (s *CurrencyRepository) Delete(ctx context.Context, id string) (err error)
 
 	return
 }
+
+// currencyError reports a sign, code or country already taken as ErrorDuplicate and
+// a missing country as ErrorUnknownCountry.
+func currencyError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code {
+		case uniqueViolation:
+			return currency.ErrorDuplicate
+		case foreignKeyViolation:
+			return currency.ErrorUnknownCountry
+		}
+	}
+	return err
+}
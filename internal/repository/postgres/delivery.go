@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"warehouse-service/internal/domain/delivery"
 	"warehouse-service/pkg/storage"
@@ -24,7 +25,7 @@ func NewDeliveryRepository(db *sqlx.DB) *DeliveryRepository {
 
 func (s *DeliveryRepository) Select(ctx context.Context) (dest []delivery.Entity, err error) {
 	query := `
-        SELECT id, store_id, periods,areas, is_active
+        SELECT id, store_id, periods,areas, is_active, slot_minutes, slot_capacity, fees
         FROM deliveries`
 
 	err = s.db.SelectContext(ctx, &dest, query)
@@ -32,13 +33,26 @@ func (s *DeliveryRepository) Select(ctx context.Context) (dest []delivery.Entity
 	return
 }
 
+func (s *DeliveryRepository) SelectByStores(ctx context.Context, storeIDs []string) (dest []delivery.Entity, err error) {
+	query := `
+        SELECT id, store_id, periods,areas, is_active, slot_minutes, slot_capacity, fees
+        FROM deliveries
+        WHERE store_id = ANY($1::uuid[])`
+
+	args := []interface{}{pq.Array(storeIDs)}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
 func (s *DeliveryRepository) Create(ctx context.Context, data delivery.Entity) (id string, err error) {
 	query := `
-        INSERT INTO deliveries(store_id, periods,areas, is_active)
-        VALUES ($1, $2, $3,$4)
+        INSERT INTO deliveries(store_id, periods,areas, is_active, slot_minutes, slot_capacity, fees)
+        VALUES ($1, $2, $3,$4, $5, $6, $7)
         RETURNING id`
 
-	args := []interface{}{data.StoreID, data.Periods, data.Areas, data.IsActive}
+	args := []interface{}{data.StoreID, data.Periods, data.Areas, data.IsActive, data.SlotMinutes, data.SlotCapacity, data.Fees}
 
 	err = s.db.QueryRowContext(ctx, query, args...).Scan(&id)
 
@@ -47,7 +61,7 @@ func (s *DeliveryRepository) Create(ctx context.Context, data delivery.Entity) (
 
 func (s *DeliveryRepository) Get(ctx context.Context, storeID string) (dest *delivery.Entity, err error) {
 	query := `
-        SELECT id, store_id, periods,areas, is_active
+        SELECT id, store_id, periods,areas, is_active, slot_minutes, slot_capacity, fees
         FROM deliveries
         WHERE store_id=$1`
 
@@ -85,6 +99,8 @@ func (s *DeliveryRepository) Update(ctx context.Context, storeID string, data *d
 }
 
 func (s *DeliveryRepository) prepareArgs(data *delivery.Entity) (sets []string, args []any) {
+	args = append(args, data.IsActive)
+	sets = append(sets, fmt.Sprintf("is_active=$%d", len(args)))
 
 	if data.Periods != nil {
 		args = append(args, data.Periods)
@@ -96,6 +112,27 @@ func (s *DeliveryRepository) prepareArgs(data *delivery.Entity) (sets []string,
 		sets = append(sets, fmt.Sprintf("areas=$%d", len(args)))
 	}
 
+	if data.SlotMinutes > 0 {
+		args = append(args, data.SlotMinutes)
+		sets = append(sets, fmt.Sprintf("slot_minutes=$%d", len(args)))
+	}
+
+	if data.SlotCapacity > 0 {
+		args = append(args, data.SlotCapacity)
+		sets = append(sets, fmt.Sprintf("slot_capacity=$%d", len(args)))
+	}
+
+	if data.Fees != nil {
+		// empty fees are written as NULL, which removes them
+		var fees []byte
+		if len(data.Fees) > 0 {
+			fees = data.Fees
+		}
+
+		args = append(args, fees)
+		sets = append(sets, fmt.Sprintf("fees=$%d", len(args)))
+	}
+
 	return
 }
 
@@ -5,12 +5,27 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"strings"
 
 	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/reservation"
 	"warehouse-service/pkg/storage"
 )
 
+const (
+	// uniqueViolation is the SQLSTATE postgres reports for a broken unique constraint.
+	uniqueViolation = "23505"
+
+	// foreignKeyViolation is the SQLSTATE of a row referring to a missing one, or of
+	// deleting a row others still refer to.
+	foreignKeyViolation = "23503"
+
+	// checkViolation is the SQLSTATE of a failed check, inventories_serialized_quantity raises it too.
+	checkViolation = "23514"
+)
+
 type InventoryRepository struct {
 	db *sqlx.DB
 }
@@ -23,7 +38,7 @@ func NewInventoryRepository(db *sqlx.DB) *InventoryRepository {
 
 func (s *InventoryRepository) Select(ctx context.Context) (dest []inventory.Entity, err error) {
 	query := `
-        SELECT id, store_id,product_id quantity, quantity_min,quantity_max,price,price_special,price_previous,is_available
+        SELECT id, store_id, COALESCE(catalog_id, '') AS catalog_id, COALESCE(product_id, '') AS product_id, quantity, quantity_min, quantity_max, price, price_special, price_previous, is_available, allow_backorder, count_id, is_serialized
         FROM inventories`
 
 	err = s.db.SelectContext(ctx, &dest, query)
@@ -31,22 +46,221 @@ func (s *InventoryRepository) Select(ctx context.Context) (dest []inventory.Enti
 	return
 }
 
-func (s *InventoryRepository) Create(ctx context.Context, data inventory.Entity) (id string, err error) {
+// Each scans the rows one by one as postgres sends them, the ordering
+// follows the unique index on store and product so no sort is buffered.
+func (s *InventoryRepository) Each(ctx context.Context, filter inventory.Filter, fn func(data inventory.Entity) error) (err error) {
 	query := `
-        INSERT INTO inventories(store_id,product_id,quantity, price)
-        VALUES ($1, $2, $3,$4)
-        RETURNING id`
+        SELECT id, store_id, COALESCE(catalog_id, '') AS catalog_id, COALESCE(product_id, '') AS product_id, quantity, quantity_min, quantity_max, price, price_special, price_previous, is_available, allow_backorder, count_id, is_serialized
+        FROM inventories
+        WHERE ($1='' OR store_id=$1) AND ($2='' OR catalog_id=$2)
+        ORDER BY store_id, product_id`
+
+	args := []interface{}{filter.StoreID, filter.CatalogID}
+
+	rows, err := s.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data inventory.Entity
+		if err = rows.StructScan(&data); err != nil {
+			return
+		}
+		if err = fn(data); err != nil {
+			return
+		}
+	}
+
+	return rows.Err()
+}
+
+func (s *InventoryRepository) Create(ctx context.Context, data inventory.Entity, change movement.Entity) (id string, err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	if id, err = s.insert(ctx, tx, data, change, ""); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+			err = inventory.ErrorDuplicate
+		}
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+// Upsert creates or updates the inventory of the product in the store.
+// When another request inserts the same pair first, the insert does nothing
+// and the row committed by that request is updated instead.
+func (s *InventoryRepository) Upsert(ctx context.Context, data inventory.Entity, change movement.Entity) (id string, err error) {
+	defer func() {
+		err = serializedError(err)
+	}()
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	id, err = s.insert(ctx, tx, data, change, "ON CONFLICT (store_id, product_id) DO NOTHING")
+	if err != nil && err != sql.ErrNoRows {
+		return
+	}
+
+	if err == sql.ErrNoRows {
+		query := `
+        SELECT id
+        FROM inventories
+        WHERE store_id=$1 AND product_id=$2`
+
+		if err = tx.QueryRowContext(ctx, query, data.StoreID, data.ProductID).Scan(&id); err != nil {
+			return
+		}
 
-	args := []interface{}{data.StoreID, data.ProductID, data.Quantity, data.Price}
+		if err = s.update(ctx, tx, id, data, change); err != nil {
+			return
+		}
+	}
 
-	err = s.db.QueryRowContext(ctx, query, args...).Scan(&id)
+	err = tx.Commit()
+
+	return
+}
+
+// UpsertBatch copies the batch into a temporary table and merges it in two
+// statements: new pairs are inserted first, then the existing ones are locked
+// and updated, so every movement has the delta against the replaced balance.
+// The prices go into the histories in the same transaction.
+func (s *InventoryRepository) UpsertBatch(ctx context.Context, data []inventory.Entity, change movement.Entity) (err error) {
+	defer func() {
+		err = serializedError(err)
+	}()
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	query := `
+        CREATE TEMP TABLE inventory_import (
+            store_id        VARCHAR,
+            product_id      VARCHAR,
+            quantity        INTEGER,
+            quantity_min    INTEGER,
+            quantity_max    INTEGER,
+            price           NUMERIC,
+            price_special   NUMERIC,
+            price_previous  NUMERIC,
+            is_available    BOOLEAN,
+            allow_backorder BOOLEAN
+        ) ON COMMIT DROP`
+
+	if _, err = tx.ExecContext(ctx, query); err != nil {
+		return
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("inventory_import", inventory.Columns...))
+	if err != nil {
+		return
+	}
+	defer stmt.Close()
+
+	for _, object := range data {
+		args := []interface{}{object.StoreID, object.ProductID, object.Quantity, object.Price, object.QuantityMin, object.QuantityMax, object.PriceSpecial, object.PricePrevious, object.IsAvailable, object.AllowBackorder}
+		if _, err = stmt.ExecContext(ctx, args...); err != nil {
+			return
+		}
+	}
+
+	if _, err = stmt.ExecContext(ctx); err != nil {
+		return
+	}
+
+	query = `
+        WITH inserted AS (
+            INSERT INTO inventories (store_id, product_id, quantity, quantity_min, quantity_max, price, price_special, price_previous, is_available, allow_backorder)
+            SELECT store_id, product_id, quantity, COALESCE(quantity_min, 0), COALESCE(quantity_max, 0), price, COALESCE(price_special, 0), COALESCE(price_previous, 0), COALESCE(is_available, FALSE), COALESCE(allow_backorder, FALSE)
+            FROM inventory_import
+            ON CONFLICT (store_id, product_id) DO NOTHING
+            RETURNING id, quantity
+        )
+        INSERT INTO movements (inventory_id, reason, delta, balance, actor, correlation_id)
+        SELECT id, $1, quantity, quantity, $2, $3
+        FROM inserted
+        WHERE quantity <> 0`
+
+	args := []interface{}{change.Reason, change.Actor, change.CorrelationID}
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return
+	}
+
+	query = `
+        WITH previous AS (
+            SELECT i.id, i.quantity
+            FROM inventories i
+            JOIN inventory_import m ON m.store_id = i.store_id AND m.product_id = i.product_id
+            FOR UPDATE OF i
+        ), updated AS (
+            UPDATE inventories i
+            SET quantity=m.quantity,
+                quantity_min=COALESCE(m.quantity_min, i.quantity_min),
+                quantity_max=COALESCE(m.quantity_max, i.quantity_max),
+                price=m.price,
+                price_special=COALESCE(m.price_special, i.price_special),
+                price_previous=COALESCE(m.price_previous, i.price_previous),
+                is_available=COALESCE(m.is_available, i.is_available),
+                allow_backorder=COALESCE(m.allow_backorder, i.allow_backorder),
+                updated_at=CURRENT_TIMESTAMP
+            FROM inventory_import m, previous p
+            WHERE i.id = p.id AND m.store_id = i.store_id AND m.product_id = i.product_id
+            RETURNING i.id, p.quantity AS before, i.quantity AS after
+        )
+        INSERT INTO movements (inventory_id, reason, delta, balance, actor, correlation_id)
+        SELECT id, $1, after - before, after, $2, $3
+        FROM updated
+        WHERE after <> before`
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return
+	}
+
+	// an inventory with a price history keeps the imported price in it, unless it is the one in effect
+	query = `
+        INSERT INTO prices (inventory_id, price, price_special, valid_from)
+        SELECT i.id, m.price, m.price_special, CURRENT_TIMESTAMP
+        FROM inventory_import m
+        JOIN inventories i ON i.store_id = m.store_id AND i.product_id = m.product_id
+        LEFT JOIN LATERAL (
+            SELECT p.price, p.price_special
+            FROM prices p
+            WHERE p.inventory_id = i.id AND p.valid_from <= CURRENT_TIMESTAMP AND (p.valid_to IS NULL OR p.valid_to > CURRENT_TIMESTAMP)
+            ORDER BY p.valid_from DESC, p.created_at DESC
+            LIMIT 1
+        ) e ON TRUE
+        WHERE EXISTS (SELECT 1 FROM prices p WHERE p.inventory_id = i.id)
+          AND (e.price IS NULL OR e.price <> m.price OR COALESCE(e.price_special, 0) <> COALESCE(m.price_special, 0))`
+
+	if _, err = tx.ExecContext(ctx, query); err != nil {
+		return
+	}
+
+	err = tx.Commit()
 
 	return
 }
 
 func (s *InventoryRepository) Get(ctx context.Context, id string) (dest inventory.Entity, err error) {
 	query := `
-        SELECT id, store_id,product_id quantity, quantity_min,quantity_max,price,price_sepcial,price_previous,is_available
+        SELECT id, store_id, COALESCE(catalog_id, '') AS catalog_id, COALESCE(product_id, '') AS product_id, quantity, quantity_min, quantity_max, price, price_special, price_previous, is_available, allow_backorder, count_id, is_serialized
         FROM inventories
         WHERE id=$1`
 
@@ -63,26 +277,162 @@ func (s *InventoryRepository) Get(ctx context.Context, id string) (dest inventor
 	return
 }
 
-func (s *InventoryRepository) Update(ctx context.Context, storeID string, data inventory.Entity) (err error) {
-	sets, args := s.prepareArgs(data)
-	if len(args) > 0 {
-		args = append(args, storeID)
-		sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+func (s *InventoryRepository) Update(ctx context.Context, id string, data inventory.Entity, change movement.Entity) (err error) {
+	defer func() {
+		err = serializedError(err)
+	}()
 
-		query := fmt.Sprintf("UPDATE inventories SET %s WHERE store_id=$%d", strings.Join(sets, ", "), len(args))
-		_, err = s.db.ExecContext(ctx, query, args...)
-		if err != nil && err != sql.ErrNoRows {
-			return
+	if _, args := s.prepareArgs(data); len(args) == 0 {
+		return
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	if err = s.update(ctx, tx, id, data, change); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+// insert adds the inventory and journals its initial quantity, the conflict
+// clause turns a duplicate into sql.ErrNoRows instead of an error.
+func (s *InventoryRepository) insert(ctx context.Context, tx *sqlx.Tx, data inventory.Entity, change movement.Entity, conflict string) (id string, err error) {
+	query := `
+        INSERT INTO inventories (store_id, product_id, quantity, quantity_min, quantity_max, price, price_special, price_previous, is_available, allow_backorder)
+        VALUES ($1, $2, $3, COALESCE($4, 0), COALESCE($5, 0), $6, COALESCE($7, 0), COALESCE($8, 0), COALESCE($9, FALSE), COALESCE($10, FALSE))
+        ` + conflict + `
+        RETURNING id, quantity`
+
+	args := []interface{}{data.StoreID, data.ProductID, data.Quantity, data.QuantityMin, data.QuantityMax, data.Price, data.PriceSpecial, data.PricePrevious, data.IsAvailable, data.AllowBackorder}
+
+	var quantity int
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&id, &quantity); err != nil {
+		return
+	}
+
+	if quantity != 0 {
+		change.InventoryID = id
+		change.Delta = quantity
+		change.Balance = quantity
+
+		err = insertMovement(ctx, tx, change)
+	}
+
+	return
+}
+
+// update locks the row, so the movement written next to the new quantity
+// always carries the delta against the balance it actually replaced.
+func (s *InventoryRepository) update(ctx context.Context, tx *sqlx.Tx, id string, data inventory.Entity, change movement.Entity) (err error) {
+	var before, after int
+
+	query := `
+        SELECT quantity
+        FROM inventories
+        WHERE id=$1
+        FOR UPDATE`
+
+	if err = tx.QueryRowContext(ctx, query, id).Scan(&before); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
 		}
+		return
+	}
+
+	sets, args := s.prepareArgs(data)
+	args = append(args, id)
+	sets = append(sets, "updated_at=CURRENT_TIMESTAMP")
+
+	query = fmt.Sprintf("UPDATE inventories SET %s WHERE id=$%d RETURNING quantity", strings.Join(sets, ", "), len(args))
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&after); err != nil {
+		return
+	}
+
+	if after != before {
+		change.InventoryID = id
+		change.Delta = after - before
+		change.Balance = after
+
+		err = insertMovement(ctx, tx, change)
+	}
+
+	return
+}
+
+// Adjust applies the delta and journals it in a single conditional statement,
+// so concurrent adjustments never overwrite each other or go below zero. A
+// decrease cannot take stock held by active reservations: the inventory row is
+// locked first, as placing a hold does, so the statement sees every hold.
+func (s *InventoryRepository) Adjust(ctx context.Context, id string, delta int, change movement.Entity) (balance int, err error) {
+	defer func() {
+		err = serializedError(err)
+	}()
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	query := `
+        SELECT id
+        FROM inventories
+        WHERE id=$1
+        FOR UPDATE`
 
+	if err = tx.QueryRowContext(ctx, query, id).Scan(&id); err != nil {
 		if err == sql.ErrNoRows {
 			err = storage.ErrorNotFound
 		}
+		return
+	}
+
+	query = `
+        WITH updated AS (
+            UPDATE inventories
+            SET quantity=quantity+$2, updated_at=CURRENT_TIMESTAMP
+            WHERE id=$1 AND ($2 >= 0 OR allow_backorder OR quantity+$2 >= (
+                SELECT COALESCE(SUM(quantity), 0)
+                FROM reservations
+                WHERE inventory_id=$1 AND status=$6 AND expires_at > CURRENT_TIMESTAMP))
+            RETURNING id, quantity
+        )
+        INSERT INTO movements (inventory_id, reason, delta, balance, actor, correlation_id)
+        SELECT id, $3, $2, quantity, $4, $5
+        FROM updated
+        RETURNING balance`
+
+	args := []interface{}{id, delta, change.Reason, change.Actor, change.CorrelationID, reservation.StatusHeld}
+
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&balance); err != nil {
+		// nothing was updated, the stock left after the holds is short
+		if err == sql.ErrNoRows {
+			err = inventory.ErrorInsufficientStock
+		}
+		return
 	}
 
+	err = tx.Commit()
+
 	return
 }
 
+// serializedError reports a write that tried to change the quantity of a serialized
+// inventory, which the inventories_serialized_quantity trigger refuses, as ErrorSerialized.
+func serializedError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == checkViolation && pqErr.Constraint == "inventories_serialized_quantity" {
+		return inventory.ErrorSerialized
+	}
+	return err
+}
+
 func (s *InventoryRepository) prepareArgs(data inventory.Entity) (sets []string, args []any) {
 
 	if data.Quantity != nil {
@@ -113,6 +463,10 @@ func (s *InventoryRepository) prepareArgs(data inventory.Entity) (sets []string,
 		args = append(args, *data.IsAvailable)
 		sets = append(sets, fmt.Sprintf("is_available=$%d", len(args)))
 	}
+	if data.AllowBackorder != nil {
+		args = append(args, *data.AllowBackorder)
+		sets = append(sets, fmt.Sprintf("allow_backorder=$%d", len(args)))
+	}
 
 	return
 }
@@ -0,0 +1,333 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/location"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/pkg/storage"
+)
+
+// LocationRepository keeps the locations and the bin stock, the inventories_unbin
+// trigger takes every decrement not aimed at a bin out of the bins.
+type LocationRepository struct {
+	db *sqlx.DB
+}
+
+func NewLocationRepository(db *sqlx.DB) *LocationRepository {
+	return &LocationRepository{
+		db: db,
+	}
+}
+
+func (s *LocationRepository) Select(ctx context.Context, storeID string) (dest []location.Entity, err error) {
+	query := `
+        SELECT l.created_at, l.id, l.store_id, l.parent_id, l.kind, l.code, l.name
+        FROM locations AS l
+        LEFT JOIN locations AS p ON p.id=l.parent_id
+        LEFT JOIN locations AS g ON g.id=p.parent_id
+        WHERE l.store_id=$1
+        ORDER BY CONCAT_WS('/', g.code, p.code, l.code)`
+
+	args := []interface{}{storeID}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (s *LocationRepository) Get(ctx context.Context, id string) (dest location.Entity, err error) {
+	query := `
+        SELECT created_at, id, store_id, parent_id, kind, code, name
+        FROM locations
+        WHERE id=$1`
+
+	args := []interface{}{id}
+
+	if err = s.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (s *LocationRepository) Create(ctx context.Context, data location.Entity) (id string, err error) {
+	if data.ParentID != nil {
+		parent, err := s.Get(ctx, *data.ParentID)
+		if err != nil && err != storage.ErrorNotFound {
+			return "", err
+		}
+
+		if err == storage.ErrorNotFound || parent.StoreID != data.StoreID || parent.Kind != location.ParentKind(data.Kind) {
+			return "", location.ErrorParent
+		}
+	}
+
+	query := `
+        INSERT INTO locations (store_id, parent_id, kind, code, name)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id`
+
+	args := []interface{}{data.StoreID, data.ParentID, data.Kind, data.Code, data.Name}
+
+	if err = s.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+			err = location.ErrorDuplicate
+		}
+	}
+
+	return
+}
+
+func (s *LocationRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+        DELETE FROM locations AS l
+        WHERE l.id=$1
+            AND NOT EXISTS (SELECT 1 FROM locations WHERE parent_id=l.id)
+            AND NOT EXISTS (SELECT 1 FROM bin_stock WHERE location_id=l.id)
+        RETURNING l.id`
+
+	args := []interface{}{id}
+
+	if err = s.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if err != sql.ErrNoRows {
+			return
+		}
+
+		// tell a missing location from one still in use
+		if _, err = s.Get(ctx, id); err == nil {
+			err = location.ErrorNotEmpty
+		}
+	}
+
+	return
+}
+
+func (s *LocationRepository) SelectStock(ctx context.Context, filter location.Filter) (dest []location.Stock, err error) {
+	query := `
+        SELECT b.location_id, b.inventory_id, i.store_id, COALESCE(i.product_id, '') AS product_id,
+               CONCAT_WS('/', g.code, p.code, l.code) AS path, b.quantity
+        FROM bin_stock AS b
+        JOIN inventories AS i ON i.id=b.inventory_id
+        JOIN locations AS l ON l.id=b.location_id
+        LEFT JOIN locations AS p ON p.id=l.parent_id
+        LEFT JOIN locations AS g ON g.id=p.parent_id
+        WHERE ($1='' OR b.inventory_id::text=$1) AND ($2='' OR i.store_id=$2) AND ($3='' OR i.product_id=$3)
+        ORDER BY path, b.inventory_id`
+
+	args := []interface{}{filter.InventoryID, filter.StoreID, filter.ProductID}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (s *LocationRepository) Move(ctx context.Context, inventoryID, fromID, toID string, quantity int) (err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	storeID, balance, err := s.lock(ctx, tx, inventoryID)
+	if err != nil {
+		return
+	}
+
+	if err = s.checkBins(ctx, tx, storeID, fromID, toID); err != nil {
+		return
+	}
+
+	if fromID == "" {
+		var held int
+
+		query := `
+        SELECT COALESCE(SUM(quantity), 0)
+        FROM bin_stock
+        WHERE inventory_id=$1`
+
+		if err = tx.QueryRowContext(ctx, query, inventoryID).Scan(&held); err != nil {
+			return
+		}
+
+		if balance-held < quantity {
+			return location.ErrorUnassigned
+		}
+	} else if err = s.putAway(ctx, tx, inventoryID, fromID, -quantity); err != nil {
+		return
+	}
+
+	if toID != "" {
+		if err = s.putAway(ctx, tx, inventoryID, toID, quantity); err != nil {
+			return
+		}
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+func (s *LocationRepository) Adjust(ctx context.Context, inventoryID, binID string, delta int, change movement.Entity) (balance int, err error) {
+	defer func() {
+		err = serializedError(err)
+	}()
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	storeID, quantity, err := s.lock(ctx, tx, inventoryID)
+	if err != nil {
+		return
+	}
+
+	if err = s.checkBins(ctx, tx, storeID, binID); err != nil {
+		return
+	}
+
+	// a pick cannot take stock held by active reservations
+	if delta < 0 {
+		held, err := heldQuantity(ctx, tx, inventoryID)
+		if err != nil {
+			return 0, err
+		}
+
+		if quantity-held+delta < 0 {
+			return 0, inventory.ErrorInsufficientStock
+		}
+	}
+
+	// a pick leaves the bin before the quantity drops, so the trigger finds nothing in excess
+	if delta < 0 {
+		if err = s.putAway(ctx, tx, inventoryID, binID, delta); err != nil {
+			return
+		}
+	}
+
+	query := `
+        UPDATE inventories
+        SET quantity=quantity+$1, updated_at=CURRENT_TIMESTAMP
+        WHERE id=$2
+        RETURNING quantity`
+
+	if err = tx.QueryRowContext(ctx, query, delta, inventoryID).Scan(&balance); err != nil {
+		return
+	}
+
+	// stock filling backorders leaves right away, only what remains is put away
+	put := delta
+	if balance < put {
+		put = balance
+	}
+
+	if delta > 0 && put > 0 {
+		if err = s.putAway(ctx, tx, inventoryID, binID, put); err != nil {
+			return
+		}
+	}
+
+	change.InventoryID = inventoryID
+	change.Delta = delta
+	change.Balance = balance
+
+	if err = insertMovement(ctx, tx, change); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+// lock takes the inventory row lock and returns the store and quantity of the inventory.
+func (s *LocationRepository) lock(ctx context.Context, tx *sqlx.Tx, inventoryID string) (storeID string, quantity int, err error) {
+	query := `
+        SELECT store_id, quantity
+        FROM inventories
+        WHERE id=$1
+        FOR UPDATE`
+
+	if err = tx.QueryRowContext(ctx, query, inventoryID).Scan(&storeID, &quantity); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+// checkBins makes sure every non-blank id is a bin of the store.
+func (s *LocationRepository) checkBins(ctx context.Context, tx *sqlx.Tx, storeID string, ids ...string) (err error) {
+	bins := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != "" {
+			bins = append(bins, id)
+		}
+	}
+
+	var found int
+
+	query := `
+        SELECT COUNT(*)
+        FROM locations
+        WHERE id::text = ANY($1::varchar[]) AND kind=$2 AND store_id::text=$3`
+
+	args := []interface{}{pq.Array(bins), location.KindBin, storeID}
+
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&found); err != nil {
+		return
+	}
+
+	if found != len(bins) {
+		err = location.ErrorNotBin
+	}
+
+	return
+}
+
+// putAway adds the signed quantity to the bin, taking out more than it holds fails.
+func (s *LocationRepository) putAway(ctx context.Context, tx *sqlx.Tx, inventoryID, binID string, quantity int) (err error) {
+	if quantity > 0 {
+		query := `
+        INSERT INTO bin_stock (inventory_id, location_id, quantity)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (inventory_id, location_id) DO UPDATE
+        SET quantity=bin_stock.quantity+EXCLUDED.quantity`
+
+		_, err = tx.ExecContext(ctx, query, inventoryID, binID, quantity)
+
+		return
+	}
+
+	query := `
+        UPDATE bin_stock
+        SET quantity=quantity+$3
+        WHERE inventory_id=$1 AND location_id=$2 AND quantity >= -$3`
+
+	result, err := tx.ExecContext(ctx, query, inventoryID, binID, quantity)
+	if err != nil {
+		return
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return inventory.ErrorInsufficientStock
+	}
+
+	query = `
+        DELETE FROM bin_stock
+        WHERE inventory_id=$1 AND location_id=$2 AND quantity=0`
+
+	_, err = tx.ExecContext(ctx, query, inventoryID, binID)
+
+	return
+}
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"warehouse-service/internal/domain/lot"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/pkg/storage"
+)
+
+// LotRepository fills the lots, the inventories_consume_lots trigger takes
+// every decrement of an inventory quantity out of them.
+type LotRepository struct {
+	db *sqlx.DB
+}
+
+func NewLotRepository(db *sqlx.DB) *LotRepository {
+	return &LotRepository{
+		db: db,
+	}
+}
+
+func (s *LotRepository) Select(ctx context.Context, inventoryID string) (dest []lot.Entity, err error) {
+	query := `
+        SELECT l.created_at, l.updated_at, l.expires_at, l.inventory_id, i.store_id, COALESCE(i.product_id, '') AS product_id, l.number, l.quantity
+        FROM lots AS l
+        JOIN inventories AS i ON i.id=l.inventory_id
+        WHERE l.inventory_id=$1
+        ORDER BY l.expires_at, l.number`
+
+	args := []interface{}{inventoryID}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (s *LotRepository) SelectExpiring(ctx context.Context, storeID string, before time.Time) (dest []lot.Entity, err error) {
+	query := `
+        SELECT l.created_at, l.updated_at, l.expires_at, l.inventory_id, i.store_id, COALESCE(i.product_id, '') AS product_id, l.number, l.quantity
+        FROM lots AS l
+        JOIN inventories AS i ON i.id=l.inventory_id
+        WHERE i.store_id=$1 AND l.expires_at < $2::date
+        ORDER BY l.expires_at, l.number`
+
+	args := []interface{}{storeID, before.Format(lot.DateLayout)}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (s *LotRepository) Receive(ctx context.Context, data lot.Entity, change movement.Entity) (balance int, err error) {
+	defer func() {
+		err = serializedError(err)
+	}()
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	query := `
+        UPDATE inventories
+        SET quantity=quantity+$1, updated_at=CURRENT_TIMESTAMP
+        WHERE id=$2
+        RETURNING quantity`
+
+	if err = tx.QueryRowContext(ctx, query, data.Quantity, data.InventoryID).Scan(&balance); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
+		}
+		return
+	}
+
+	// stock filling backorders leaves right away, only what remains goes into the lot
+	received := data.Quantity
+	if balance < received {
+		received = balance
+	}
+
+	if received > 0 {
+		query = `
+        INSERT INTO lots (inventory_id, number, expires_at, quantity)
+        VALUES ($1, $2, $3::date, $4)
+        ON CONFLICT (inventory_id, number) DO UPDATE
+        SET quantity=lots.quantity+EXCLUDED.quantity, updated_at=CURRENT_TIMESTAMP
+        WHERE lots.expires_at=EXCLUDED.expires_at`
+
+		args := []interface{}{data.InventoryID, data.Number, data.ExpiresAt.Format(lot.DateLayout), received}
+
+		result, err := tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			return 0, lot.ErrorExpiryMismatch
+		}
+	}
+
+	change.InventoryID = data.InventoryID
+	change.Delta = data.Quantity
+	change.Balance = balance
+
+	if err = insertMovement(ctx, tx, change); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
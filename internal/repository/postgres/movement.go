@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"warehouse-service/internal/domain/movement"
+)
+
+type MovementRepository struct {
+	db *sqlx.DB
+}
+
+func NewMovementRepository(db *sqlx.DB) *MovementRepository {
+	return &MovementRepository{
+		db: db,
+	}
+}
+
+func (s *MovementRepository) Select(ctx context.Context, inventoryID string) (dest []movement.Entity, err error) {
+	query := `
+        SELECT created_at, id, inventory_id, reason, delta, balance, actor, correlation_id
+        FROM movements
+        WHERE inventory_id=$1
+        ORDER BY created_at, seq`
+
+	args := []interface{}{inventoryID}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (s *MovementRepository) SelectChanged(ctx context.Context, since time.Time) (dest []string, err error) {
+	query := `
+        SELECT DISTINCT inventory_id
+        FROM movements
+        WHERE created_at >= $1::timestamptz`
+
+	args := []interface{}{since}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+// insertMovement writes the journal row inside the transaction that changes the quantity.
+func insertMovement(ctx context.Context, tx *sqlx.Tx, data movement.Entity) (err error) {
+	query := `
+        INSERT INTO movements (inventory_id, reason, delta, balance, actor, correlation_id)
+        VALUES ($1, $2, $3, $4, $5, $6)`
+
+	args := []interface{}{data.InventoryID, data.Reason, data.Delta, data.Balance, data.Actor, data.CorrelationID}
+
+	_, err = tx.ExecContext(ctx, query, args...)
+
+	return
+}
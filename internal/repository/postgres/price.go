@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"warehouse-service/internal/domain/price"
+	"warehouse-service/pkg/storage"
+)
+
+type PriceRepository struct {
+	db *sqlx.DB
+}
+
+func NewPriceRepository(db *sqlx.DB) *PriceRepository {
+	return &PriceRepository{
+		db: db,
+	}
+}
+
+func (s *PriceRepository) Select(ctx context.Context, inventoryID string) (dest []price.Entity, err error) {
+	query := `
+        SELECT created_at, valid_from, valid_to, id, inventory_id, price, price_special
+        FROM prices
+        WHERE inventory_id=$1
+        ORDER BY valid_from, created_at`
+
+	args := []interface{}{inventoryID}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (s *PriceRepository) SelectMany(ctx context.Context, inventoryIDs []string) (dest []price.Entity, err error) {
+	query := `
+        SELECT created_at, valid_from, valid_to, id, inventory_id, price, price_special
+        FROM prices
+        WHERE inventory_id = ANY($1)
+        ORDER BY inventory_id, valid_from, created_at`
+
+	args := []interface{}{pq.Array(inventoryIDs)}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (s *PriceRepository) Get(ctx context.Context, id string) (dest price.Entity, err error) {
+	query := `
+        SELECT created_at, valid_from, valid_to, id, inventory_id, price, price_special
+        FROM prices
+        WHERE id=$1`
+
+	args := []interface{}{id}
+
+	if err = s.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (s *PriceRepository) Create(ctx context.Context, data price.Entity) (id string, err error) {
+	query := `
+        INSERT INTO prices (inventory_id, price, price_special, valid_from, valid_to)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id`
+
+	args := []interface{}{data.InventoryID, data.Price, data.PriceSpecial, data.ValidFrom, data.ValidTo}
+
+	err = s.db.QueryRowContext(ctx, query, args...).Scan(&id)
+
+	return
+}
+
+func (s *PriceRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `
+        DELETE FROM prices
+        WHERE id=$1 AND valid_from > CURRENT_TIMESTAMP
+        RETURNING id`
+
+	args := []interface{}{id}
+
+	if err = s.db.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		if err != sql.ErrNoRows {
+			return
+		}
+
+		// tell a missing price from one already in effect
+		if _, err = s.Get(ctx, id); err == nil {
+			err = price.ErrorStarted
+		}
+	}
+
+	return
+}
+
+func (s *PriceRepository) SelectBoundaries(ctx context.Context, since, until time.Time) (dest []string, err error) {
+	query := `
+        SELECT inventory_id
+        FROM prices
+        WHERE valid_from > $1 AND valid_from <= $2
+        UNION
+        SELECT inventory_id
+        FROM prices
+        WHERE valid_to > $1 AND valid_to <= $2
+        ORDER BY inventory_id`
+
+	args := []interface{}{since, until}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"warehouse-service/internal/domain/rate"
+	"warehouse-service/pkg/storage"
+)
+
+type RateRepository struct {
+	db *sqlx.DB
+}
+
+func NewRateRepository(db *sqlx.DB) *RateRepository {
+	return &RateRepository{
+		db: db,
+	}
+}
+
+func (s *RateRepository) Select(ctx context.Context, filter rate.Filter) (dest []rate.Entity, err error) {
+	query := `
+        SELECT created_at, valid_from, base, quote, rate
+        FROM exchange_rates
+        WHERE ($1='' OR base=$1) AND ($2='' OR quote=$2)
+        ORDER BY base, quote, valid_from`
+
+	args := []interface{}{filter.Base, filter.Quote}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (s *RateRepository) Get(ctx context.Context, base, quote string, at time.Time) (dest rate.Entity, err error) {
+	query := `
+        SELECT created_at, valid_from, base, quote, rate
+        FROM exchange_rates
+        WHERE base=$1 AND quote=$2 AND valid_from <= $3
+        ORDER BY valid_from DESC
+        LIMIT 1`
+
+	args := []interface{}{base, quote, at}
+
+	if err = s.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
+		}
+	}
+
+	return
+}
+
+func (s *RateRepository) Save(ctx context.Context, data []rate.Entity) (err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO exchange_rates (base, quote, rate, valid_from)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (base, quote, valid_from) DO UPDATE
+        SET rate=EXCLUDED.rate`
+
+	for _, object := range data {
+		args := []interface{}{object.Base, object.Quote, object.Rate, object.ValidFrom}
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return
+		}
+	}
+
+	err = tx.Commit()
+
+	return
+}
@@ -0,0 +1,228 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/reservation"
+	"warehouse-service/pkg/storage"
+)
+
+type ReservationRepository struct {
+	db *sqlx.DB
+}
+
+func NewReservationRepository(db *sqlx.DB) *ReservationRepository {
+	return &ReservationRepository{
+		db: db,
+	}
+}
+
+func (s *ReservationRepository) Select(ctx context.Context, inventoryID string) (dest []reservation.Entity, err error) {
+	query := `
+        SELECT created_at, updated_at, expires_at, id, inventory_id, quantity, status
+        FROM reservations
+        WHERE inventory_id=$1
+        ORDER BY created_at`
+
+	args := []interface{}{inventoryID}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+// Create locks the inventory row, so concurrent holds on the same item are
+// checked against availability one after another and can never oversell.
+func (s *ReservationRepository) Create(ctx context.Context, data reservation.Entity) (id string, err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	var quantity, held int
+
+	query := `
+        SELECT quantity
+        FROM inventories
+        WHERE id=$1
+        FOR UPDATE`
+
+	if err = tx.QueryRowContext(ctx, query, data.InventoryID).Scan(&quantity); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
+		}
+		return
+	}
+
+	if held, err = heldQuantity(ctx, tx, data.InventoryID); err != nil {
+		return
+	}
+
+	if quantity-held < data.Quantity {
+		err = inventory.ErrorInsufficientStock
+		return
+	}
+
+	query = `
+        INSERT INTO reservations (inventory_id, quantity, status, expires_at)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id`
+
+	args := []interface{}{data.InventoryID, data.Quantity, reservation.StatusHeld, data.ExpiresAt}
+
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+func (s *ReservationRepository) Get(ctx context.Context, id string) (dest reservation.Entity, err error) {
+	query := `
+        SELECT created_at, updated_at, expires_at, id, inventory_id, quantity, status
+        FROM reservations
+        WHERE id=$1`
+
+	args := []interface{}{id}
+
+	if err = s.db.GetContext(ctx, &dest, query, args...); err != nil && err != sql.ErrNoRows {
+		return
+	}
+
+	if err == sql.ErrNoRows {
+		err = storage.ErrorNotFound
+	}
+
+	return
+}
+
+func (s *ReservationRepository) Commit(ctx context.Context, id string, change movement.Entity) (err error) {
+	defer func() {
+		err = serializedError(err)
+	}()
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	data := reservation.Entity{}
+
+	query := `
+        SELECT created_at, updated_at, expires_at, id, inventory_id, quantity, status
+        FROM reservations
+        WHERE id=$1
+        FOR UPDATE`
+
+	if err = tx.GetContext(ctx, &data, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
+		}
+		return
+	}
+
+	if data.Status != reservation.StatusHeld {
+		err = reservation.ErrorNotHeld
+		return
+	}
+
+	query = `
+        UPDATE inventories
+        SET quantity=quantity-$1, updated_at=CURRENT_TIMESTAMP
+        WHERE id=$2 AND quantity >= $1
+        RETURNING quantity`
+
+	if err = tx.QueryRowContext(ctx, query, data.Quantity, data.InventoryID).Scan(&change.Balance); err != nil {
+		if err == sql.ErrNoRows {
+			err = inventory.ErrorInsufficientStock
+		}
+		return
+	}
+
+	change.InventoryID = data.InventoryID
+	change.Delta = -data.Quantity
+
+	if err = insertMovement(ctx, tx, change); err != nil {
+		return
+	}
+
+	query = `
+        UPDATE reservations
+        SET status=$1, updated_at=CURRENT_TIMESTAMP
+        WHERE id=$2 AND expires_at > CURRENT_TIMESTAMP`
+
+	result, err := tx.ExecContext(ctx, query, reservation.StatusCommitted, id)
+	if err != nil {
+		return
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		err = reservation.ErrorNotHeld
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+func (s *ReservationRepository) Release(ctx context.Context, id string) (err error) {
+	query := `
+        UPDATE reservations
+        SET status=$1, updated_at=CURRENT_TIMESTAMP
+        WHERE id=$2 AND status=$3 AND expires_at > CURRENT_TIMESTAMP`
+
+	args := []interface{}{reservation.StatusReleased, id, reservation.StatusHeld}
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		if _, err = s.Get(ctx, id); err != nil {
+			return
+		}
+		err = reservation.ErrorNotHeld
+	}
+
+	return
+}
+
+func (s *ReservationRepository) Expire(ctx context.Context) (count int64, err error) {
+	query := `
+        UPDATE reservations
+        SET status=$1, updated_at=CURRENT_TIMESTAMP
+        WHERE status=$2 AND expires_at <= CURRENT_TIMESTAMP`
+
+	args := []interface{}{reservation.StatusExpired, reservation.StatusHeld}
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+
+	return result.RowsAffected()
+}
+
+// heldQuantity sums the active holds on the inventory. Run after the inventory row
+// is locked it sees every hold, as placing one takes the same lock.
+func heldQuantity(ctx context.Context, tx *sqlx.Tx, inventoryID string) (held int, err error) {
+	query := `
+        SELECT COALESCE(SUM(quantity), 0)
+        FROM reservations
+        WHERE inventory_id=$1 AND status=$2 AND expires_at > CURRENT_TIMESTAMP`
+
+	err = tx.QueryRowContext(ctx, query, inventoryID, reservation.StatusHeld).Scan(&held)
+
+	return
+}
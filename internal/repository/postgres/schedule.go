@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"warehouse-service/internal/domain/schedule"
 	"warehouse-service/pkg/storage"
@@ -32,6 +33,19 @@ func (s *ScheduleRepository) Select(ctx context.Context) (dest []schedule.Entity
 	return
 }
 
+func (s *ScheduleRepository) SelectByStores(ctx context.Context, storeIDs []string) (dest []schedule.Entity, err error) {
+	query := `
+        SELECT id, store_id, periods, is_active
+        FROM schedules
+        WHERE store_id = ANY($1::uuid[])`
+
+	args := []interface{}{pq.Array(storeIDs)}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
 func (s *ScheduleRepository) Create(ctx context.Context, data schedule.Entity) (id string, err error) {
 	query := `
         INSERT INTO schedules(store_id, periods, is_active)
@@ -85,6 +99,8 @@ func (s *ScheduleRepository) Update(ctx context.Context, storeID string, data *s
 }
 
 func (s *ScheduleRepository) prepareArgs(data *schedule.Entity) (sets []string, args []any) {
+	args = append(args, data.IsActive)
+	sets = append(sets, fmt.Sprintf("is_active=$%d", len(args)))
 
 	if data.Periods != nil {
 		args = append(args, data.Periods)
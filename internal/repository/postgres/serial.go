@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/serial"
+	"warehouse-service/pkg/storage"
+)
+
+type SerialRepository struct {
+	db *sqlx.DB
+}
+
+func NewSerialRepository(db *sqlx.DB) *SerialRepository {
+	return &SerialRepository{
+		db: db,
+	}
+}
+
+func (s *SerialRepository) Select(ctx context.Context, inventoryID, status string) (dest []serial.Entity, err error) {
+	query := `
+        SELECT s.created_at, s.updated_at, s.inventory_id, i.store_id, s.product_id, s.number, s.status
+        FROM serials AS s
+        JOIN inventories AS i ON i.id=s.inventory_id
+        WHERE s.inventory_id=$1 AND ($2='' OR s.status=$2)
+        ORDER BY s.number`
+
+	args := []interface{}{inventoryID, status}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (s *SerialRepository) SelectByNumber(ctx context.Context, number string) (dest []serial.Entity, err error) {
+	query := `
+        SELECT s.created_at, s.updated_at, s.inventory_id, i.store_id, s.product_id, s.number, s.status
+        FROM serials AS s
+        JOIN inventories AS i ON i.id=s.inventory_id
+        WHERE s.number=$1
+        ORDER BY s.product_id`
+
+	args := []interface{}{number}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (s *SerialRepository) Create(ctx context.Context, inventoryID string, numbers []string, change movement.Entity) (balance int, err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	var productID string
+
+	query := `
+        SELECT COALESCE(product_id, '')
+        FROM inventories
+        WHERE id=$1
+        FOR UPDATE`
+
+	if err = tx.QueryRowContext(ctx, query, inventoryID).Scan(&productID); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
+		}
+		return
+	}
+
+	query = `
+        INSERT INTO serials (inventory_id, product_id, number, status)
+        SELECT $1, $2, number, $3
+        FROM UNNEST($4::varchar[]) AS number`
+
+	args := []interface{}{inventoryID, productID, serial.StatusInStock, pq.Array(numbers)}
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+			err = serial.ErrorDuplicate
+		}
+		return
+	}
+
+	if balance, err = s.recount(ctx, tx, inventoryID, change); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+func (s *SerialRepository) SetStatus(ctx context.Context, inventoryID, number, status string, change movement.Entity) (balance int, err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	var current string
+
+	query := `
+        SELECT s.status
+        FROM serials AS s
+        JOIN inventories AS i ON i.id=s.inventory_id
+        WHERE s.inventory_id=$1 AND s.number=$2
+        FOR UPDATE`
+
+	if err = tx.QueryRowContext(ctx, query, inventoryID, number).Scan(&current); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
+		}
+		return
+	}
+
+	if !serial.CanMove(current, status) {
+		err = serial.ErrorTransition
+		return
+	}
+
+	query = `
+        UPDATE serials
+        SET status=$3, updated_at=CURRENT_TIMESTAMP
+        WHERE inventory_id=$1 AND number=$2`
+
+	if _, err = tx.ExecContext(ctx, query, inventoryID, number, status); err != nil {
+		return
+	}
+
+	if balance, err = s.recount(ctx, tx, inventoryID, change); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+// recount sets the quantity to the units in stock, the caller must hold the inventory row lock.
+func (s *SerialRepository) recount(ctx context.Context, tx *sqlx.Tx, inventoryID string, change movement.Entity) (balance int, err error) {
+	var before int
+
+	query := `
+        SELECT quantity
+        FROM inventories
+        WHERE id=$1`
+
+	if err = tx.QueryRowContext(ctx, query, inventoryID).Scan(&before); err != nil {
+		return
+	}
+
+	query = `
+        UPDATE inventories
+        SET is_serialized=TRUE,
+            quantity=(SELECT COUNT(*) FROM serials WHERE inventory_id=$1 AND status=$2),
+            updated_at=CURRENT_TIMESTAMP
+        WHERE id=$1
+        RETURNING quantity`
+
+	if err = tx.QueryRowContext(ctx, query, inventoryID, serial.StatusInStock).Scan(&balance); err != nil {
+		return
+	}
+
+	if balance != before {
+		change.InventoryID = inventoryID
+		change.Delta = balance - before
+		change.Balance = balance
+
+		err = insertMovement(ctx, tx, change)
+	}
+
+	return
+}
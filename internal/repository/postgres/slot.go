@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"warehouse-service/internal/domain/slot"
+	"warehouse-service/pkg/storage"
+)
+
+// SlotRepository counts the bookings of each slot in delivery_slots, the conflict
+// update on the slot row takes a place only while one is left.
+type SlotRepository struct {
+	db *sqlx.DB
+}
+
+func NewSlotRepository(db *sqlx.DB) *SlotRepository {
+	return &SlotRepository{
+		db: db,
+	}
+}
+
+func (s *SlotRepository) SelectBooked(ctx context.Context, storeID string, from, to time.Time) (dest []slot.Count, err error) {
+	query := `
+        SELECT starts_at, booked
+        FROM delivery_slots
+        WHERE store_id=$1 AND starts_at >= $2 AND starts_at < $3 AND booked > 0
+        ORDER BY starts_at`
+
+	args := []interface{}{storeID, from, to}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
+func (s *SlotRepository) Book(ctx context.Context, data slot.Booking, capacity int) (id string, err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	var booked int
+
+	query := `
+        INSERT INTO delivery_slots (store_id, starts_at, booked)
+        VALUES ($1, $2, 1)
+        ON CONFLICT (store_id, starts_at) DO UPDATE
+        SET booked=delivery_slots.booked+1
+        WHERE delivery_slots.booked < $3
+        RETURNING booked`
+
+	args := []interface{}{data.StoreID, data.StartsAt, capacity}
+
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&booked); err != nil {
+		if err == sql.ErrNoRows {
+			err = slot.ErrorFull
+		}
+		return
+	}
+
+	query = `
+        INSERT INTO delivery_bookings (store_id, starts_at, ends_at, reference)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id`
+
+	args = []interface{}{data.StoreID, data.StartsAt, data.EndsAt, data.Reference}
+
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+func (s *SlotRepository) Cancel(ctx context.Context, storeID, id string) (err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	var startsAt time.Time
+
+	query := `
+        DELETE FROM delivery_bookings
+        WHERE store_id=$1 AND id::text=$2
+        RETURNING starts_at`
+
+	args := []interface{}{storeID, id}
+
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&startsAt); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
+		}
+		return
+	}
+
+	query = `
+        UPDATE delivery_slots
+        SET booked=booked-1
+        WHERE store_id=$1 AND starts_at=$2`
+
+	if _, err = tx.ExecContext(ctx, query, storeID, startsAt); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
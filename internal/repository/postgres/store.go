@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"warehouse-service/internal/domain/store"
 	"warehouse-service/pkg/storage"
@@ -24,7 +25,7 @@ func NewStoreRepository(db *sqlx.DB) *StoreRepository {
 
 func (s *StoreRepository) Select(ctx context.Context) (dest []store.Entity, err error) {
 	query := `
-        SELECT id, merchant_id, city_id, name, address, location, rating, is_active
+        SELECT id, merchant_id, city_id, name, address, location, rating, is_active, latitude, longitude
         FROM stores`
 
 	err = s.db.SelectContext(ctx, &dest, query)
@@ -32,13 +33,26 @@ func (s *StoreRepository) Select(ctx context.Context) (dest []store.Entity, err
 	return
 }
 
+func (s *StoreRepository) SelectByIDs(ctx context.Context, ids []string) (dest []store.Entity, err error) {
+	query := `
+        SELECT id, merchant_id, city_id, name, address, location, rating, is_active, latitude, longitude
+        FROM stores
+        WHERE id = ANY($1::uuid[])`
+
+	args := []interface{}{pq.Array(ids)}
+
+	err = s.db.SelectContext(ctx, &dest, query, args...)
+
+	return
+}
+
 func (s *StoreRepository) Create(ctx context.Context, data store.Entity) (id string, err error) {
 	query := `
-        INSERT INTO stores (merchant_id, city_id, name, address, location)
-        VALUES ($1, $2, $3, $4, $5)
+        INSERT INTO stores (merchant_id, city_id, name, address, location, latitude, longitude)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
         RETURNING id`
 
-	args := []interface{}{data.MerchantID, data.CityID, data.Name, data.Address, data.Location}
+	args := []interface{}{data.MerchantID, data.CityID, data.Name, data.Address, data.Location, data.Latitude, data.Longitude}
 
 	err = s.db.QueryRowContext(ctx, query, args...).Scan(&id)
 
@@ -47,7 +61,7 @@ func (s *StoreRepository) Create(ctx context.Context, data store.Entity) (id str
 
 func (s *StoreRepository) Get(ctx context.Context, id string) (dest store.Entity, err error) {
 	query := `
-        SELECT id, merchant_id, city_id, name, address, location, rating, is_active
+        SELECT id, merchant_id, city_id, name, address, location, rating, is_active, latitude, longitude
         FROM stores
         WHERE id=$1`
 
@@ -101,6 +115,14 @@ func (s *StoreRepository) prepareArgs(data store.Entity) (sets []string, args []
 		sets = append(sets, fmt.Sprintf("location=$%d", len(args)))
 	}
 
+	if data.Latitude != nil && data.Longitude != nil {
+		args = append(args, data.Latitude)
+		sets = append(sets, fmt.Sprintf("latitude=$%d", len(args)))
+
+		args = append(args, data.Longitude)
+		sets = append(sets, fmt.Sprintf("longitude=$%d", len(args)))
+	}
+
 	if data.Rating != nil {
 		args = append(args, data.Rating)
 		sets = append(sets, fmt.Sprintf("rating=$%d", len(args)))
@@ -0,0 +1,318 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/reservation"
+	"warehouse-service/internal/domain/transfer"
+	"warehouse-service/pkg/storage"
+)
+
+type TransferRepository struct {
+	db *sqlx.DB
+}
+
+func NewTransferRepository(db *sqlx.DB) *TransferRepository {
+	return &TransferRepository{
+		db: db,
+	}
+}
+
+func (s *TransferRepository) Select(ctx context.Context, filter transfer.Filter) (dest []transfer.Entity, err error) {
+	query := `
+        SELECT created_at, updated_at, shipped_at, id, source_store_id, destination_store_id, status
+        FROM transfers
+        WHERE ($1='' OR source_store_id=$1 OR destination_store_id=$1) AND ($2='' OR status=$2)
+        ORDER BY created_at DESC`
+
+	args := []interface{}{filter.StoreID, filter.Status}
+
+	if err = s.db.SelectContext(ctx, &dest, query, args...); err != nil {
+		return
+	}
+
+	ids := make([]string, len(dest))
+	for i, data := range dest {
+		ids[i] = data.ID
+	}
+
+	lines, err := s.selectLines(ctx, s.db, ids)
+	if err != nil {
+		return
+	}
+
+	for i, data := range dest {
+		dest[i].Lines = lines[data.ID]
+	}
+
+	return
+}
+
+func (s *TransferRepository) Create(ctx context.Context, data transfer.Entity) (id string, err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO transfers (source_store_id, destination_store_id, status)
+        VALUES ($1, $2, $3)
+        RETURNING id`
+
+	args := []interface{}{data.SourceStoreID, data.DestinationStoreID, transfer.StatusCreated}
+
+	if err = tx.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		return
+	}
+
+	query = `
+        INSERT INTO transfer_lines (transfer_id, product_id, quantity)
+        VALUES ($1, $2, $3)`
+
+	for _, line := range data.Lines {
+		if _, err = tx.ExecContext(ctx, query, id, line.ProductID, line.Quantity); err != nil {
+			return
+		}
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+func (s *TransferRepository) Get(ctx context.Context, id string) (dest transfer.Entity, err error) {
+	query := `
+        SELECT created_at, updated_at, shipped_at, id, source_store_id, destination_store_id, status
+        FROM transfers
+        WHERE id=$1`
+
+	args := []interface{}{id}
+
+	if err = s.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
+		}
+		return
+	}
+
+	lines, err := s.selectLines(ctx, s.db, []string{id})
+	if err != nil {
+		return
+	}
+	dest.Lines = lines[id]
+
+	return
+}
+
+// Ship locks the source inventories in the order of the products, so two
+// transfers out of the same store cannot deadlock each other.
+func (s *TransferRepository) Ship(ctx context.Context, id string, change movement.Entity) (err error) {
+	defer func() {
+		err = serializedError(err)
+	}()
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	data, err := s.lock(ctx, tx, id)
+	if err != nil {
+		return
+	}
+
+	if data.Status != transfer.StatusCreated {
+		err = transfer.ErrorNotCreated
+		return
+	}
+
+	for _, line := range data.Lines {
+		change.Delta = -line.Quantity
+
+		// the row is locked before the update, so the update sees every hold placed on it
+		query := `
+        SELECT id
+        FROM inventories
+        WHERE store_id=$1 AND product_id=$2
+        FOR UPDATE`
+
+		if err = tx.QueryRowContext(ctx, query, data.SourceStoreID, line.ProductID).Scan(&change.InventoryID); err != nil {
+			if err == sql.ErrNoRows {
+				err = inventory.ErrorInsufficientStock
+			}
+			return
+		}
+
+		// stock held by active reservations cannot be shipped
+		query = `
+        UPDATE inventories
+        SET quantity=quantity-$1, updated_at=CURRENT_TIMESTAMP
+        WHERE id=$2 AND quantity-$1 >= (
+            SELECT COALESCE(SUM(quantity), 0)
+            FROM reservations
+            WHERE inventory_id=$2 AND status=$3 AND expires_at > CURRENT_TIMESTAMP)
+        RETURNING quantity`
+
+		err = tx.QueryRowContext(ctx, query, line.Quantity, change.InventoryID, reservation.StatusHeld).Scan(&change.Balance)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				err = inventory.ErrorInsufficientStock
+			}
+			return
+		}
+
+		if err = insertMovement(ctx, tx, change); err != nil {
+			return
+		}
+	}
+
+	query := `
+        UPDATE transfers
+        SET status=$1, shipped_at=CURRENT_TIMESTAMP, updated_at=CURRENT_TIMESTAMP
+        WHERE id=$2`
+
+	if _, err = tx.ExecContext(ctx, query, transfer.StatusInTransit, id); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+// Receive adds the lines to the destination inventories, a product new to the
+// destination is stocked there with the price it has in the source.
+func (s *TransferRepository) Receive(ctx context.Context, id string, lines []transfer.Line, change movement.Entity) (err error) {
+	defer func() {
+		err = serializedError(err)
+	}()
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	data, err := s.lock(ctx, tx, id)
+	if err != nil {
+		return
+	}
+
+	if data.Status != transfer.StatusInTransit && data.Status != transfer.StatusPartiallyReceived {
+		err = transfer.ErrorNotInTransit
+		return
+	}
+
+	for _, line := range lines {
+		query := `
+        UPDATE transfer_lines
+        SET received=received+$1
+        WHERE transfer_id=$2 AND product_id=$3 AND received+$1 <= quantity`
+
+		result, err := tx.ExecContext(ctx, query, line.Quantity, id, line.ProductID)
+		if err != nil {
+			return err
+		}
+
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			return transfer.ErrorOverReceipt
+		}
+
+		query = `
+        INSERT INTO inventories (store_id, catalog_id, product_id, quantity, price)
+        SELECT $1, source.catalog_id, $3, 0, COALESCE(source.price, 0)
+        FROM (SELECT 1) AS one
+        LEFT JOIN inventories AS source ON source.store_id=$2 AND source.product_id=$3
+        ON CONFLICT (store_id, product_id) DO NOTHING`
+
+		if _, err = tx.ExecContext(ctx, query, data.DestinationStoreID, data.SourceStoreID, line.ProductID); err != nil {
+			return err
+		}
+
+		query = `
+        UPDATE inventories
+        SET quantity=quantity+$1, updated_at=CURRENT_TIMESTAMP
+        WHERE store_id=$2 AND product_id=$3
+        RETURNING id, quantity`
+
+		change.Delta = line.Quantity
+
+		err = tx.QueryRowContext(ctx, query, line.Quantity, data.DestinationStoreID, line.ProductID).Scan(&change.InventoryID, &change.Balance)
+		if err != nil {
+			return err
+		}
+
+		if err = insertMovement(ctx, tx, change); err != nil {
+			return err
+		}
+	}
+
+	query := `
+        UPDATE transfers
+        SET status=CASE WHEN EXISTS (SELECT 1 FROM transfer_lines WHERE transfer_id=$1 AND received < quantity) THEN $2 ELSE $3 END,
+            updated_at=CURRENT_TIMESTAMP
+        WHERE id=$1`
+
+	args := []interface{}{id, transfer.StatusPartiallyReceived, transfer.StatusReceived}
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+// lock reads the transfer and its lines, keeping the transfer locked until the transaction ends.
+func (s *TransferRepository) lock(ctx context.Context, tx *sqlx.Tx, id string) (dest transfer.Entity, err error) {
+	query := `
+        SELECT created_at, updated_at, shipped_at, id, source_store_id, destination_store_id, status
+        FROM transfers
+        WHERE id=$1
+        FOR UPDATE`
+
+	if err = tx.GetContext(ctx, &dest, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
+		}
+		return
+	}
+
+	lines, err := s.selectLines(ctx, tx, []string{id})
+	if err != nil {
+		return
+	}
+	dest.Lines = lines[id]
+
+	return
+}
+
+// selectLines groups the lines of the transfers by the transfer id.
+func (s *TransferRepository) selectLines(ctx context.Context, db sqlx.QueryerContext, ids []string) (dest map[string][]transfer.Line, err error) {
+	query := `
+        SELECT transfer_id, product_id, quantity, received
+        FROM transfer_lines
+        WHERE transfer_id = ANY($1::uuid[])
+        ORDER BY product_id`
+
+	lines := make([]transfer.Line, 0)
+	if err = sqlx.SelectContext(ctx, db, &lines, query, pq.Array(ids)); err != nil {
+		return
+	}
+
+	dest = make(map[string][]transfer.Line, len(ids))
+	for _, line := range lines {
+		dest[line.TransferID] = append(dest[line.TransferID], line)
+	}
+
+	return
+}
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"warehouse-service/internal/domain/unit"
+	"warehouse-service/pkg/storage"
+)
+
+type UnitRepository struct {
+	db *sqlx.DB
+}
+
+func NewUnitRepository(db *sqlx.DB) *UnitRepository {
+	return &UnitRepository{
+		db: db,
+	}
+}
+
+func (s *UnitRepository) Get(ctx context.Context, productID string) (dest unit.Entity, err error) {
+	query := `
+        SELECT created_at, updated_at, product_id, base_unit
+        FROM units
+        WHERE product_id=$1`
+
+	args := []interface{}{productID}
+
+	if err = s.db.GetContext(ctx, &dest, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
+		}
+		return
+	}
+
+	query = `
+        SELECT product_id, code, factor
+        FROM unit_conversions
+        WHERE product_id=$1
+        ORDER BY factor, code`
+
+	err = s.db.SelectContext(ctx, &dest.Units, query, args...)
+
+	return
+}
+
+func (s *UnitRepository) Save(ctx context.Context, data unit.Entity) (err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	query := `
+        INSERT INTO units (product_id, base_unit)
+        VALUES ($1, $2)
+        ON CONFLICT (product_id) DO UPDATE
+        SET base_unit=EXCLUDED.base_unit, updated_at=CURRENT_TIMESTAMP`
+
+	if _, err = tx.ExecContext(ctx, query, data.ProductID, data.BaseUnit); err != nil {
+		return
+	}
+
+	query = `
+        DELETE FROM unit_conversions
+        WHERE product_id=$1`
+
+	if _, err = tx.ExecContext(ctx, query, data.ProductID); err != nil {
+		return
+	}
+
+	codes := make([]string, 0, len(data.Units))
+	factors := make([]string, 0, len(data.Units))
+	for _, object := range data.Units {
+		codes = append(codes, object.Code)
+		factors = append(factors, object.Factor.String())
+	}
+
+	query = `
+        INSERT INTO unit_conversions (product_id, code, factor)
+        SELECT $1, code, factor
+        FROM UNNEST($2::varchar[], $3::numeric[]) AS u (code, factor)`
+
+	if _, err = tx.ExecContext(ctx, query, data.ProductID, pq.Array(codes), pq.Array(factors)); err != nil {
+		return
+	}
+
+	err = tx.Commit()
+
+	return
+}
+
+func (s *UnitRepository) Delete(ctx context.Context, productID string) (err error) {
+	query := `
+        DELETE FROM units
+        WHERE product_id=$1
+        RETURNING product_id`
+
+	args := []interface{}{productID}
+
+	if err = s.db.QueryRowContext(ctx, query, args...).Scan(&productID); err != nil {
+		if err == sql.ErrNoRows {
+			err = storage.ErrorNotFound
+		}
+	}
+
+	return
+}
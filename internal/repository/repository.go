@@ -1,13 +1,26 @@
 package repository
 
 import (
+	"warehouse-service/internal/domain/alert"
+	"warehouse-service/internal/domain/calendar"
 	"warehouse-service/internal/domain/city"
+	"warehouse-service/internal/domain/count"
 	"warehouse-service/internal/domain/country"
 	"warehouse-service/internal/domain/currency"
 	"warehouse-service/internal/domain/delivery"
 	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/location"
+	"warehouse-service/internal/domain/lot"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/price"
+	"warehouse-service/internal/domain/rate"
+	"warehouse-service/internal/domain/reservation"
 	"warehouse-service/internal/domain/schedule"
+	"warehouse-service/internal/domain/serial"
+	"warehouse-service/internal/domain/slot"
 	"warehouse-service/internal/domain/store"
+	"warehouse-service/internal/domain/transfer"
+	"warehouse-service/internal/domain/unit"
 	"warehouse-service/internal/repository/memory"
 	"warehouse-service/internal/repository/postgres"
 	"warehouse-service/pkg/storage"
@@ -33,6 +46,32 @@ type Repository struct {
 	Currency currency.Repository
 
 	Inventory inventory.Repository
+
+	Reservation reservation.Repository
+
+	Movement movement.Repository
+
+	Alert alert.Repository
+
+	Transfer transfer.Repository
+
+	Count count.Repository
+
+	Lot lot.Repository
+
+	Serial serial.Repository
+
+	Location location.Repository
+
+	Unit unit.Repository
+
+	Price price.Repository
+
+	Rate rate.Repository
+
+	Calendar calendar.Repository
+
+	Slot slot.Repository
 }
 
 // New takes a variable amount of Configuration functions and returns a new Repository
@@ -67,6 +106,37 @@ func WithMemoryStore() Configuration {
 
 		s.Store = memory.NewStoreRepository()
 
+		s.City = memory.NewCityRepository()
+
+		s.Schedule = memory.NewScheduleRepository()
+
+		s.Delivery = memory.NewDeliveryRepository()
+
+		s.Country = memory.NewCountryRepository()
+		s.Currency = memory.NewCurrencyRepository()
+
+		inventories := memory.NewInventoryRepository()
+		s.Inventory = inventories
+
+		s.Reservation = memory.NewReservationRepository(inventories)
+
+		s.Movement = memory.NewMovementRepository(inventories)
+
+		s.Alert = memory.NewAlertRepository()
+
+		s.Transfer = memory.NewTransferRepository(inventories)
+
+		s.Count = memory.NewCountRepository(inventories)
+
+		s.Lot = memory.NewLotRepository(inventories)
+		s.Serial = memory.NewSerialRepository(inventories)
+		s.Location = memory.NewLocationRepository(inventories)
+		s.Unit = memory.NewUnitRepository()
+		s.Price = memory.NewPriceRepository(inventories)
+		s.Rate = memory.NewRateRepository()
+		s.Calendar = memory.NewCalendarRepository()
+		s.Slot = memory.NewSlotRepository()
+
 		return
 	}
 }
@@ -99,6 +169,25 @@ func WithPostgresStore(schema, dataSourceName string) Configuration {
 
 		s.Inventory = postgres.NewInventoryRepository(s.postgres.Client)
 
+		s.Reservation = postgres.NewReservationRepository(s.postgres.Client)
+
+		s.Movement = postgres.NewMovementRepository(s.postgres.Client)
+
+		s.Alert = postgres.NewAlertRepository(s.postgres.Client)
+
+		s.Transfer = postgres.NewTransferRepository(s.postgres.Client)
+
+		s.Count = postgres.NewCountRepository(s.postgres.Client)
+
+		s.Lot = postgres.NewLotRepository(s.postgres.Client)
+		s.Serial = postgres.NewSerialRepository(s.postgres.Client)
+		s.Location = postgres.NewLocationRepository(s.postgres.Client)
+		s.Unit = postgres.NewUnitRepository(s.postgres.Client)
+		s.Price = postgres.NewPriceRepository(s.postgres.Client)
+		s.Rate = postgres.NewRateRepository(s.postgres.Client)
+		s.Calendar = postgres.NewCalendarRepository(s.postgres.Client)
+		s.Slot = postgres.NewSlotRepository(s.postgres.Client)
+
 		return
 	}
 }
@@ -0,0 +1,123 @@
+package warehouse
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"warehouse-service/internal/domain/alert"
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/pkg/storage"
+)
+
+// alertReopenWindow is how long after resolving an alert a new crossing
+// of the same threshold reopens it instead of raising another one.
+const alertReopenWindow = time.Hour
+
+func (s *Service) ListAlerts(ctx context.Context, filter alert.Filter) (res []alert.Response, err error) {
+	alertData, err := s.alertRepository.Select(ctx, filter)
+	if err != nil {
+		return
+	}
+	res = alert.ParseFromEntities(alertData)
+
+	return
+}
+
+func (s *Service) AcknowledgeAlert(ctx context.Context, id string) (res alert.Response, err error) {
+	if err = s.alertRepository.Acknowledge(ctx, id); err != nil {
+		return
+	}
+
+	alertData, err := s.alertRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	res = alert.ParseFromEntity(alertData)
+
+	return
+}
+
+// EvaluateAlerts checks the thresholds of every inventory moved since the given
+// moment, raising an alert for a crossed one and resolving it once cleared.
+// Checking an inventory twice changes nothing, so the windows may overlap.
+// A saved threshold is checked when it is saved, see evaluateInventory.
+func (s *Service) EvaluateAlerts(ctx context.Context, since time.Time) (count int, err error) {
+	inventoryIDs, err := s.movementRepository.SelectChanged(ctx, since)
+	if err != nil {
+		return
+	}
+
+	reopenSince := time.Now().Add(-alertReopenWindow)
+	for _, id := range inventoryIDs {
+		inventoryData, err := s.inventoryRepository.Get(ctx, id)
+		if err == storage.ErrorNotFound {
+			continue
+		}
+		if err != nil {
+			return count, err
+		}
+
+		if err = s.evaluateAlerts(ctx, inventoryData, reopenSince); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return
+}
+
+// evaluateInventory checks the thresholds of the inventory right away, a saved
+// threshold may be crossed without the quantity moving.
+func (s *Service) evaluateInventory(ctx context.Context, id string) (err error) {
+	inventoryData, err := s.inventoryRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+
+	return s.evaluateAlerts(ctx, inventoryData, time.Now().Add(-alertReopenWindow))
+}
+
+func (s *Service) evaluateAlerts(ctx context.Context, data inventory.Entity, reopenSince time.Time) (err error) {
+	quantity, err := strconv.Atoi(*data.Quantity)
+	if err != nil {
+		return
+	}
+
+	checks := []struct {
+		kind      string
+		threshold *string
+		crossed   func(quantity, threshold int) bool
+	}{
+		{alert.KindLowStock, data.QuantityMin, func(quantity, threshold int) bool { return quantity < threshold }},
+		{alert.KindOverstock, data.QuantityMax, func(quantity, threshold int) bool { return quantity > threshold }},
+	}
+
+	for _, check := range checks {
+		// a missing or zero threshold is not configured
+		threshold := 0
+		if check.threshold != nil {
+			if threshold, err = strconv.Atoi(*check.threshold); err != nil {
+				return
+			}
+		}
+
+		if threshold <= 0 || !check.crossed(quantity, threshold) {
+			err = s.alertRepository.Resolve(ctx, data.ID, check.kind, quantity)
+		} else {
+			err = s.alertRepository.Raise(ctx, alert.Entity{
+				InventoryID: data.ID,
+				StoreID:     data.StoreID,
+				ProductID:   data.ProductID,
+				Kind:        check.kind,
+				Quantity:    quantity,
+				Threshold:   threshold,
+			}, reopenSince)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
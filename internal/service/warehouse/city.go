@@ -0,0 +1,115 @@
+package warehouse
+
+import (
+	"context"
+
+	"warehouse-service/internal/domain/city"
+	"warehouse-service/pkg/storage"
+)
+
+// ListCities lists the cities of the country, or every city when the id is blank.
+func (s *Service) ListCities(ctx context.Context, countryID string) (res []*city.Response, err error) {
+	data, err := s.cityRepository.Select(ctx)
+	if err != nil {
+		return
+	}
+
+	res = make([]*city.Response, 0, len(data))
+	for i := range data {
+		if countryID == "" || data[i].CountryID == countryID {
+			res = append(res, city.ParseFromEntity(&data[i]))
+		}
+	}
+
+	return
+}
+
+func (s *Service) AddCity(ctx context.Context, req city.Request) (res *city.Response, err error) {
+	if err = s.checkCountry(ctx, req.CountryID, city.ErrorUnknownCountry); err != nil {
+		return
+	}
+
+	data := city.Entity{
+		CountryID: req.CountryID,
+		Name:      &req.Name,
+		GeoCenter: &req.GeoCenter,
+	}
+
+	// a blank zone takes the one of the country
+	if req.TimeZone != "" {
+		data.TimeZone = &req.TimeZone
+	}
+
+	if data.ID, err = s.cityRepository.Create(ctx, data); err != nil {
+		return
+	}
+	res = city.ParseFromEntity(&data)
+
+	return
+}
+
+func (s *Service) GetCity(ctx context.Context, id string) (res *city.Response, err error) {
+	data, err := s.cityRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+
+	if data == nil {
+		return nil, storage.ErrorNotFound
+	}
+	res = city.ParseFromEntity(data)
+
+	return
+}
+
+// UpdateCity keeps the time zone stored when the request leaves it blank.
+func (s *Service) UpdateCity(ctx context.Context, id string, req city.Request) (err error) {
+	if _, err = s.GetCity(ctx, id); err != nil {
+		return
+	}
+
+	if err = s.checkCountry(ctx, req.CountryID, city.ErrorUnknownCountry); err != nil {
+		return
+	}
+
+	data := city.Entity{
+		CountryID: req.CountryID,
+		Name:      &req.Name,
+		GeoCenter: &req.GeoCenter,
+	}
+
+	if req.TimeZone != "" {
+		data.TimeZone = &req.TimeZone
+	}
+
+	return s.cityRepository.Update(ctx, id, data)
+}
+
+// DeleteCity refuses to delete a city that still has stores.
+func (s *Service) DeleteCity(ctx context.Context, id string) (err error) {
+	if _, err = s.GetCity(ctx, id); err != nil {
+		return
+	}
+
+	stores, err := s.storeRepository.Select(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, object := range stores {
+		if object.CityID == id {
+			return city.ErrorInUse
+		}
+	}
+
+	return s.cityRepository.Delete(ctx, id)
+}
+
+// checkCountry returns the error given when there is no country with the id.
+func (s *Service) checkCountry(ctx context.Context, id string, unknown error) (err error) {
+	if _, err = s.countryRepository.Get(ctx, id); err == storage.ErrorNotFound {
+		err = unknown
+	}
+
+	return
+}
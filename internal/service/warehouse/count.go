@@ -0,0 +1,88 @@
+package warehouse
+
+import (
+	"context"
+
+	"warehouse-service/internal/domain/count"
+	"warehouse-service/internal/domain/movement"
+)
+
+func (s *Service) ListCounts(ctx context.Context, filter count.Filter) (res []count.Response, err error) {
+	countData, err := s.countRepository.Select(ctx, filter)
+	if err != nil {
+		return
+	}
+	res = count.ParseFromEntities(countData)
+
+	return
+}
+
+// AddCount opens a session and snapshots the quantities it is going to count.
+func (s *Service) AddCount(ctx context.Context, req count.Request) (res count.Response, err error) {
+	if _, err = s.storeRepository.Get(ctx, req.StoreID); err != nil {
+		return
+	}
+
+	data := count.Entity{
+		StoreID: req.StoreID,
+	}
+
+	id, err := s.countRepository.Create(ctx, data, req.ProductIDs)
+	if err != nil {
+		return
+	}
+
+	return s.GetCount(ctx, id)
+}
+
+func (s *Service) GetCount(ctx context.Context, id string) (res count.Response, err error) {
+	countData, err := s.countRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	res = count.ParseFromEntity(countData)
+
+	return
+}
+
+func (s *Service) RecordCount(ctx context.Context, id string, req count.EntryRequest) (res count.Response, err error) {
+	entry := count.Entry{
+		ProductID: req.ProductID,
+		Counter:   req.Counter,
+		Quantity:  *req.Quantity,
+	}
+
+	if err = s.countRepository.Record(ctx, id, entry); err != nil {
+		return
+	}
+
+	return s.GetCount(ctx, id)
+}
+
+func (s *Service) GetCountVariance(ctx context.Context, id string) (res count.VarianceResponse, err error) {
+	countData, err := s.countRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	res = count.ParseVarianceFromEntity(countData)
+
+	return
+}
+
+// ApproveCount posts the variances as adjustments and closes the session,
+// items nobody has counted keep their quantity.
+func (s *Service) ApproveCount(ctx context.Context, id string, req movement.Request) (res count.VarianceResponse, err error) {
+	if err = s.countRepository.Approve(ctx, id, newMovement(req, movement.ReasonAdjustment)); err != nil {
+		return
+	}
+
+	return s.GetCountVariance(ctx, id)
+}
+
+func (s *Service) CancelCount(ctx context.Context, id string) (res count.Response, err error) {
+	if err = s.countRepository.Cancel(ctx, id); err != nil {
+		return
+	}
+
+	return s.GetCount(ctx, id)
+}
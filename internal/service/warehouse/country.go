@@ -0,0 +1,83 @@
+package warehouse
+
+import (
+	"context"
+
+	"warehouse-service/internal/domain/country"
+)
+
+func (s *Service) ListCountries(ctx context.Context) (res []*country.Response, err error) {
+	data, err := s.countryRepository.Select(ctx)
+	if err != nil {
+		return
+	}
+	res = country.ParseFromEntities(data)
+
+	return
+}
+
+func (s *Service) AddCountry(ctx context.Context, req country.Request) (res *country.Response, err error) {
+	data := country.Entity{
+		Name:     &req.Name,
+		TimeZone: &req.TimeZone,
+	}
+
+	if data.ID, err = s.countryRepository.Create(ctx, data); err != nil {
+		return
+	}
+	res = country.ParseFromEntity(&data)
+
+	return
+}
+
+func (s *Service) GetCountry(ctx context.Context, id string) (res *country.Response, err error) {
+	data, err := s.countryRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	res = country.ParseFromEntity(&data)
+
+	return
+}
+
+func (s *Service) UpdateCountry(ctx context.Context, id string, req country.Request) (err error) {
+	if _, err = s.countryRepository.Get(ctx, id); err != nil {
+		return
+	}
+
+	data := country.Entity{
+		Name:     &req.Name,
+		TimeZone: &req.TimeZone,
+	}
+
+	return s.countryRepository.Update(ctx, id, data)
+}
+
+// DeleteCountry refuses to delete a country that still has cities or a currency.
+func (s *Service) DeleteCountry(ctx context.Context, id string) (err error) {
+	if _, err = s.countryRepository.Get(ctx, id); err != nil {
+		return
+	}
+
+	cities, err := s.cityRepository.Select(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, object := range cities {
+		if object.CountryID == id {
+			return country.ErrorInUse
+		}
+	}
+
+	currencyData, err := s.currencyRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+
+	if currencyData != nil {
+		return country.ErrorInUse
+	}
+
+	return s.countryRepository.Delete(ctx, id)
+}
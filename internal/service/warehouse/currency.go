@@ -0,0 +1,166 @@
+package warehouse
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+
+	"warehouse-service/internal/domain/currency"
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/pkg/money"
+	"warehouse-service/pkg/storage"
+)
+
+func (s *Service) ListCurrencies(ctx context.Context) (res []*currency.Response, err error) {
+	data, err := s.currencyRepository.Select(ctx)
+	if err != nil {
+		return
+	}
+
+	res = make([]*currency.Response, 0, len(data))
+	for i := range data {
+		res = append(res, currency.ParseFromEntity(&data[i]))
+	}
+
+	return
+}
+
+func (s *Service) AddCurrency(ctx context.Context, req currency.Request) (res *currency.Response, err error) {
+	if err = s.checkCountry(ctx, req.CountryID, currency.ErrorUnknownCountry); err != nil {
+		return
+	}
+
+	data := currency.Entity{
+		CountryID: req.CountryID,
+		Sign:      &req.Sign,
+		Decimals:  &req.Decimals,
+		Prefix:    req.Prefix,
+	}
+
+	if req.Code != "" {
+		data.Code = &req.Code
+	}
+
+	if data.ID, err = s.currencyRepository.Create(ctx, data); err != nil {
+		return
+	}
+	res = currency.ParseFromEntity(&data)
+
+	return
+}
+
+func (s *Service) GetCurrency(ctx context.Context, id string) (res *currency.Response, err error) {
+	data, err := s.currencyRepository.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	if data == nil {
+		return nil, storage.ErrorNotFound
+	}
+	res = currency.ParseFromEntity(data)
+
+	return
+}
+
+// UpdateCurrency keeps the code stored when the request leaves it blank.
+func (s *Service) UpdateCurrency(ctx context.Context, id string, req currency.Request) (err error) {
+	current, err := s.currencyRepository.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	if current == nil {
+		return storage.ErrorNotFound
+	}
+
+	if err = s.checkCountry(ctx, req.CountryID, currency.ErrorUnknownCountry); err != nil {
+		return
+	}
+
+	data := currency.Entity{
+		CountryID: req.CountryID,
+		Sign:      &req.Sign,
+		Decimals:  &req.Decimals,
+		Prefix:    req.Prefix,
+	}
+
+	if req.Code != "" {
+		data.Code = &req.Code
+	}
+
+	return s.currencyRepository.Update(ctx, current.CountryID, data)
+}
+
+func (s *Service) DeleteCurrency(ctx context.Context, id string) (err error) {
+	if _, err = s.GetCurrency(ctx, id); err != nil {
+		return
+	}
+
+	return s.currencyRepository.Delete(ctx, id)
+}
+
+// storeCurrency returns the currency of the country of the store, nil when it has none.
+func (s *Service) storeCurrency(ctx context.Context, storeID string) (dest *currency.Entity, err error) {
+	storeData, err := s.storeRepository.Get(ctx, storeID)
+	if err != nil {
+		return
+	}
+
+	cityData, err := s.cityRepository.Get(ctx, storeData.CityID)
+	if err != nil || cityData == nil {
+		return
+	}
+
+	return s.currencyRepository.Get(ctx, cityData.CountryID)
+}
+
+// pricing returns how the prices of the store are shown: converted into the currency
+// with the code when it is set, in the currency of the store otherwise. It is not ok
+// for a store without a currency read in its own, its prices are shown as stored.
+func (s *Service) pricing(ctx context.Context, storeID, code string) (ex exchange, ok bool, err error) {
+	source, err := s.storeCurrency(ctx, storeID)
+	if err != nil {
+		return
+	}
+
+	if code != "" {
+		ex, err = s.exchangeFrom(ctx, source, code)
+		return ex, err == nil, err
+	}
+
+	if source == nil {
+		return
+	}
+
+	ex.target, err = source.Money()
+	return ex, err == nil, err
+}
+
+// displayInventory writes the price of the response for display in the currency of the store.
+func (s *Service) displayInventory(ctx context.Context, res *inventory.Response, locale money.Locale) (err error) {
+	ex, ok, err := s.pricing(ctx, res.StoreID, "")
+	if err != nil || !ok {
+		return
+	}
+
+	return s.priceInventory(res, ex, locale)
+}
+
+// priceInventory converts the prices of the response when the exchange has a rate
+// and writes the price for display the way the locale does.
+func (s *Service) priceInventory(res *inventory.Response, ex exchange, locale money.Locale) (err error) {
+	if ex.rate != nil {
+		if err = s.convertInventory(res, *ex.rate, ex.target.Decimals); err != nil {
+			return
+		}
+	}
+
+	amount, err := decimal.NewFromString(res.Price)
+	if err != nil {
+		return
+	}
+	res.PriceDisplay = money.Format(amount, ex.target, locale)
+
+	return
+}
@@ -0,0 +1,182 @@
+package warehouse
+
+import (
+	"context"
+	"encoding/json"
+
+	"warehouse-service/internal/domain/delivery"
+	"warehouse-service/pkg/geo"
+)
+
+// GetDelivery returns the delivery of the store, an inactive one without periods
+// or areas when none was set.
+func (s *Service) GetDelivery(ctx context.Context, storeID string) (res *delivery.Response, err error) {
+	if _, err = s.storeRepository.Get(ctx, storeID); err != nil {
+		return
+	}
+
+	data, err := s.deliveryRepository.Get(ctx, storeID)
+	if err != nil {
+		return
+	}
+
+	if res = delivery.ParseFromEntity(data); res == nil {
+		res = &delivery.Response{
+			Periods: make([]delivery.Period, 0),
+			Areas:   make([]delivery.Polygon, 0),
+		}
+	}
+
+	return
+}
+
+// SaveDelivery replaces the periods and the areas of the delivery of the store, creating
+// it when none was set. Zero slot settings and fees left out keep the ones stored, or
+// take the defaults on creation, while fees given as null remove the stored ones.
+func (s *Service) SaveDelivery(ctx context.Context, storeID string, req delivery.Request) (res *delivery.Response, err error) {
+	if _, err = s.storeRepository.Get(ctx, storeID); err != nil {
+		return
+	}
+
+	data := delivery.Entity{
+		StoreID:      storeID,
+		IsActive:     req.IsActive,
+		SlotMinutes:  req.SlotMinutes,
+		SlotCapacity: req.SlotCapacity,
+	}
+
+	periods := req.Periods
+	if periods == nil {
+		periods = make([]delivery.Period, 0)
+	}
+
+	if data.Periods, err = json.Marshal(periods); err != nil {
+		return
+	}
+
+	areas := req.Areas
+	if areas == nil {
+		areas = make([]delivery.Polygon, 0)
+	}
+
+	if data.Areas, err = json.Marshal(areas); err != nil {
+		return
+	}
+
+	if req.Fees != nil {
+		if data.Fees, err = json.Marshal(req.Fees); err != nil {
+			return
+		}
+	}
+
+	current, err := s.deliveryRepository.Get(ctx, storeID)
+	if err != nil {
+		return
+	}
+
+	if req.ClearFees && current != nil {
+		data.Fees = make([]byte, 0)
+	}
+
+	if current == nil {
+		if data.SlotMinutes == 0 {
+			data.SlotMinutes = delivery.DefaultSlotMinutes
+		}
+
+		if data.SlotCapacity == 0 {
+			data.SlotCapacity = delivery.DefaultSlotCapacity
+		}
+
+		_, err = s.deliveryRepository.Create(ctx, data)
+	} else {
+		err = s.deliveryRepository.Update(ctx, storeID, &data)
+	}
+
+	if err != nil {
+		return
+	}
+
+	return s.GetDelivery(ctx, storeID)
+}
+
+// CheckDelivery tells whether the store delivers to the point, a store with no
+// active delivery delivers nowhere.
+func (s *Service) CheckDelivery(ctx context.Context, storeID string, point geo.Point) (res delivery.CheckResponse, err error) {
+	if _, err = s.storeRepository.Get(ctx, storeID); err != nil {
+		return
+	}
+
+	res = delivery.CheckResponse{
+		StoreID:   storeID,
+		Latitude:  point.Lat,
+		Longitude: point.Lng,
+	}
+
+	data, err := s.deliveryRepository.Get(ctx, storeID)
+	if err != nil {
+		return
+	}
+	res.Delivers = delivers(delivery.ParseFromEntity(data), point)
+
+	return
+}
+
+// delivers tells whether the delivery is active and covers the point.
+func delivers(data *delivery.Response, point geo.Point) bool {
+	return data != nil && data.IsActive && delivery.Covers(data.Areas, point)
+}
+
+// QuoteDelivery prices the delivery of a basket from the store to the destination by
+// the fee rules of the store, in its currency.
+func (s *Service) QuoteDelivery(ctx context.Context, storeID string, req delivery.QuoteRequest) (res delivery.QuoteResponse, err error) {
+	storeData, err := s.storeRepository.Get(ctx, storeID)
+	if err != nil {
+		return
+	}
+
+	deliveryData, err := s.activeDelivery(ctx, storeID)
+	if err != nil {
+		return
+	}
+
+	destination := req.Destination()
+	if !delivers(deliveryData, destination) {
+		return res, delivery.ErrorUnavailable
+	}
+
+	if deliveryData.Fees == nil {
+		return res, delivery.ErrorNoFees
+	}
+
+	// a store without coordinates only fails the fees charging by distance
+	var origin *geo.Point
+	if point, ok := storeData.Point(); ok {
+		origin = &point
+	}
+
+	source, err := s.storeCurrency(ctx, storeID)
+	if err != nil {
+		return
+	}
+
+	// amounts of a store without a currency keep two decimals
+	decimals := int32(2)
+	if source != nil && source.Decimals != nil {
+		target, err := source.Money()
+		if err != nil {
+			return res, err
+		}
+		decimals = target.Decimals
+	}
+
+	if res, err = deliveryData.Fees.Quote(deliveryData.Areas, origin, destination, *req.BasketTotal, decimals); err != nil {
+		return
+	}
+	res.StoreID = storeID
+
+	if source != nil && source.Code != nil {
+		res.Currency = *source.Code
+	}
+
+	return
+}
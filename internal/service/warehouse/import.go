@@ -0,0 +1,209 @@
+package warehouse
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"sort"
+	"time"
+
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/pkg/storage"
+)
+
+// importBatchSize is the number of rows written to the repository at once.
+const importBatchSize = 1000
+
+// importRow remembers the line of the entity, so a failed row can be reported against it.
+type importRow struct {
+	line int
+	data inventory.Entity
+}
+
+// ImportInventory streams the CSV rows, validates them and upserts the valid ones in batches.
+// Only a broken header or a failing read stops the import, every other problem is
+// reported against the line that caused it.
+func (s *Service) ImportInventory(ctx context.Context, src io.Reader, req movement.Request) (res inventory.ImportResponse, err error) {
+	res.Errors = make([]inventory.ImportError, 0)
+
+	reader := csv.NewReader(src)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	record, err := reader.Read()
+	if err == io.EOF {
+		err = inventory.ErrorInvalidHeader
+	}
+	if err != nil {
+		return
+	}
+
+	header, err := inventory.ParseHeader(record)
+	if err != nil {
+		return
+	}
+
+	change := newMovement(req, movement.ReasonAdjustment)
+	stores := make(map[string]error)
+	batch := make([]importRow, 0, importBatchSize)
+
+	for {
+		record, err = reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			parseErr, ok := err.(*csv.ParseError)
+			if !ok {
+				return
+			}
+			res.Reject(parseErr.StartLine, parseErr.Err)
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		row, err := inventory.ParseFromRecord(header, record)
+		if err != nil {
+			res.Reject(line, err)
+			continue
+		}
+
+		if err = s.checkImportStore(ctx, stores, row.StoreID); err != nil {
+			if err != storage.ErrorNotFound {
+				return res, err
+			}
+			res.Reject(line, errors.New("store_id: store does not exist"))
+			continue
+		}
+
+		batch = append(batch, importRow{line: line, data: inventory.Entity{
+			StoreID:        row.StoreID,
+			ProductID:      row.ProductID,
+			Quantity:       &row.Quantity,
+			QuantityMin:    row.QuantityMin,
+			QuantityMax:    row.QuantityMax,
+			Price:          &row.Price,
+			PriceSpecial:   row.PriceSpecial,
+			PricePrevious:  row.PricePrevious,
+			IsAvailable:    row.IsAvailable,
+			AllowBackorder: row.AllowBackorder,
+		}})
+
+		if len(batch) == importBatchSize {
+			if err = s.flushImport(ctx, batch, change, &res); err != nil {
+				return res, err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if err = s.flushImport(ctx, batch, change, &res); err != nil {
+		return
+	}
+
+	// rows retried one by one are rejected after the lines parsed later
+	sort.SliceStable(res.Errors, func(i, j int) bool {
+		return res.Errors[i].Line < res.Errors[j].Line
+	})
+
+	return res, nil
+}
+
+// flushImport writes the batch, when the write fails the rows are written one by one,
+// so only the lines that cannot be written are rejected. Only checking the thresholds
+// of the written rows fails the import.
+func (s *Service) flushImport(ctx context.Context, batch []importRow, change movement.Entity, res *inventory.ImportResponse) (err error) {
+	if len(batch) == 0 {
+		return
+	}
+
+	// a file may list the same product twice, the last line wins
+	positions := make(map[string]int, len(batch))
+	data := make([]inventory.Entity, 0, len(batch))
+	lines := make([][]int, 0, len(batch))
+	for _, row := range batch {
+		key := row.data.StoreID + "/" + row.data.ProductID
+		if i, ok := positions[key]; ok {
+			data[i] = row.data
+			lines[i] = append(lines[i], row.line)
+			continue
+		}
+		positions[key] = len(data)
+		data = append(data, row.data)
+		lines = append(lines, []int{row.line})
+	}
+
+	if err := s.inventoryRepository.UpsertBatch(ctx, data, change); err == nil {
+		res.Accepted += len(batch)
+		return s.evaluateImport(ctx, data)
+	}
+
+	written := make([]inventory.Entity, 0, len(data))
+	for i, object := range data {
+		if err := s.inventoryRepository.UpsertBatch(ctx, []inventory.Entity{object}, change); err != nil {
+			for _, line := range lines[i] {
+				res.Reject(line, err)
+			}
+			continue
+		}
+		res.Accepted += len(lines[i])
+		written = append(written, object)
+	}
+
+	return s.evaluateImport(ctx, written)
+}
+
+// evaluateImport checks the thresholds of the rows that set one, the way a
+// single update does. The inventories are looked up once per store.
+func (s *Service) evaluateImport(ctx context.Context, data []inventory.Entity) (err error) {
+	products := make(map[string]map[string]bool)
+	for _, object := range data {
+		if object.QuantityMin == nil && object.QuantityMax == nil {
+			continue
+		}
+
+		if products[object.StoreID] == nil {
+			products[object.StoreID] = make(map[string]bool)
+		}
+		products[object.StoreID][object.ProductID] = true
+	}
+
+	reopenSince := time.Now().Add(-alertReopenWindow)
+	for storeID, productIDs := range products {
+		var inventories []inventory.Entity
+
+		err = s.inventoryRepository.Each(ctx, inventory.Filter{StoreID: storeID}, func(object inventory.Entity) error {
+			if productIDs[object.ProductID] {
+				inventories = append(inventories, object)
+			}
+			return nil
+		})
+		if err != nil {
+			return
+		}
+
+		for _, object := range inventories {
+			if err = s.evaluateAlerts(ctx, object, reopenSince); err != nil {
+				return
+			}
+		}
+	}
+
+	return
+}
+
+// checkImportStore looks every store up once per import.
+func (s *Service) checkImportStore(ctx context.Context, stores map[string]error, id string) error {
+	err, ok := stores[id]
+	if !ok {
+		_, err = s.storeRepository.Get(ctx, id)
+		if err != nil && err != storage.ErrorNotFound {
+			return err
+		}
+		stores[id] = err
+	}
+	return err
+}
@@ -2,55 +2,254 @@ package warehouse
 
 import (
 	"context"
+	"strconv"
 	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/unit"
+	"warehouse-service/pkg/money"
 )
 
-func (s *Service) ListInventory(ctx context.Context) (res []inventory.Response, err error) {
+// ListInventory shows the quantities and the prices the way the view asks. An
+// inventory of a product without the unit of the view is shown in its base unit,
+// the unit of each response tells which one it is in.
+func (s *Service) ListInventory(ctx context.Context, view inventory.View) (res []inventory.Response, err error) {
 	inventorData, err := s.inventoryRepository.Select(ctx)
 	if err != nil {
 		return
 	}
 	res = inventory.ParseFromEntities(inventorData)
 
+	if err = s.overlayPrices(ctx, res); err != nil {
+		return
+	}
+
+	// the stores are looked up once however many of their inventories are listed
+	type storePricing struct {
+		ex exchange
+		ok bool
+	}
+	stores := make(map[string]storePricing)
+	units := make(map[string]unit.Entity)
+
+	for i := range res {
+		pricing, found := stores[res[i].StoreID]
+		if !found {
+			if pricing.ex, pricing.ok, err = s.pricing(ctx, res[i].StoreID, view.Currency); err != nil {
+				return nil, err
+			}
+			stores[res[i].StoreID] = pricing
+		}
+
+		if pricing.ok {
+			if err = s.priceInventory(&res[i], pricing.ex, view.Locale); err != nil {
+				return nil, err
+			}
+		}
+
+		unitData, found := units[res[i].ProductID]
+		if !found {
+			if unitData, err = s.unitsOf(ctx, res[i].ProductID); err != nil {
+				return nil, err
+			}
+			units[res[i].ProductID] = unitData
+		}
+
+		code := view.Unit
+		if !unitData.Has(code) {
+			code = ""
+		}
+
+		if err = presentIn(&res[i], unitData, code); err != nil {
+			return nil, err
+		}
+	}
+
 	return
 }
 
+// ExportInventory passes the matching inventories to fn one at a time, in the order of store and product.
+func (s *Service) ExportInventory(ctx context.Context, filter inventory.Filter, fn func(res inventory.Response) error) (err error) {
+	return s.inventoryRepository.Each(ctx, filter, func(data inventory.Entity) error {
+		return fn(inventory.ParseFromEntity(data))
+	})
+}
+
 func (s *Service) AddInventory(ctx context.Context, req inventory.Request) (res inventory.Response, err error) {
+	if err = s.normalizeInventory(ctx, req.ProductID, &req); err != nil {
+		return
+	}
+
 	data := inventory.Entity{
-		StoreID:   req.StoreID,
-		ProductID: req.ProductID,
-		Quantity:  &req.Quantity,
-		Price:     &req.Price,
+		StoreID:        req.StoreID,
+		ProductID:      req.ProductID,
+		Quantity:       &req.Quantity,
+		Price:          &req.Price,
+		AllowBackorder: req.AllowBackorder,
 	}
 
-	data.ID, err = s.inventoryRepository.Create(ctx, data)
+	data.ID, err = s.inventoryRepository.Create(ctx, data, newMovement(req.Request, movement.ReasonReceipt))
 	if err != nil {
 		return
 	}
 	res = inventory.ParseFromEntity(data)
 
+	if err = s.displayInventory(ctx, &res, money.Locale{}); err != nil {
+		return
+	}
+	err = s.presentInventory(ctx, &res, req.Unit)
+
 	return
 }
 
-func (s *Service) GetInventory(ctx context.Context, id string) (res inventory.Response, err error) {
+// UpsertInventory syncs the stock of a product in a store without knowing the inventory ID.
+func (s *Service) UpsertInventory(ctx context.Context, req inventory.Request) (res inventory.Response, err error) {
+	if _, err = s.storeRepository.Get(ctx, req.StoreID); err != nil {
+		return
+	}
+
+	if err = s.normalizeInventory(ctx, req.ProductID, &req); err != nil {
+		return
+	}
+
+	data := inventory.Entity{
+		StoreID:        req.StoreID,
+		ProductID:      req.ProductID,
+		Quantity:       &req.Quantity,
+		QuantityMin:    req.QuantityMin,
+		QuantityMax:    req.QuantityMax,
+		Price:          &req.Price,
+		PriceSpecial:   req.PriceSpecial,
+		PricePrevious:  req.PricePrevious,
+		IsAvailable:    req.IsAvailable,
+		AllowBackorder: req.AllowBackorder,
+	}
+
+	id, err := s.inventoryRepository.Upsert(ctx, data, newMovement(req.Request, movement.ReasonAdjustment))
+	if err != nil {
+		return
+	}
+
+	if err = s.recordPrice(ctx, id, req.Price, req.PriceSpecial); err != nil {
+		return
+	}
+
+	if req.QuantityMin != nil || req.QuantityMax != nil {
+		if err = s.evaluateInventory(ctx, id); err != nil {
+			return
+		}
+	}
+
+	return s.GetInventory(ctx, id, inventory.View{Unit: req.Unit})
+}
+
+// GetInventory shows the quantities and the prices the way the view asks.
+func (s *Service) GetInventory(ctx context.Context, id string, view inventory.View) (res inventory.Response, err error) {
 	inventoryData, err := s.inventoryRepository.Get(ctx, id)
 	if err != nil {
 		return
 	}
 	res = inventory.ParseFromEntity(inventoryData)
+
+	if err = s.overlayPrice(ctx, &res); err != nil {
+		return
+	}
+
+	ex, ok, err := s.pricing(ctx, res.StoreID, view.Currency)
+	if err != nil {
+		return
+	}
+
+	if ok {
+		if err = s.priceInventory(&res, ex, view.Locale); err != nil {
+			return
+		}
+	}
+
+	err = s.presentInventory(ctx, &res, view.Unit)
 	return
 }
 
 func (s *Service) UpdateInventory(ctx context.Context, id string, req inventory.Request) (err error) {
+	if req.Unit != "" {
+		inventoryData, err := s.inventoryRepository.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = s.normalizeInventory(ctx, inventoryData.ProductID, &req); err != nil {
+			return err
+		}
+	}
+
 	data := inventory.Entity{
-		Quantity:      &req.Quantity,
-		QuantityMin:   req.QuantityMin,
-		QuantityMax:   req.QuantityMax,
-		Price:         &req.Price,
-		PriceSpecial:  req.PriceSpecial,
-		PricePrevious: req.PricePrevious,
-	}
-	return s.inventoryRepository.Update(ctx, id, data)
+		Quantity:       &req.Quantity,
+		QuantityMin:    req.QuantityMin,
+		QuantityMax:    req.QuantityMax,
+		Price:          &req.Price,
+		PriceSpecial:   req.PriceSpecial,
+		PricePrevious:  req.PricePrevious,
+		AllowBackorder: req.AllowBackorder,
+	}
+
+	if err = s.inventoryRepository.Update(ctx, id, data, newMovement(req.Request, movement.ReasonAdjustment)); err != nil {
+		return
+	}
+
+	if err = s.recordPrice(ctx, id, req.Price, req.PriceSpecial); err != nil {
+		return
+	}
+
+	if req.QuantityMin != nil || req.QuantityMax != nil {
+		err = s.evaluateInventory(ctx, id)
+	}
+
+	return
+}
+
+func (s *Service) AdjustInventory(ctx context.Context, id string, req inventory.AdjustRequest) (res inventory.Response, err error) {
+	delta := int(req.Delta.IntPart())
+	if req.Unit != "" {
+		inventoryData, err := s.inventoryRepository.Get(ctx, id)
+		if err != nil {
+			return res, err
+		}
+
+		unitData, err := s.unitsOf(ctx, inventoryData.ProductID)
+		if err != nil {
+			return res, err
+		}
+
+		if delta, err = unitData.ToBase(req.Delta, req.Unit); err != nil {
+			return res, err
+		}
+	}
+
+	var balance int
+	if req.BinID != "" {
+		balance, err = s.locationRepository.Adjust(ctx, id, req.BinID, delta, newMovement(req.Request, req.Reason))
+	} else {
+		balance, err = s.inventoryRepository.Adjust(ctx, id, delta, newMovement(req.Request, req.Reason))
+	}
+	if err != nil {
+		return
+	}
+
+	inventoryData, err := s.inventoryRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+
+	// report the balance produced by this adjustment, not by a later one
+	quantity := strconv.Itoa(balance)
+	inventoryData.Quantity = &quantity
+	res = inventory.ParseFromEntity(inventoryData)
+
+	if err = s.displayInventory(ctx, &res, money.Locale{}); err != nil {
+		return
+	}
+	err = s.presentInventory(ctx, &res, req.Unit)
+
+	return
 }
 
 func (s *Service) DeleteInventory(ctx context.Context, id string) (err error) {
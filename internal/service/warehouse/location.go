@@ -0,0 +1,104 @@
+package warehouse
+
+import (
+	"context"
+	"strconv"
+
+	"warehouse-service/internal/domain/location"
+	"warehouse-service/pkg/storage"
+)
+
+func (s *Service) ListLocations(ctx context.Context, storeID string) (res []location.Response, err error) {
+	if _, err = s.storeRepository.Get(ctx, storeID); err != nil {
+		return
+	}
+
+	data, err := s.locationRepository.Select(ctx, storeID)
+	if err != nil {
+		return
+	}
+	res = location.ParseFromEntities(data)
+
+	return
+}
+
+func (s *Service) AddLocation(ctx context.Context, storeID string, req location.Request) (res location.Response, err error) {
+	if _, err = s.storeRepository.Get(ctx, storeID); err != nil {
+		return
+	}
+
+	data := location.Entity{
+		StoreID:  storeID,
+		ParentID: req.ParentID,
+		Kind:     req.Kind,
+		Code:     req.Code,
+		Name:     req.Name,
+	}
+
+	data.ID, err = s.locationRepository.Create(ctx, data)
+	if err != nil {
+		return
+	}
+	res = location.ParseFromEntity(data)
+
+	return
+}
+
+func (s *Service) DeleteLocation(ctx context.Context, storeID, id string) (err error) {
+	data, err := s.locationRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+
+	if data.StoreID != storeID {
+		return storage.ErrorNotFound
+	}
+
+	return s.locationRepository.Delete(ctx, id)
+}
+
+// ListBins splits the inventory quantity over the bins it is put away in.
+func (s *Service) ListBins(ctx context.Context, inventoryID string) (res location.BinsResponse, err error) {
+	inventoryData, err := s.inventoryRepository.Get(ctx, inventoryID)
+	if err != nil {
+		return
+	}
+
+	quantity := 0
+	if inventoryData.Quantity != nil {
+		if quantity, err = strconv.Atoi(*inventoryData.Quantity); err != nil {
+			return
+		}
+	}
+
+	data, err := s.locationRepository.SelectStock(ctx, location.Filter{InventoryID: inventoryID})
+	if err != nil {
+		return
+	}
+	res = location.ParseBinsFromEntities(quantity, data)
+
+	return
+}
+
+func (s *Service) MoveStock(ctx context.Context, inventoryID string, req location.MoveRequest) (res location.BinsResponse, err error) {
+	if err = s.locationRepository.Move(ctx, inventoryID, req.FromBinID, req.ToBinID, req.Quantity); err != nil {
+		return
+	}
+
+	return s.ListBins(ctx, inventoryID)
+}
+
+// LocateProduct tells the bins of the store the product is put away in.
+func (s *Service) LocateProduct(ctx context.Context, storeID, productID string) (res []location.StockResponse, err error) {
+	if _, err = s.storeRepository.Get(ctx, storeID); err != nil {
+		return
+	}
+
+	data, err := s.locationRepository.SelectStock(ctx, location.Filter{StoreID: storeID, ProductID: productID})
+	if err != nil {
+		return
+	}
+	res = location.ParseStockFromEntities(data)
+
+	return
+}
@@ -0,0 +1,82 @@
+package warehouse
+
+import (
+	"context"
+	"time"
+
+	"warehouse-service/internal/domain/lot"
+	"warehouse-service/internal/domain/movement"
+)
+
+// ListLots shows the lots of the inventory first-expiry-first-out with the part held by reservations.
+func (s *Service) ListLots(ctx context.Context, inventoryID string) (res []lot.Response, err error) {
+	if _, err = s.inventoryRepository.Get(ctx, inventoryID); err != nil {
+		return
+	}
+
+	lotData, err := s.lotRepository.Select(ctx, inventoryID)
+	if err != nil {
+		return
+	}
+
+	reservationData, err := s.reservationRepository.Select(ctx, inventoryID)
+	if err != nil {
+		return
+	}
+
+	held, now := 0, time.Now()
+	for _, data := range reservationData {
+		if data.IsActive(now) {
+			held += data.Quantity
+		}
+	}
+	res = lot.ParseFromEntities(lotData, held)
+
+	return
+}
+
+func (s *Service) ReceiveLot(ctx context.Context, inventoryID string, req lot.Request) (res []lot.Response, err error) {
+	expiresAt, err := time.Parse(lot.DateLayout, req.ExpiresAt)
+	if err != nil {
+		return
+	}
+
+	data := lot.Entity{
+		InventoryID: inventoryID,
+		Number:      req.Number,
+		ExpiresAt:   expiresAt,
+		Quantity:    req.Quantity,
+	}
+
+	if _, err = s.lotRepository.Receive(ctx, data, newMovement(req.Request, movement.ReasonReceipt)); err != nil {
+		return
+	}
+
+	return s.ListLots(ctx, inventoryID)
+}
+
+// ListExpiringLots lists the lots of the store expiring within the given number of days, today
+// in the time zone of the store included. The expiry dates carry no zone, so today is compared
+// with them as a date too.
+func (s *Service) ListExpiringLots(ctx context.Context, storeID string, days int) (res []lot.ExpiringResponse, err error) {
+	storeData, err := s.storeRepository.Get(ctx, storeID)
+	if err != nil {
+		return
+	}
+
+	location, err := s.storeTimeZone(ctx, storeData)
+	if err != nil {
+		return
+	}
+
+	year, month, day := time.Now().In(location).Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	lotData, err := s.lotRepository.SelectExpiring(ctx, storeID, today.AddDate(0, 0, days+1))
+	if err != nil {
+		return
+	}
+	res = lot.ParseExpiringFromEntities(lotData, today)
+
+	return
+}
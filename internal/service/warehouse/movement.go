@@ -0,0 +1,35 @@
+package warehouse
+
+import (
+	"context"
+
+	"warehouse-service/internal/domain/movement"
+)
+
+func (s *Service) ListMovements(ctx context.Context, inventoryID string) (res []movement.Response, err error) {
+	if _, err = s.inventoryRepository.Get(ctx, inventoryID); err != nil {
+		return
+	}
+
+	movementData, err := s.movementRepository.Select(ctx, inventoryID)
+	if err != nil {
+		return
+	}
+	res = movement.ParseFromEntities(movementData)
+
+	return
+}
+
+// newMovement fills the journal entry from the request, the reason defaults
+// to the one of the operation when the caller has not specified it.
+func newMovement(req movement.Request, reason string) movement.Entity {
+	if req.Reason != "" {
+		reason = req.Reason
+	}
+
+	return movement.Entity{
+		Reason:        reason,
+		Actor:         req.Actor,
+		CorrelationID: req.CorrelationID,
+	}
+}
@@ -0,0 +1,262 @@
+package warehouse
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/price"
+	"warehouse-service/pkg/storage"
+)
+
+// noPriceSpecial is how the inventory stores the absence of a special price.
+const noPriceSpecial = "0"
+
+// ListPrices shows the price history of the inventory with the status of each price now.
+func (s *Service) ListPrices(ctx context.Context, inventoryID string) (res []price.Response, err error) {
+	if _, err = s.inventoryRepository.Get(ctx, inventoryID); err != nil {
+		return
+	}
+
+	data, err := s.priceRepository.Select(ctx, inventoryID)
+	if err != nil {
+		return
+	}
+	res = price.ParseFromEntities(data, time.Now())
+
+	return
+}
+
+// SchedulePrice adds a price to the history of the inventory. The first one
+// records the stored price as valid since the inventory was created, so the
+// history covers the time around the new price too.
+func (s *Service) SchedulePrice(ctx context.Context, inventoryID string, req price.Request) (res []price.Response, err error) {
+	inventoryData, err := s.inventoryRepository.Get(ctx, inventoryID)
+	if err != nil {
+		return
+	}
+
+	history, err := s.priceRepository.Select(ctx, inventoryID)
+	if err != nil {
+		return
+	}
+
+	if len(history) == 0 && inventoryData.Price != nil {
+		base := price.Entity{
+			InventoryID:  inventoryID,
+			Price:        *inventoryData.Price,
+			PriceSpecial: inventoryData.PriceSpecial,
+			ValidFrom:    inventoryData.CreatedAt,
+		}
+
+		if _, err = s.priceRepository.Create(ctx, base); err != nil {
+			return
+		}
+	}
+
+	now := time.Now()
+	data := price.Entity{
+		InventoryID:  inventoryID,
+		Price:        req.Price,
+		PriceSpecial: req.PriceSpecial,
+		ValidFrom:    now,
+		ValidTo:      req.ValidTo,
+	}
+	if req.ValidFrom != nil && req.ValidFrom.After(now) {
+		data.ValidFrom = *req.ValidFrom
+	}
+
+	if _, err = s.priceRepository.Create(ctx, data); err != nil {
+		return
+	}
+
+	if _, err = s.applyPrice(ctx, inventoryID, now); err != nil {
+		return
+	}
+
+	return s.ListPrices(ctx, inventoryID)
+}
+
+func (s *Service) CancelPrice(ctx context.Context, inventoryID, id string) (err error) {
+	data, err := s.priceRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+
+	if data.InventoryID != inventoryID {
+		return storage.ErrorNotFound
+	}
+
+	return s.priceRepository.Delete(ctx, id)
+}
+
+// ApplyPrices writes the price in effect into the inventories with a price
+// starting or ending since the time, so caches and readers of the stored row
+// pick up the change. It returns the number of inventories changed.
+func (s *Service) ApplyPrices(ctx context.Context, since time.Time) (applied int, err error) {
+	now := time.Now()
+
+	ids, err := s.priceRepository.SelectBoundaries(ctx, since, now)
+	if err != nil {
+		return
+	}
+
+	for _, id := range ids {
+		changed, err := s.applyPrice(ctx, id, now)
+		if err == storage.ErrorNotFound {
+			continue
+		}
+		if err != nil {
+			return applied, err
+		}
+
+		if changed {
+			applied++
+		}
+	}
+
+	return
+}
+
+// applyPrice writes the price in effect at the time into the inventory, keeping
+// the replaced one as the previous price. It reports whether anything changed.
+func (s *Service) applyPrice(ctx context.Context, inventoryID string, at time.Time) (changed bool, err error) {
+	history, err := s.priceRepository.Select(ctx, inventoryID)
+	if err != nil {
+		return
+	}
+
+	effective, ok := price.Effective(history, at)
+	if !ok {
+		return
+	}
+
+	inventoryData, err := s.inventoryRepository.Get(ctx, inventoryID)
+	if err != nil {
+		return
+	}
+
+	special := noPriceSpecial
+	if effective.PriceSpecial != nil {
+		special = *effective.PriceSpecial
+	}
+
+	data := inventory.Entity{}
+	if inventoryData.Price == nil || !samePrice(*inventoryData.Price, effective.Price) {
+		data.Price = &effective.Price
+		data.PricePrevious = inventoryData.Price
+	}
+	if !samePrice(valueOr(inventoryData.PriceSpecial, noPriceSpecial), special) {
+		data.PriceSpecial = &special
+	}
+
+	if data.Price == nil && data.PriceSpecial == nil {
+		return
+	}
+
+	err = s.inventoryRepository.Update(ctx, inventoryID, data, movement.Entity{Reason: movement.ReasonAdjustment})
+	changed = err == nil
+
+	return
+}
+
+// overlayPrice shows the price in effect now, a boundary may have passed before the job wrote it.
+func (s *Service) overlayPrice(ctx context.Context, res *inventory.Response) (err error) {
+	history, err := s.priceRepository.Select(ctx, res.ID)
+	if err != nil {
+		return
+	}
+	overlayHistory(res, history, time.Now())
+
+	return
+}
+
+// overlayPrices does what overlayPrice does for every response with one read of the histories.
+func (s *Service) overlayPrices(ctx context.Context, res []inventory.Response) (err error) {
+	ids := make([]string, len(res))
+	for i := range res {
+		ids[i] = res[i].ID
+	}
+
+	data, err := s.priceRepository.SelectMany(ctx, ids)
+	if err != nil {
+		return
+	}
+
+	histories := make(map[string][]price.Entity)
+	for _, object := range data {
+		histories[object.InventoryID] = append(histories[object.InventoryID], object)
+	}
+
+	now := time.Now()
+	for i := range res {
+		overlayHistory(&res[i], histories[res[i].ID], now)
+	}
+
+	return
+}
+
+// overlayHistory replaces the stored price of the response with the one of the history in effect at the time.
+func overlayHistory(res *inventory.Response, history []price.Entity, at time.Time) {
+	effective, ok := price.Effective(history, at)
+	if !ok || samePrice(res.Price, effective.Price) && samePrice(valueOr(res.PriceSpecial, noPriceSpecial), valueOr(effective.PriceSpecial, noPriceSpecial)) {
+		return
+	}
+
+	if !samePrice(res.Price, effective.Price) {
+		previous := res.Price
+		res.PricePrevious = &previous
+	}
+	res.Price = effective.Price
+	res.PriceSpecial = effective.PriceSpecial
+}
+
+// recordPrice keeps a direct write of the price in the history of an inventory
+// that has one, from now on and until the next price. An inventory without a
+// history only has its stored price, a CSV import is recorded by UpsertBatch.
+func (s *Service) recordPrice(ctx context.Context, inventoryID, value string, special *string) (err error) {
+	history, err := s.priceRepository.Select(ctx, inventoryID)
+	if err != nil || len(history) == 0 {
+		return
+	}
+
+	now := time.Now()
+	effective, ok := price.Effective(history, now)
+	if ok && samePrice(effective.Price, value) && samePrice(valueOr(effective.PriceSpecial, noPriceSpecial), valueOr(special, noPriceSpecial)) {
+		return
+	}
+
+	data := price.Entity{
+		InventoryID:  inventoryID,
+		Price:        value,
+		PriceSpecial: special,
+		ValidFrom:    now,
+	}
+	_, err = s.priceRepository.Create(ctx, data)
+
+	return
+}
+
+func samePrice(a, b string) bool {
+	x, err := decimal.NewFromString(a)
+	if err != nil {
+		return a == b
+	}
+
+	y, err := decimal.NewFromString(b)
+	if err != nil {
+		return false
+	}
+
+	return x.Equal(y)
+}
+
+func valueOr(value *string, fallback string) string {
+	if value == nil {
+		return fallback
+	}
+	return *value
+}
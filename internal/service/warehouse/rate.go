@@ -0,0 +1,147 @@
+package warehouse
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"warehouse-service/internal/domain/currency"
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/rate"
+	"warehouse-service/pkg/money"
+	"warehouse-service/pkg/storage"
+)
+
+// exchange shows the prices of a store in the target currency, converting them
+// at the rate when it is set.
+type exchange struct {
+	rate   *rate.Entity
+	target money.Currency
+}
+
+func (s *Service) ListRates(ctx context.Context, filter rate.Filter) (res []rate.Response, err error) {
+	data, err := s.rateRepository.Select(ctx, filter)
+	if err != nil {
+		return
+	}
+	res = rate.ParseFromEntities(data)
+
+	return
+}
+
+// LoadRates saves the rates of the file and returns how many it holds, a rate
+// already loaded for the same pair and start is replaced.
+func (s *Service) LoadRates(ctx context.Context, path string) (count int, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	data, err := rate.Read(file)
+	if err != nil {
+		return
+	}
+
+	if err = s.rateRepository.Save(ctx, data); err != nil {
+		return
+	}
+
+	return len(data), nil
+}
+
+// exchangeFrom returns the conversion from the source currency into the currency
+// with the code, at the rate in effect now.
+func (s *Service) exchangeFrom(ctx context.Context, source *currency.Entity, code string) (res exchange, err error) {
+	code = strings.ToUpper(code)
+	if !currency.IsCode(code) {
+		return res, rate.ErrorUnknownCurrency
+	}
+
+	target, err := s.currencyRepository.GetByCode(ctx, code)
+	if err != nil {
+		return
+	}
+
+	if target == nil {
+		return res, rate.ErrorUnknownCurrency
+	}
+
+	if res.target, err = target.Money(); err != nil {
+		return
+	}
+
+	if source == nil || source.Code == nil {
+		return res, rate.ErrorNoCurrency
+	}
+
+	data, err := s.rateBetween(ctx, *source.Code, code, time.Now())
+	if err != nil {
+		return
+	}
+	res.rate = &data
+
+	return
+}
+
+// rateBetween returns the rate of the pair in effect at the time, the opposite
+// pair is inverted when only that one is published.
+func (s *Service) rateBetween(ctx context.Context, base, quote string, at time.Time) (dest rate.Entity, err error) {
+	if base == quote {
+		return rate.Identity(base, at), nil
+	}
+
+	dest, err = s.rateRepository.Get(ctx, base, quote, at)
+	if err != storage.ErrorNotFound {
+		return
+	}
+
+	dest, err = s.rateRepository.Get(ctx, quote, base, at)
+	if err == storage.ErrorNotFound {
+		return dest, rate.ErrorMissing
+	}
+	if err != nil {
+		return
+	}
+
+	return dest.Invert(), nil
+}
+
+// convertInventory shows the prices of the response in the quote currency of the rate.
+// The pointers are replaced, not written through, as they may be shared with the stored entity.
+func (s *Service) convertInventory(res *inventory.Response, data rate.Entity, decimals int32) (err error) {
+	convert := func(value string) (string, error) {
+		amount, err := decimal.NewFromString(value)
+		if err != nil {
+			return "", err
+		}
+
+		return data.Convert(amount, decimals).StringFixed(decimals), nil
+	}
+
+	if res.Price, err = convert(res.Price); err != nil {
+		return
+	}
+
+	if res.PriceSpecial != nil {
+		value, err := convert(*res.PriceSpecial)
+		if err != nil {
+			return err
+		}
+		res.PriceSpecial = &value
+	}
+
+	if res.PricePrevious != nil {
+		value, err := convert(*res.PricePrevious)
+		if err != nil {
+			return err
+		}
+		res.PricePrevious = &value
+	}
+	res.Currency = data.Quote
+
+	return
+}
@@ -0,0 +1,96 @@
+package warehouse
+
+import (
+	"context"
+	"time"
+
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/reservation"
+	"warehouse-service/pkg/storage"
+)
+
+func (s *Service) ListReservations(ctx context.Context, inventoryID string) (res []reservation.Response, err error) {
+	if _, err = s.inventoryRepository.Get(ctx, inventoryID); err != nil {
+		return
+	}
+
+	reservationData, err := s.reservationRepository.Select(ctx, inventoryID)
+	if err != nil {
+		return
+	}
+	res = reservation.ParseFromEntities(reservationData)
+
+	return
+}
+
+func (s *Service) AddReservation(ctx context.Context, inventoryID string, req reservation.Request) (res reservation.Response, err error) {
+	data := reservation.Entity{
+		InventoryID: inventoryID,
+		Quantity:    req.Quantity,
+		Status:      reservation.StatusHeld,
+		ExpiresAt:   time.Now().Add(time.Duration(req.TTL) * time.Second),
+	}
+
+	data.ID, err = s.reservationRepository.Create(ctx, data)
+	if err != nil {
+		return
+	}
+	data.CreatedAt = time.Now()
+	res = reservation.ParseFromEntity(data)
+
+	return
+}
+
+func (s *Service) GetReservation(ctx context.Context, inventoryID, id string) (res reservation.Response, err error) {
+	reservationData, err := s.getReservation(ctx, inventoryID, id)
+	if err != nil {
+		return
+	}
+	res = reservation.ParseFromEntity(reservationData)
+
+	return
+}
+
+func (s *Service) CommitReservation(ctx context.Context, inventoryID, id string, req movement.Request) (res reservation.Response, err error) {
+	if _, err = s.getReservation(ctx, inventoryID, id); err != nil {
+		return
+	}
+
+	if err = s.reservationRepository.Commit(ctx, id, newMovement(req, movement.ReasonSale)); err != nil {
+		return
+	}
+
+	return s.GetReservation(ctx, inventoryID, id)
+}
+
+func (s *Service) ReleaseReservation(ctx context.Context, inventoryID, id string) (res reservation.Response, err error) {
+	if _, err = s.getReservation(ctx, inventoryID, id); err != nil {
+		return
+	}
+
+	if err = s.reservationRepository.Release(ctx, id); err != nil {
+		return
+	}
+
+	return s.GetReservation(ctx, inventoryID, id)
+}
+
+// ExpireReservations marks overdue holds as expired. Availability never counts
+// overdue holds, so this only keeps the stored status in line with reality.
+func (s *Service) ExpireReservations(ctx context.Context) (count int64, err error) {
+	return s.reservationRepository.Expire(ctx)
+}
+
+// getReservation makes sure the reservation belongs to the requested inventory.
+func (s *Service) getReservation(ctx context.Context, inventoryID, id string) (dest reservation.Entity, err error) {
+	dest, err = s.reservationRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+
+	if dest.InventoryID != inventoryID {
+		err = storage.ErrorNotFound
+	}
+
+	return
+}
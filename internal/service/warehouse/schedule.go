@@ -0,0 +1,291 @@
+package warehouse
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"warehouse-service/internal/domain/calendar"
+	"warehouse-service/internal/domain/city"
+	"warehouse-service/internal/domain/schedule"
+	"warehouse-service/internal/domain/store"
+)
+
+// defaultTimeZone is the zone the times of day of a store are read in when its city is unknown.
+const defaultTimeZone = "Asia/Almaty"
+
+func (s *Service) ListSeasons(ctx context.Context, storeID string) (res []calendar.SeasonResponse, err error) {
+	if _, err = s.storeRepository.Get(ctx, storeID); err != nil {
+		return
+	}
+
+	data, err := s.calendarRepository.SelectSeasons(ctx, storeID)
+	if err != nil {
+		return
+	}
+	res = calendar.ParseFromSeasons(data)
+
+	return
+}
+
+func (s *Service) AddSeason(ctx context.Context, storeID string, req calendar.SeasonRequest) (res calendar.SeasonResponse, err error) {
+	if _, err = s.storeRepository.Get(ctx, storeID); err != nil {
+		return
+	}
+
+	data := calendar.Season{
+		StoreID: storeID,
+		Name:    req.Name,
+	}
+
+	if data.ValidFrom, err = calendar.ParseDate(req.ValidFrom); err != nil {
+		return
+	}
+
+	if req.ValidTo != nil {
+		validTo, err := calendar.ParseDate(*req.ValidTo)
+		if err != nil {
+			return res, err
+		}
+		data.ValidTo = &validTo
+	}
+
+	periods := req.Periods
+	if periods == nil {
+		periods = make([]schedule.Period, 0)
+	}
+
+	if data.Periods, err = json.Marshal(periods); err != nil {
+		return
+	}
+
+	if data.ID, err = s.calendarRepository.CreateSeason(ctx, data); err != nil {
+		return
+	}
+	res = calendar.ParseFromSeason(data)
+
+	return
+}
+
+func (s *Service) DeleteSeason(ctx context.Context, storeID, id string) (err error) {
+	return s.calendarRepository.DeleteSeason(ctx, storeID, id)
+}
+
+// ListExceptions lists the exceptions of the store from one date through another.
+func (s *Service) ListExceptions(ctx context.Context, storeID string, from, to time.Time) (res []calendar.ExceptionResponse, err error) {
+	if _, err = s.storeRepository.Get(ctx, storeID); err != nil {
+		return
+	}
+
+	data, err := s.calendarRepository.SelectExceptions(ctx, storeID, from, to)
+	if err != nil {
+		return
+	}
+	res = calendar.ParseFromExceptions(data)
+
+	return
+}
+
+// SaveException sets the hours of the store on the date, replacing an exception already set.
+func (s *Service) SaveException(ctx context.Context, storeID string, date time.Time, req calendar.ExceptionRequest) (res calendar.ExceptionResponse, err error) {
+	if _, err = s.storeRepository.Get(ctx, storeID); err != nil {
+		return
+	}
+
+	data := calendar.Exception{
+		StoreID: storeID,
+		Date:    date,
+		Closed:  req.Closed,
+		Note:    req.Note,
+	}
+
+	hours := req.Hours
+	if hours == nil {
+		hours = make([]schedule.Hours, 0)
+	}
+
+	if data.Hours, err = json.Marshal(hours); err != nil {
+		return
+	}
+
+	if err = s.calendarRepository.SaveException(ctx, data); err != nil {
+		return
+	}
+	res = calendar.ParseFromException(data)
+
+	return
+}
+
+func (s *Service) DeleteException(ctx context.Context, storeID string, date time.Time) (err error) {
+	return s.calendarRepository.DeleteException(ctx, storeID, date)
+}
+
+// GetHours resolves the hours of the store for the days from the date on, today
+// in the time zone of the store when the date is nil.
+func (s *Service) GetHours(ctx context.Context, storeID string, from *time.Time, days int) (res []calendar.DayResponse, err error) {
+	storeData, err := s.storeRepository.Get(ctx, storeID)
+	if err != nil {
+		return
+	}
+
+	location, err := s.storeTimeZone(ctx, storeData)
+	if err != nil {
+		return
+	}
+
+	start := time.Now().In(location)
+	if from != nil {
+		start = *from
+	}
+	year, month, day := start.Date()
+	start = time.Date(year, month, day, 0, 0, 0, 0, location)
+
+	scheduleData, err := s.scheduleRepository.Get(ctx, storeID)
+	if err != nil {
+		return
+	}
+
+	calendarData, err := s.calendarOf(ctx, storeID, schedule.ParseFromEntity(scheduleData), start, start.AddDate(0, 0, days-1))
+	if err != nil {
+		return
+	}
+	res = calendar.ParseFromCalendar(calendarData, start, days)
+
+	return
+}
+
+// calendarOf gathers the weekly schedule, the seasons and the exceptions from one date through another.
+func (s *Service) calendarOf(ctx context.Context, storeID string, weekly *schedule.Response, from, to time.Time) (dest calendar.Calendar, err error) {
+	dest = weeklyCalendar(weekly)
+
+	if dest.Seasons, err = s.calendarRepository.SelectSeasons(ctx, storeID); err != nil {
+		return
+	}
+
+	dest.Exceptions, err = s.calendarRepository.SelectExceptions(ctx, storeID, from, to)
+
+	return
+}
+
+// storeTimeZone returns the time zone the times of day of the store are read in.
+func (s *Service) storeTimeZone(ctx context.Context, storeData store.Entity) (*time.Location, error) {
+	cityData, err := s.cityRepository.Get(ctx, storeData.CityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.timeZoneOf(ctx, cityData)
+}
+
+// timeZoneOf returns the time zone the schedules of the stores of the city are read in,
+// the zone of the city or else the one of its country.
+func (s *Service) timeZoneOf(ctx context.Context, cityData *city.Entity) (*time.Location, error) {
+	if cityData == nil {
+		return time.LoadLocation(defaultTimeZone)
+	}
+
+	if cityData.TimeZone != nil {
+		return time.LoadLocation(*cityData.TimeZone)
+	}
+
+	countryData, err := s.countryRepository.Get(ctx, cityData.CountryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if countryData.TimeZone == nil {
+		return time.LoadLocation(defaultTimeZone)
+	}
+
+	return time.LoadLocation(*countryData.TimeZone)
+}
+
+// weeklyCalendar starts a calendar from the weekly schedule, an inactive one tells no hours.
+func weeklyCalendar(weekly *schedule.Response) (dest calendar.Calendar) {
+	if weekly != nil && weekly.IsActive {
+		dest.Default = weekly.Periods
+		if dest.Default == nil {
+			dest.Default = make([]schedule.Period, 0)
+		}
+	}
+	return
+}
+
+// openingWindow is the span of dates the opening of a store at the time depends on,
+// in the location of the time.
+func openingWindow(at time.Time) (from, to time.Time) {
+	year, month, day := at.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, at.Location())
+
+	return today.AddDate(0, 0, -1), today.AddDate(0, 0, schedule.Horizon)
+}
+
+// setOpening tells by the calendar of the store whether it is open at the time, in the
+// location of the time. With nothing telling the hours they are left unknown.
+func setOpening(res *store.Response, calendarData calendar.Calendar, at time.Time) {
+	if calendarData.IsEmpty() {
+		return
+	}
+
+	status := schedule.Evaluate(calendarData.HoursOn, at)
+	res.OpenNow = &status.OpenNow
+	res.ClosesAt = status.ClosesAt
+	res.NextOpenAt = status.NextOpenAt
+}
+
+// GetSchedule returns the weekly schedule of the store, an inactive one without
+// periods when none was set.
+func (s *Service) GetSchedule(ctx context.Context, storeID string) (res *schedule.Response, err error) {
+	if _, err = s.storeRepository.Get(ctx, storeID); err != nil {
+		return
+	}
+
+	data, err := s.scheduleRepository.Get(ctx, storeID)
+	if err != nil {
+		return
+	}
+
+	if res = schedule.ParseFromEntity(data); res == nil {
+		res = &schedule.Response{Periods: make([]schedule.Period, 0)}
+	}
+
+	return
+}
+
+// SaveSchedule replaces the weekly schedule of the store, creating it when none was set.
+func (s *Service) SaveSchedule(ctx context.Context, storeID string, req schedule.Request) (res *schedule.Response, err error) {
+	if _, err = s.storeRepository.Get(ctx, storeID); err != nil {
+		return
+	}
+
+	data := schedule.Entity{
+		StoreID:  storeID,
+		IsActive: req.IsActive,
+	}
+
+	periods := req.Periods
+	if periods == nil {
+		periods = make([]schedule.Period, 0)
+	}
+
+	if data.Periods, err = json.Marshal(periods); err != nil {
+		return
+	}
+
+	current, err := s.scheduleRepository.Get(ctx, storeID)
+	if err != nil {
+		return
+	}
+
+	if current == nil {
+		_, err = s.scheduleRepository.Create(ctx, data)
+	} else {
+		err = s.scheduleRepository.Update(ctx, storeID, &data)
+	}
+
+	if err != nil {
+		return
+	}
+
+	return s.GetSchedule(ctx, storeID)
+}
@@ -0,0 +1,66 @@
+package warehouse
+
+import (
+	"context"
+
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/serial"
+)
+
+func (s *Service) ListSerials(ctx context.Context, inventoryID, status string) (res []serial.Response, err error) {
+	if _, err = s.inventoryRepository.Get(ctx, inventoryID); err != nil {
+		return
+	}
+
+	data, err := s.serialRepository.Select(ctx, inventoryID, status)
+	if err != nil {
+		return
+	}
+	res = serial.ParseFromEntities(data)
+
+	return
+}
+
+// AddSerials registers the units in stock and turns the inventory into a serialized one.
+func (s *Service) AddSerials(ctx context.Context, inventoryID string, req serial.Request) (res []serial.Response, err error) {
+	if _, err = s.serialRepository.Create(ctx, inventoryID, req.Numbers, newMovement(req.Request, movement.ReasonReceipt)); err != nil {
+		return
+	}
+
+	return s.ListSerials(ctx, inventoryID, "")
+}
+
+func (s *Service) SetSerialStatus(ctx context.Context, inventoryID, number string, req serial.StatusRequest) (res serial.Response, err error) {
+	reason := movement.ReasonAdjustment
+	if req.Status == serial.StatusSold {
+		reason = movement.ReasonSale
+	}
+
+	if _, err = s.serialRepository.SetStatus(ctx, inventoryID, number, req.Status, newMovement(req.Request, reason)); err != nil {
+		return
+	}
+
+	data, err := s.serialRepository.Select(ctx, inventoryID, "")
+	if err != nil {
+		return
+	}
+
+	for _, object := range data {
+		if object.Number == number {
+			res = serial.ParseFromEntity(object)
+		}
+	}
+
+	return
+}
+
+// FindSerials looks a number up in every store.
+func (s *Service) FindSerials(ctx context.Context, number string) (res []serial.Response, err error) {
+	data, err := s.serialRepository.SelectByNumber(ctx, number)
+	if err != nil {
+		return
+	}
+	res = serial.ParseFromEntities(data)
+
+	return
+}
@@ -1,13 +1,27 @@
 package warehouse
 
 import (
+	"warehouse-service/internal/domain/alert"
+	"warehouse-service/internal/domain/calendar"
 	"warehouse-service/internal/domain/city"
+	"warehouse-service/internal/domain/count"
 	"warehouse-service/internal/domain/country"
 	"warehouse-service/internal/domain/currency"
 	"warehouse-service/internal/domain/delivery"
 	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/location"
+	"warehouse-service/internal/domain/lot"
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/price"
+	"warehouse-service/internal/domain/rate"
+	"warehouse-service/internal/domain/reservation"
 	"warehouse-service/internal/domain/schedule"
+	"warehouse-service/internal/domain/serial"
+	"warehouse-service/internal/domain/slot"
 	"warehouse-service/internal/domain/store"
+	"warehouse-service/internal/domain/transfer"
+	"warehouse-service/internal/domain/unit"
+	"warehouse-service/pkg/geo"
 )
 
 // Configuration is an alias for a function that will take in a pointer to a Service and modify it
@@ -30,13 +44,44 @@ type Service struct {
 
 	inventoryRepository inventory.Repository
 	inventoryCache      inventory.Cache
+
+	reservationRepository reservation.Repository
+
+	movementRepository movement.Repository
+
+	alertRepository alert.Repository
+
+	transferRepository transfer.Repository
+
+	countRepository count.Repository
+
+	lotRepository lot.Repository
+
+	serialRepository serial.Repository
+
+	locationRepository location.Repository
+
+	unitRepository unit.Repository
+
+	priceRepository price.Repository
+
+	rateRepository rate.Repository
+
+	calendarRepository calendar.Repository
+
+	slotRepository slot.Repository
+
+	// storeIndex finds the stores near a point, IndexStores fills it
+	storeIndex *geo.Index
 }
 
 // New takes a variable amount of Configuration functions and returns a new Service
 // Each Configuration will be called in the order they are passed in
 func New(configs ...Configuration) (s *Service, err error) {
 	// Create the service
-	s = &Service{}
+	s = &Service{
+		storeIndex: geo.NewIndex(),
+	}
 
 	// Apply all Configurations passed in
 	for _, cfg := range configs {
@@ -85,3 +130,165 @@ func WithInventoryCache(inventoryCache inventory.Cache) Configuration {
 		return nil
 	}
 }
+
+func WithReservationRepository(reservationRepository reservation.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.reservationRepository = reservationRepository
+		return nil
+	}
+}
+
+func WithMovementRepository(movementRepository movement.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.movementRepository = movementRepository
+		return nil
+	}
+}
+
+func WithAlertRepository(alertRepository alert.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.alertRepository = alertRepository
+		return nil
+	}
+}
+
+func WithTransferRepository(transferRepository transfer.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.transferRepository = transferRepository
+		return nil
+	}
+}
+
+func WithCountRepository(countRepository count.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.countRepository = countRepository
+		return nil
+	}
+}
+
+func WithLotRepository(lotRepository lot.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.lotRepository = lotRepository
+		return nil
+	}
+}
+
+func WithSerialRepository(serialRepository serial.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.serialRepository = serialRepository
+		return nil
+	}
+}
+
+func WithLocationRepository(locationRepository location.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.locationRepository = locationRepository
+		return nil
+	}
+}
+
+func WithUnitRepository(unitRepository unit.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.unitRepository = unitRepository
+		return nil
+	}
+}
+
+func WithPriceRepository(priceRepository price.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.priceRepository = priceRepository
+		return nil
+	}
+}
+
+func WithCityRepository(cityRepository city.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.cityRepository = cityRepository
+		return nil
+	}
+}
+
+func WithCurrencyRepository(currencyRepository currency.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.currencyRepository = currencyRepository
+		return nil
+	}
+}
+
+func WithRateRepository(rateRepository rate.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.rateRepository = rateRepository
+		return nil
+	}
+}
+
+func WithScheduleRepository(scheduleRepository schedule.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.scheduleRepository = scheduleRepository
+		return nil
+	}
+}
+
+func WithDeliveryRepository(deliveryRepository delivery.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.deliveryRepository = deliveryRepository
+		return nil
+	}
+}
+
+func WithCalendarRepository(calendarRepository calendar.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.calendarRepository = calendarRepository
+		return nil
+	}
+}
+
+func WithCountryRepository(countryRepository country.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.countryRepository = countryRepository
+		return nil
+	}
+}
+
+func WithSlotRepository(slotRepository slot.Repository) Configuration {
+	// return a function that matches the Configuration alias,
+	// You need to return this so that the parent function can take in all the needed parameters
+	return func(s *Service) error {
+		s.slotRepository = slotRepository
+		return nil
+	}
+}
@@ -0,0 +1,132 @@
+package warehouse
+
+import (
+	"context"
+	"time"
+
+	"warehouse-service/internal/domain/delivery"
+	"warehouse-service/internal/domain/slot"
+)
+
+// ListSlots lists the slots of the store with places left over the days from today on,
+// in the time zone of the store. Slots already started are left out.
+func (s *Service) ListSlots(ctx context.Context, storeID string, days int) (res []slot.Response, err error) {
+	res = make([]slot.Response, 0)
+
+	storeData, err := s.storeRepository.Get(ctx, storeID)
+	if err != nil {
+		return
+	}
+
+	deliveryData, err := s.activeDelivery(ctx, storeID)
+	if err != nil || deliveryData == nil {
+		return
+	}
+
+	location, err := s.storeTimeZone(ctx, storeData)
+	if err != nil {
+		return
+	}
+	now := time.Now().In(location)
+
+	slots := slot.Generate(deliveryData.Periods, deliveryData.SlotMinutes, now, days)
+	if len(slots) == 0 {
+		return
+	}
+
+	counts, err := s.slotRepository.SelectBooked(ctx, storeID, slots[0].StartsAt, slots[len(slots)-1].EndsAt)
+	if err != nil {
+		return
+	}
+
+	booked := make(map[int64]int, len(counts))
+	for _, object := range counts {
+		booked[object.StartsAt.Unix()] = object.Booked
+	}
+
+	for _, object := range slots {
+		if !object.StartsAt.After(now) {
+			continue
+		}
+
+		available := deliveryData.SlotCapacity - booked[object.StartsAt.Unix()]
+		if available <= 0 {
+			continue
+		}
+
+		res = append(res, slot.Response{
+			StartsAt:  object.StartsAt,
+			EndsAt:    object.EndsAt,
+			Capacity:  deliveryData.SlotCapacity,
+			Booked:    booked[object.StartsAt.Unix()],
+			Available: available,
+		})
+	}
+
+	return
+}
+
+// BookSlot takes a place in the slot of the store starting at the time of the request.
+func (s *Service) BookSlot(ctx context.Context, storeID string, req slot.BookingRequest) (res slot.BookingResponse, err error) {
+	storeData, err := s.storeRepository.Get(ctx, storeID)
+	if err != nil {
+		return
+	}
+
+	deliveryData, err := s.activeDelivery(ctx, storeID)
+	if err != nil {
+		return
+	}
+
+	if deliveryData == nil || !req.StartsAt.After(time.Now()) {
+		return res, slot.ErrorUnknown
+	}
+
+	location, err := s.storeTimeZone(ctx, storeData)
+	if err != nil {
+		return
+	}
+	startsAt := req.StartsAt.In(location)
+
+	// a slot starting after midnight may come from a period of the day before
+	var found *slot.Slot
+	for _, object := range slot.Generate(deliveryData.Periods, deliveryData.SlotMinutes, startsAt.AddDate(0, 0, -1), 2) {
+		if object.StartsAt.Equal(startsAt) {
+			found = &object
+			break
+		}
+	}
+
+	if found == nil {
+		return res, slot.ErrorUnknown
+	}
+
+	data := slot.Booking{
+		StoreID:   storeID,
+		StartsAt:  found.StartsAt,
+		EndsAt:    found.EndsAt,
+		Reference: req.Reference,
+	}
+
+	if data.ID, err = s.slotRepository.Book(ctx, data, deliveryData.SlotCapacity); err != nil {
+		return
+	}
+	res = slot.ParseFromBooking(data)
+
+	return
+}
+
+func (s *Service) CancelBooking(ctx context.Context, storeID, id string) (err error) {
+	return s.slotRepository.Cancel(ctx, storeID, id)
+}
+
+// activeDelivery returns the delivery of the store, nil when it has none or it is inactive.
+func (s *Service) activeDelivery(ctx context.Context, storeID string) (res *delivery.Response, err error) {
+	data, err := s.deliveryRepository.Get(ctx, storeID)
+	if err != nil || data == nil || !data.IsActive {
+		return
+	}
+	res = delivery.ParseFromEntity(data)
+
+	return
+}
@@ -3,48 +3,48 @@ package warehouse
 import (
 	"context"
 	"encoding/json"
+	"math"
+	"time"
+	"warehouse-service/internal/domain/calendar"
 	"warehouse-service/internal/domain/city"
 	"warehouse-service/internal/domain/currency"
 	"warehouse-service/internal/domain/delivery"
+	"warehouse-service/internal/domain/rate"
 	"warehouse-service/internal/domain/schedule"
 	"warehouse-service/internal/domain/store"
+	"warehouse-service/pkg/geo"
 )
 
-func (s *Service) ListStores(ctx context.Context) (res []store.Response, err error) {
+// ListStores adds the exchange rate from the currency of each store into the currency with the code, when it is set.
+// A store with unknown hours matches neither open nor closed stores.
+// Filtered by a point it keeps the stores delivering there.
+func (s *Service) ListStores(ctx context.Context, filter store.Filter, currencyCode string) (res []store.Response, err error) {
 	storeData, err := s.storeRepository.Select(ctx)
 	if err != nil {
 		return
 	}
-	res = store.ParseFromEntities(storeData)
-
-	for i := 0; i < len(storeData); i++ {
-		cityData, err := s.cityRepository.Get(ctx, storeData[i].CityID)
-		if err != nil {
-			return nil, err
-		}
-		res[i].City = city.ParseFromEntity(cityData)
+	if res, err = s.describeStores(ctx, storeData, currencyCode); err != nil {
+		return
+	}
 
-		if cityData != nil {
-			currencyData, err := s.currencyRepository.Get(ctx, cityData.CountryID)
-			if err != nil {
-				return nil, err
+	if filter.OpenNow != nil {
+		filtered := make([]store.Response, 0, len(res))
+		for _, object := range res {
+			if object.OpenNow != nil && *object.OpenNow == *filter.OpenNow {
+				filtered = append(filtered, object)
 			}
-			res[i].Currency = currency.ParseFromEntity(currencyData)
-
 		}
+		res = filtered
+	}
 
-		scheduleData, err := s.scheduleRepository.Get(ctx, storeData[i].ID)
-		if err != nil {
-			return nil, err
-		}
-		res[i].Schedule = schedule.ParseFromEntity(scheduleData)
-
-		deliveryData, err := s.deliveryRepository.Get(ctx, storeData[i].ID)
-		if err != nil {
-			return nil, err
+	if filter.DeliversTo != nil {
+		filtered := make([]store.Response, 0, len(res))
+		for _, object := range res {
+			if delivers(object.Delivery, *filter.DeliversTo) {
+				filtered = append(filtered, object)
+			}
 		}
-		res[i].Delivery = delivery.ParseFromEntity(deliveryData)
-
+		res = filtered
 	}
 
 	return
@@ -58,6 +58,8 @@ func (s *Service) AddStore(ctx context.Context, req store.Request) (res store.Re
 		Address:    &req.Address,
 		Location:   &req.Location,
 		Rating:     &req.Rating,
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
 	}
 
 	data.ID, err = s.storeRepository.Create(ctx, data)
@@ -66,42 +68,148 @@ func (s *Service) AddStore(ctx context.Context, req store.Request) (res store.Re
 	}
 	res = store.ParseFromEntity(data)
 
+	if point, ok := data.Point(); ok {
+		s.storeIndex.Put(data.ID, point)
+	}
+
 	return
 
 }
 
-func (s *Service) GetStore(ctx context.Context, id string) (res store.Response, err error) {
+// GetStore adds the exchange rate from the currency of the store into the currency with the code, when it is set.
+func (s *Service) GetStore(ctx context.Context, id, currencyCode string) (res store.Response, err error) {
 	storeData, err := s.storeRepository.Get(ctx, id)
 	if err != nil {
 		return
 	}
-	res = store.ParseFromEntity(storeData)
+	described, err := s.describeStores(ctx, []store.Entity{storeData}, currencyCode)
+	if err != nil {
+		return
+	}
+	res = described[0]
+
+	return
+}
+
+// storePlace is what the stores of a city share.
+type storePlace struct {
+	city         *city.Response
+	currency     *currency.Response
+	exchangeRate *rate.Response
+	location     *time.Location
+}
 
-	cityData, err := s.cityRepository.Get(ctx, storeData.CityID)
+// placeOf reads the city of the stores with its currency and time zone.
+func (s *Service) placeOf(ctx context.Context, cityID, currencyCode string) (dest storePlace, err error) {
+	cityData, err := s.cityRepository.Get(ctx, cityID)
 	if err != nil {
 		return
 	}
-	res.City = city.ParseFromEntity(cityData)
+	dest.city = city.ParseFromEntity(cityData)
 
 	if cityData != nil {
 		currencyData, err := s.currencyRepository.Get(ctx, cityData.CountryID)
 		if err != nil {
-			return res, err
+			return dest, err
 		}
-		res.Currency = currency.ParseFromEntity(currencyData)
+		dest.currency = currency.ParseFromEntity(currencyData)
+
+		if currencyCode != "" {
+			ex, err := s.exchangeFrom(ctx, currencyData, currencyCode)
+			if err != nil {
+				return dest, err
+			}
+			exchangeRate := rate.ParseFromEntity(*ex.rate)
+			dest.exchangeRate = &exchangeRate
+		}
+	}
+
+	dest.location, err = s.timeZoneOf(ctx, cityData)
+
+	return
+}
+
+// describeStores parses the stores with their city, currency, hours and delivery. The
+// schedules, calendars and deliveries are read once for all the stores and the cities
+// once each, however many stores are described.
+func (s *Service) describeStores(ctx context.Context, storeData []store.Entity, currencyCode string) (res []store.Response, err error) {
+	res = store.ParseFromEntities(storeData)
+	if len(storeData) == 0 {
+		return
 	}
 
-	scheduleData, err := s.scheduleRepository.Get(ctx, storeData.ID)
+	ids := make([]string, len(storeData))
+	for i := range storeData {
+		ids[i] = storeData[i].ID
+	}
+
+	scheduleData, err := s.scheduleRepository.SelectByStores(ctx, ids)
 	if err != nil {
 		return
 	}
-	res.Schedule = schedule.ParseFromEntity(scheduleData)
+	schedules := make(map[string]*schedule.Entity, len(scheduleData))
+	for i := range scheduleData {
+		schedules[scheduleData[i].StoreID] = &scheduleData[i]
+	}
+
+	deliveryData, err := s.deliveryRepository.SelectByStores(ctx, ids)
+	if err != nil {
+		return
+	}
+	deliveries := make(map[string]*delivery.Entity, len(deliveryData))
+	for i := range deliveryData {
+		deliveries[deliveryData[i].StoreID] = &deliveryData[i]
+	}
+
+	seasonData, err := s.calendarRepository.SelectSeasonsByStores(ctx, ids)
+	if err != nil {
+		return
+	}
+	seasons := make(map[string][]calendar.Season)
+	for _, object := range seasonData {
+		seasons[object.StoreID] = append(seasons[object.StoreID], object)
+	}
 
-	deliveryData, err := s.deliveryRepository.Get(ctx, storeData.ID)
+	// the window of every time zone fits within a day of the one in UTC
+	now := time.Now()
+	first, last := openingWindow(now.UTC())
+
+	exceptionData, err := s.calendarRepository.SelectExceptionsByStores(ctx, ids, first.AddDate(0, 0, -1), last.AddDate(0, 0, 1))
 	if err != nil {
 		return
 	}
-	res.Delivery = delivery.ParseFromEntity(deliveryData)
+	exceptions := make(map[string][]calendar.Exception)
+	for _, object := range exceptionData {
+		exceptions[object.StoreID] = append(exceptions[object.StoreID], object)
+	}
+
+	places := make(map[string]storePlace)
+	for i := range storeData {
+		place, ok := places[storeData[i].CityID]
+		if !ok {
+			if place, err = s.placeOf(ctx, storeData[i].CityID, currencyCode); err != nil {
+				return nil, err
+			}
+			places[storeData[i].CityID] = place
+		}
+		res[i].City = place.city
+		res[i].Currency = place.currency
+		res[i].ExchangeRate = place.exchangeRate
+
+		at := now.In(place.location)
+		res[i].TimeZone = place.location.String()
+		res[i].UTCOffset = at.Format("-07:00")
+
+		res[i].Schedule = schedule.ParseFromEntity(schedules[storeData[i].ID])
+
+		from, to := openingWindow(at)
+		calendarData := weeklyCalendar(res[i].Schedule)
+		calendarData.Seasons = seasons[storeData[i].ID]
+		calendarData.Exceptions = calendar.Within(exceptions[storeData[i].ID], from, to)
+		setOpening(&res[i], calendarData, at)
+
+		res[i].Delivery = delivery.ParseFromEntity(deliveries[storeData[i].ID])
+	}
 
 	return
 }
@@ -109,22 +217,35 @@ func (s *Service) GetStore(ctx context.Context, id string) (res store.Response,
 func (s *Service) UpdateStore(ctx context.Context, id string, req store.Request) (err error) {
 	// Update store data by store ID
 	storeData := store.Entity{
-		CityID:   req.CityID,
-		Name:     &req.Name,
-		Location: &req.Location,
-		Rating:   &req.Rating,
-		Address:  &req.Address,
-		IsActive: &req.IsActive,
+		CityID:    req.CityID,
+		Name:      &req.Name,
+		Location:  &req.Location,
+		Rating:    &req.Rating,
+		Address:   &req.Address,
+		IsActive:  &req.IsActive,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
 	}
 	err = s.storeRepository.Update(ctx, id, storeData)
 	if err != nil {
 		return
 	}
 
-	cityData := city.Entity{
-		CountryID: req.City.CountryID,
-		Name:      &req.City.Name,
-		GeoCenter: &req.City.GeoCenter,
+	// coordinates left out keep the ones stored
+	if point, ok := storeData.Point(); ok {
+		s.storeIndex.Put(id, point)
+	}
+
+	// city fields left out keep the ones stored
+	cityData := city.Entity{CountryID: req.City.CountryID}
+	if req.City.Name != "" {
+		cityData.Name = &req.City.Name
+	}
+	if req.City.GeoCenter != "" {
+		cityData.GeoCenter = &req.City.GeoCenter
+	}
+	if req.City.TimeZone != "" {
+		cityData.TimeZone = &req.City.TimeZone
 	}
 
 	err = s.cityRepository.Update(ctx, storeData.CityID, cityData)
@@ -132,16 +253,38 @@ func (s *Service) UpdateStore(ctx context.Context, id string, req store.Request)
 		return
 	}
 
-	currencyData := currency.Entity{
-		ID:       req.Currency.ID,
-		Sign:     &req.Currency.Sign,
-		Decimals: &req.Currency.Decimals,
-		Prefix:   req.Currency.Prefix,
-	}
+	// a currency left out keeps the one stored, the prefix is only
+	// taken together with a currency field
+	if req.Currency != (currency.Response{}) {
+		countryID := cityData.CountryID
+		if countryID == "" {
+			cityStored, err := s.cityRepository.Get(ctx, storeData.CityID)
+			if err != nil {
+				return err
+			}
+			if cityStored != nil {
+				countryID = cityStored.CountryID
+			}
+		}
 
-	err = s.currencyRepository.Update(ctx, cityData.CountryID, currencyData)
-	if err != nil {
-		return
+		currencyData := currency.Entity{
+			ID:     req.Currency.ID,
+			Prefix: req.Currency.Prefix,
+		}
+		if req.Currency.Code != "" {
+			currencyData.Code = &req.Currency.Code
+		}
+		if req.Currency.Sign != "" {
+			currencyData.Sign = &req.Currency.Sign
+		}
+		if req.Currency.Decimals != "" {
+			currencyData.Decimals = &req.Currency.Decimals
+		}
+
+		err = s.currencyRepository.Update(ctx, countryID, currencyData)
+		if err != nil {
+			return
+		}
 	}
 
 	// get schedule data by store id
@@ -169,14 +312,14 @@ func (s *Service) UpdateStore(ctx context.Context, id string, req store.Request)
 		if err != nil {
 			return
 		}
-		return
-	}
-	scheduleData.Periods = periodsData
+	} else {
+		scheduleData.Periods = periodsData
 
-	// if exists update schedule data
-	err = s.scheduleRepository.Update(ctx, id, scheduleData)
-	if err != nil {
-		return
+		// if exists update schedule data
+		err = s.scheduleRepository.Update(ctx, id, scheduleData)
+		if err != nil {
+			return
+		}
 	}
 
 	// get delivery data by store id
@@ -196,24 +339,50 @@ func (s *Service) UpdateStore(ctx context.Context, id string, req store.Request)
 		return
 	}
 
+	// fees left out keep the ones stored
+	var feesData []byte
+	if req.Delivery.Fees != nil {
+		if feesData, err = json.Marshal(req.Delivery.Fees); err != nil {
+			return
+		}
+	}
+
 	// check existing of schedule
 	if deliveryData == nil {
 		// if not exists create schedule data
 		deliveryData := delivery.Entity{
-			StoreID:  id,
-			Periods:  periodsData,
-			Areas:    areasData,
-			IsActive: true,
+			StoreID:      id,
+			Periods:      periodsData,
+			Areas:        areasData,
+			IsActive:     true,
+			SlotMinutes:  req.Delivery.SlotMinutes,
+			SlotCapacity: req.Delivery.SlotCapacity,
+			Fees:         feesData,
 		}
 
-		deliveryData.ID, err = s.deliveryRepository.Create(ctx, deliveryData)
-		if err != nil {
-			return
+		if deliveryData.SlotMinutes == 0 {
+			deliveryData.SlotMinutes = delivery.DefaultSlotMinutes
+		}
+
+		if deliveryData.SlotCapacity == 0 {
+			deliveryData.SlotCapacity = delivery.DefaultSlotCapacity
 		}
+
+		deliveryData.ID, err = s.deliveryRepository.Create(ctx, deliveryData)
 		return
 	}
 	deliveryData.Periods = periodsData
 	deliveryData.Areas = areasData
+	deliveryData.SlotMinutes = req.Delivery.SlotMinutes
+	deliveryData.SlotCapacity = req.Delivery.SlotCapacity
+	if feesData != nil {
+		deliveryData.Fees = feesData
+	}
+
+	// fees given as null remove the stored ones
+	if req.Delivery.ClearFees {
+		deliveryData.Fees = make([]byte, 0)
+	}
 
 	// if exists update schedule data
 	err = s.deliveryRepository.Update(ctx, id, deliveryData)
@@ -226,5 +395,66 @@ func (s *Service) UpdateStore(ctx context.Context, id string, req store.Request)
 }
 
 func (s *Service) DeleteStore(ctx context.Context, id string) (err error) {
-	return s.storeRepository.Delete(ctx, id)
+	if err = s.storeRepository.Delete(ctx, id); err != nil {
+		return
+	}
+	s.storeIndex.Remove(id)
+
+	return
+}
+
+// IndexStores fills the index of the nearby search with the coordinates of every store.
+// The index follows the stores changed through the service, indexing again picks up
+// the ones changed by other instances.
+func (s *Service) IndexStores(ctx context.Context) (err error) {
+	data, err := s.storeRepository.Select(ctx)
+	if err != nil {
+		return
+	}
+
+	points := make(map[string]geo.Point, len(data))
+	for _, object := range data {
+		if point, ok := object.Point(); ok {
+			points[object.ID] = point
+		}
+	}
+	s.storeIndex.Reset(points)
+
+	return
+}
+
+// NearbyStores lists the stores at most radius kilometres from the point, nearest first.
+func (s *Service) NearbyStores(ctx context.Context, point geo.Point, radius float64) (res []store.Response, err error) {
+	hits := s.storeIndex.Within(point, radius)
+
+	ids := make([]string, len(hits))
+	for i, object := range hits {
+		ids[i] = object.ID
+	}
+
+	storeData, err := s.storeRepository.SelectByIDs(ctx, ids)
+	if err != nil {
+		return
+	}
+
+	stores := make(map[string]store.Entity, len(storeData))
+	for _, data := range storeData {
+		stores[data.ID] = data
+	}
+
+	res = make([]store.Response, 0, len(hits))
+	for _, object := range hits {
+		data, ok := stores[object.ID]
+		if !ok {
+			// deleted by another instance since the last indexing
+			continue
+		}
+
+		distance := math.Round(object.Distance*100) / 100
+		item := store.ParseFromEntity(data)
+		item.DistanceKm = &distance
+		res = append(res, item)
+	}
+
+	return
 }
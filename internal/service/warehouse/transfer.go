@@ -0,0 +1,107 @@
+package warehouse
+
+import (
+	"context"
+	"sort"
+
+	"warehouse-service/internal/domain/movement"
+	"warehouse-service/internal/domain/transfer"
+)
+
+func (s *Service) ListTransfers(ctx context.Context, filter transfer.Filter) (res []transfer.Response, err error) {
+	transferData, err := s.transferRepository.Select(ctx, filter)
+	if err != nil {
+		return
+	}
+	res = transfer.ParseFromEntities(transferData)
+
+	return
+}
+
+// AddTransfer only moves stock between the stores of one merchant.
+func (s *Service) AddTransfer(ctx context.Context, req transfer.Request) (res transfer.Response, err error) {
+	source, err := s.storeRepository.Get(ctx, req.SourceStoreID)
+	if err != nil {
+		return
+	}
+
+	destination, err := s.storeRepository.Get(ctx, req.DestinationStoreID)
+	if err != nil {
+		return
+	}
+
+	if source.MerchantID != destination.MerchantID {
+		err = transfer.ErrorMerchantMismatch
+		return
+	}
+
+	data := transfer.Entity{
+		SourceStoreID:      req.SourceStoreID,
+		DestinationStoreID: req.DestinationStoreID,
+		Lines:              parseLines(req.Lines),
+	}
+
+	id, err := s.transferRepository.Create(ctx, data)
+	if err != nil {
+		return
+	}
+
+	return s.GetTransfer(ctx, id)
+}
+
+func (s *Service) GetTransfer(ctx context.Context, id string) (res transfer.Response, err error) {
+	transferData, err := s.transferRepository.Get(ctx, id)
+	if err != nil {
+		return
+	}
+	res = transfer.ParseFromEntity(transferData)
+
+	return
+}
+
+// ShipTransfer takes the goods out of the source, they stay on the transfer until received.
+func (s *Service) ShipTransfer(ctx context.Context, id string, req movement.Request) (res transfer.Response, err error) {
+	if err = s.transferRepository.Ship(ctx, id, newMovement(req, movement.ReasonTransfer)); err != nil {
+		return
+	}
+
+	return s.GetTransfer(ctx, id)
+}
+
+// ReceiveTransfer puts the arrived goods into the destination, without lines
+// everything still in transit is received.
+func (s *Service) ReceiveTransfer(ctx context.Context, id string, req transfer.ReceiveRequest) (res transfer.Response, err error) {
+	lines := parseLines(req.Lines)
+	if len(lines) == 0 {
+		transferData, err := s.transferRepository.Get(ctx, id)
+		if err != nil {
+			return res, err
+		}
+
+		for _, line := range transferData.Lines {
+			if quantity := transferData.InTransit(line); quantity > 0 {
+				lines = append(lines, transfer.Line{ProductID: line.ProductID, Quantity: quantity})
+			}
+		}
+	}
+
+	if err = s.transferRepository.Receive(ctx, id, lines, newMovement(req.Request, movement.ReasonTransfer)); err != nil {
+		return
+	}
+
+	return s.GetTransfer(ctx, id)
+}
+
+// parseLines orders the lines by product, so the inventories are always locked in the same order.
+func parseLines(req []transfer.LineRequest) (lines []transfer.Line) {
+	lines = make([]transfer.Line, 0, len(req))
+	for _, line := range req {
+		lines = append(lines, transfer.Line{ProductID: line.ProductID, Quantity: line.Quantity})
+	}
+
+	sort.Slice(lines, func(i, j int) bool {
+		return lines[i].ProductID < lines[j].ProductID
+	})
+
+	return
+}
@@ -0,0 +1,162 @@
+package warehouse
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+
+	"warehouse-service/internal/domain/inventory"
+	"warehouse-service/internal/domain/unit"
+	"warehouse-service/pkg/storage"
+)
+
+func (s *Service) GetUnits(ctx context.Context, productID string) (res unit.Response, err error) {
+	data, err := s.unitRepository.Get(ctx, productID)
+	if err != nil {
+		return
+	}
+	res = unit.ParseFromEntity(data)
+
+	return
+}
+
+// SaveUnits sets the unit of measure model of the product, the stored quantities
+// stay in whole base units so changing the base unit does not convert them.
+func (s *Service) SaveUnits(ctx context.Context, productID string, req unit.Request) (res unit.Response, err error) {
+	data := unit.Entity{
+		ProductID: productID,
+		BaseUnit:  req.BaseUnit,
+		Units:     make([]unit.Unit, 0, len(req.Units)),
+	}
+	for _, object := range req.Units {
+		data.Units = append(data.Units, unit.Unit{
+			ProductID: productID,
+			Code:      object.Code,
+			Factor:    object.Factor,
+		})
+	}
+
+	if err = s.unitRepository.Save(ctx, data); err != nil {
+		return
+	}
+
+	return s.GetUnits(ctx, productID)
+}
+
+func (s *Service) DeleteUnits(ctx context.Context, productID string) (err error) {
+	return s.unitRepository.Delete(ctx, productID)
+}
+
+// unitsOf returns the unit of measure model of the product, a product without one counts in plain whole units.
+func (s *Service) unitsOf(ctx context.Context, productID string) (data unit.Entity, err error) {
+	data, err = s.unitRepository.Get(ctx, productID)
+	if err == storage.ErrorNotFound {
+		return unit.Entity{ProductID: productID}, nil
+	}
+
+	return
+}
+
+// normalizeInventory converts the quantities of the request from its unit into whole base units.
+func (s *Service) normalizeInventory(ctx context.Context, productID string, req *inventory.Request) (err error) {
+	if req.Unit == "" {
+		return
+	}
+
+	unitData, err := s.unitsOf(ctx, productID)
+	if err != nil {
+		return
+	}
+
+	convert := func(value string) (string, error) {
+		quantity, err := decimal.NewFromString(value)
+		if err != nil {
+			return "", err
+		}
+
+		base, err := unitData.ToBase(quantity, req.Unit)
+		if err != nil {
+			return "", err
+		}
+
+		return strconv.Itoa(base), nil
+	}
+
+	if req.Quantity, err = convert(req.Quantity); err != nil {
+		return
+	}
+
+	if req.QuantityMin != nil {
+		value, err := convert(*req.QuantityMin)
+		if err != nil {
+			return err
+		}
+		req.QuantityMin = &value
+	}
+
+	if req.QuantityMax != nil {
+		value, err := convert(*req.QuantityMax)
+		if err != nil {
+			return err
+		}
+		req.QuantityMax = &value
+	}
+
+	return
+}
+
+// presentInventory shows the quantities of the response in the unit, the base
+// unit of the product when the code is blank. The pointers are replaced, not
+// written through, as they may be shared with the stored entity.
+func (s *Service) presentInventory(ctx context.Context, res *inventory.Response, code string) (err error) {
+	unitData, err := s.unitsOf(ctx, res.ProductID)
+	if err != nil {
+		return
+	}
+
+	return presentIn(res, unitData, code)
+}
+
+// presentIn is presentInventory with the unit of measure model of the product at hand.
+func presentIn(res *inventory.Response, unitData unit.Entity, code string) (err error) {
+	if code == "" {
+		code = unitData.BaseUnit
+	}
+
+	if code == "" {
+		return
+	}
+
+	convert := func(value string) (string, error) {
+		quantity, err := strconv.Atoi(value)
+		if err != nil {
+			return "", err
+		}
+
+		return unitData.FromBase(quantity, code)
+	}
+
+	if res.Quantity, err = convert(res.Quantity); err != nil {
+		return
+	}
+
+	if res.QuantityMin != nil {
+		value, err := convert(*res.QuantityMin)
+		if err != nil {
+			return err
+		}
+		res.QuantityMin = &value
+	}
+
+	if res.QuantityMax != nil {
+		value, err := convert(*res.QuantityMax)
+		if err != nil {
+			return err
+		}
+		res.QuantityMax = &value
+	}
+	res.Unit = code
+
+	return
+}
@@ -1,9 +1,17 @@
 package main
 
 import (
+	"os"
+	// the alpine image ships no time zone database
+	_ "time/tzdata"
+
 	"warehouse-service/internal/app"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		os.Exit(app.Import(os.Args[2:]))
+	}
+
 	app.Run()
 }
@@ -0,0 +1,14 @@
+BEGIN;
+    CREATE TABLE IF NOT EXISTS reservations (
+        created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        expires_at   TIMESTAMPTZ NOT NULL,
+        id           UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        inventory_id UUID NOT NULL,
+        quantity     INTEGER NOT NULL CHECK (quantity > 0),
+        status       VARCHAR NOT NULL DEFAULT 'held',
+        FOREIGN KEY (inventory_id) REFERENCES inventories (id) ON DELETE CASCADE
+    );
+
+    CREATE INDEX IF NOT EXISTS reservations_inventory_id_status_idx ON reservations (inventory_id, status);
+COMMIT;
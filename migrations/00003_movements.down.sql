@@ -0,0 +1,4 @@
+BEGIN;
+    DROP TABLE IF EXISTS movements CASCADE;
+    DROP FUNCTION IF EXISTS movements_append_only();
+END;
@@ -0,0 +1,27 @@
+BEGIN;
+    CREATE TABLE IF NOT EXISTS movements (
+        created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        seq            BIGSERIAL,
+        id             UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        inventory_id   UUID NOT NULL,
+        reason         VARCHAR NOT NULL,
+        delta          INTEGER NOT NULL,
+        balance        INTEGER NOT NULL,
+        actor          VARCHAR NOT NULL DEFAULT '',
+        correlation_id VARCHAR NOT NULL DEFAULT ''
+    );
+
+    CREATE INDEX IF NOT EXISTS movements_inventory_id_seq_idx ON movements (inventory_id, seq);
+
+    -- the journal outlives the inventory it describes and is never rewritten
+    CREATE OR REPLACE FUNCTION movements_append_only() RETURNS TRIGGER AS $$
+    BEGIN
+        RAISE EXCEPTION 'movements are append-only';
+    END;
+    $$ LANGUAGE plpgsql;
+
+    DROP TRIGGER IF EXISTS movements_append_only ON movements;
+    CREATE TRIGGER movements_append_only
+        BEFORE UPDATE OR DELETE ON movements
+        FOR EACH ROW EXECUTE FUNCTION movements_append_only();
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+    ALTER TABLE inventories DROP COLUMN IF EXISTS allow_backorder;
+END;
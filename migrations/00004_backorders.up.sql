@@ -0,0 +1,3 @@
+BEGIN;
+    ALTER TABLE inventories ADD COLUMN IF NOT EXISTS allow_backorder BOOLEAN NOT NULL DEFAULT FALSE;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+    ALTER TABLE inventories DROP CONSTRAINT IF EXISTS inventories_store_id_product_id_key;
+END;
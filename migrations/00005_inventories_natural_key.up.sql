@@ -0,0 +1,11 @@
+BEGIN;
+    -- keep the most recently updated row of every duplicated store/product pair
+    DELETE FROM inventories AS duplicate
+    USING inventories AS kept
+    WHERE duplicate.store_id = kept.store_id
+      AND duplicate.product_id = kept.product_id
+      AND (duplicate.updated_at, duplicate.id) < (kept.updated_at, kept.id);
+
+    ALTER TABLE inventories
+        ADD CONSTRAINT inventories_store_id_product_id_key UNIQUE (store_id, product_id);
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+    DROP INDEX IF EXISTS movements_created_at_idx;
+    DROP TABLE IF EXISTS alerts;
+END;
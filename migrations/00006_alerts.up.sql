@@ -0,0 +1,22 @@
+BEGIN;
+    CREATE TABLE IF NOT EXISTS alerts (
+        created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        resolved_at  TIMESTAMP,
+        id           UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        inventory_id UUID NOT NULL,
+        store_id     VARCHAR NOT NULL,
+        product_id   VARCHAR NOT NULL DEFAULT '',
+        kind         VARCHAR NOT NULL,
+        state        VARCHAR NOT NULL DEFAULT 'open',
+        quantity     INTEGER NOT NULL,
+        threshold    INTEGER NOT NULL
+    );
+
+    -- an inventory never has two unresolved alerts of the same kind
+    CREATE UNIQUE INDEX IF NOT EXISTS alerts_inventory_id_kind_active_idx ON alerts (inventory_id, kind) WHERE state <> 'resolved';
+    CREATE INDEX IF NOT EXISTS alerts_state_created_at_idx ON alerts (state, created_at);
+
+    -- the evaluator looks up the inventories moved since its last run
+    CREATE INDEX IF NOT EXISTS movements_created_at_idx ON movements (created_at);
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+    DROP TABLE IF EXISTS transfer_lines;
+    DROP TABLE IF EXISTS transfers;
+END;
@@ -0,0 +1,23 @@
+BEGIN;
+    CREATE TABLE IF NOT EXISTS transfers (
+        created_at           TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at           TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        shipped_at           TIMESTAMP,
+        id                   UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        source_store_id      VARCHAR NOT NULL,
+        destination_store_id VARCHAR NOT NULL,
+        status               VARCHAR NOT NULL DEFAULT 'created'
+    );
+
+    CREATE INDEX IF NOT EXISTS transfers_source_store_id_idx ON transfers (source_store_id);
+    CREATE INDEX IF NOT EXISTS transfers_destination_store_id_idx ON transfers (destination_store_id);
+
+    CREATE TABLE IF NOT EXISTS transfer_lines (
+        transfer_id UUID NOT NULL,
+        product_id  VARCHAR NOT NULL,
+        quantity    INTEGER NOT NULL CHECK (quantity > 0),
+        received    INTEGER NOT NULL DEFAULT 0 CHECK (received BETWEEN 0 AND quantity),
+        PRIMARY KEY (transfer_id, product_id),
+        FOREIGN KEY (transfer_id) REFERENCES transfers (id) ON DELETE CASCADE
+    );
+COMMIT;
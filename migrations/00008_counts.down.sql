@@ -0,0 +1,6 @@
+BEGIN;
+    ALTER TABLE inventories DROP COLUMN IF EXISTS count_id;
+    DROP TABLE IF EXISTS count_entries;
+    DROP TABLE IF EXISTS count_items;
+    DROP TABLE IF EXISTS counts;
+END;
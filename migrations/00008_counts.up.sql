@@ -0,0 +1,36 @@
+BEGIN;
+    CREATE TABLE IF NOT EXISTS counts (
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        closed_at  TIMESTAMP,
+        id         UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        store_id   VARCHAR NOT NULL,
+        status     VARCHAR NOT NULL DEFAULT 'open'
+    );
+
+    CREATE INDEX IF NOT EXISTS counts_store_id_idx ON counts (store_id);
+
+    CREATE TABLE IF NOT EXISTS count_items (
+        count_id     UUID NOT NULL,
+        inventory_id UUID NOT NULL,
+        product_id   VARCHAR NOT NULL DEFAULT '',
+        expected     INTEGER NOT NULL,
+        counted      INTEGER,
+        balance      INTEGER,
+        PRIMARY KEY (count_id, inventory_id),
+        FOREIGN KEY (count_id) REFERENCES counts (id) ON DELETE CASCADE
+    );
+
+    CREATE TABLE IF NOT EXISTS count_entries (
+        created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        count_id     UUID NOT NULL,
+        inventory_id UUID NOT NULL,
+        counter      VARCHAR NOT NULL DEFAULT '',
+        quantity     INTEGER NOT NULL CHECK (quantity >= 0),
+        PRIMARY KEY (count_id, inventory_id, counter),
+        FOREIGN KEY (count_id, inventory_id) REFERENCES count_items (count_id, inventory_id) ON DELETE CASCADE
+    );
+
+    -- the open session counting the inventory
+    ALTER TABLE inventories ADD COLUMN IF NOT EXISTS count_id UUID REFERENCES counts (id) ON DELETE SET NULL;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+    DROP TRIGGER IF EXISTS inventories_consume_lots ON inventories;
+    DROP FUNCTION IF EXISTS inventories_consume_lots();
+    DROP TABLE IF EXISTS lots;
+END;
@@ -0,0 +1,51 @@
+BEGIN;
+    CREATE TABLE IF NOT EXISTS lots (
+        created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        expires_at   DATE NOT NULL,
+        inventory_id UUID NOT NULL,
+        number       VARCHAR NOT NULL,
+        quantity     INTEGER NOT NULL CHECK (quantity > 0),
+        PRIMARY KEY (inventory_id, number),
+        FOREIGN KEY (inventory_id) REFERENCES inventories (id) ON DELETE CASCADE
+    );
+
+    CREATE INDEX IF NOT EXISTS lots_expires_at_idx ON lots (expires_at);
+
+    -- every decrement of the quantity, whatever makes it, leaves the lots first-expiry-first-out
+    CREATE OR REPLACE FUNCTION inventories_consume_lots() RETURNS TRIGGER AS $$
+    DECLARE
+        remaining INTEGER := OLD.quantity - NEW.quantity;
+        current   RECORD;
+    BEGIN
+        FOR current IN
+            SELECT number, quantity
+            FROM lots
+            WHERE inventory_id = NEW.id
+            ORDER BY expires_at, number
+            FOR UPDATE
+        LOOP
+            EXIT WHEN remaining <= 0;
+
+            IF current.quantity <= remaining THEN
+                DELETE FROM lots WHERE inventory_id = NEW.id AND number = current.number;
+                remaining := remaining - current.quantity;
+            ELSE
+                UPDATE lots
+                SET quantity = quantity - remaining, updated_at = CURRENT_TIMESTAMP
+                WHERE inventory_id = NEW.id AND number = current.number;
+                remaining := 0;
+            END IF;
+        END LOOP;
+
+        RETURN NEW;
+    END;
+    $$ LANGUAGE plpgsql;
+
+    DROP TRIGGER IF EXISTS inventories_consume_lots ON inventories;
+    CREATE TRIGGER inventories_consume_lots
+        AFTER UPDATE OF quantity ON inventories
+        FOR EACH ROW
+        WHEN (NEW.quantity < OLD.quantity)
+        EXECUTE FUNCTION inventories_consume_lots();
+COMMIT;
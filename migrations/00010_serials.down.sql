@@ -0,0 +1,6 @@
+BEGIN;
+    DROP TRIGGER IF EXISTS inventories_serialized_quantity ON inventories;
+    DROP FUNCTION IF EXISTS inventories_serialized_quantity();
+    DROP TABLE IF EXISTS serials;
+    ALTER TABLE inventories DROP COLUMN IF EXISTS is_serialized;
+END;
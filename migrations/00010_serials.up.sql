@@ -0,0 +1,36 @@
+BEGIN;
+    ALTER TABLE inventories ADD COLUMN IF NOT EXISTS is_serialized BOOLEAN NOT NULL DEFAULT FALSE;
+
+    CREATE TABLE IF NOT EXISTS serials (
+        created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        inventory_id UUID NOT NULL,
+        product_id   VARCHAR NOT NULL,
+        number       VARCHAR NOT NULL,
+        status       VARCHAR NOT NULL DEFAULT 'in_stock',
+        PRIMARY KEY (product_id, number),
+        FOREIGN KEY (inventory_id) REFERENCES inventories (id) ON DELETE CASCADE
+    );
+
+    CREATE INDEX IF NOT EXISTS serials_inventory_id_status_idx ON serials (inventory_id, status);
+    CREATE INDEX IF NOT EXISTS serials_number_idx ON serials (number);
+
+    -- the quantity of a serialized inventory is the number of its units in stock, nothing else may change it
+    CREATE OR REPLACE FUNCTION inventories_serialized_quantity() RETURNS TRIGGER AS $$
+    BEGIN
+        IF NEW.quantity <> (SELECT COUNT(*) FROM serials WHERE inventory_id = NEW.id AND status = 'in_stock') THEN
+            RAISE EXCEPTION 'the quantity of a serialized inventory follows its serial numbers'
+                USING ERRCODE = 'check_violation', CONSTRAINT = 'inventories_serialized_quantity';
+        END IF;
+
+        RETURN NEW;
+    END;
+    $$ LANGUAGE plpgsql;
+
+    DROP TRIGGER IF EXISTS inventories_serialized_quantity ON inventories;
+    CREATE TRIGGER inventories_serialized_quantity
+        BEFORE UPDATE OF quantity ON inventories
+        FOR EACH ROW
+        WHEN (NEW.is_serialized AND NEW.quantity IS DISTINCT FROM OLD.quantity)
+        EXECUTE FUNCTION inventories_serialized_quantity();
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+    DROP TRIGGER IF EXISTS inventories_unbin ON inventories;
+    DROP FUNCTION IF EXISTS inventories_unbin();
+    DROP TABLE IF EXISTS bin_stock;
+    DROP TABLE IF EXISTS locations;
+END;
@@ -0,0 +1,69 @@
+BEGIN;
+    CREATE TABLE IF NOT EXISTS locations (
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id         UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        store_id   UUID NOT NULL,
+        parent_id  UUID,
+        kind       VARCHAR NOT NULL,
+        code       VARCHAR NOT NULL,
+        name       VARCHAR,
+        FOREIGN KEY (store_id) REFERENCES stores (id) ON DELETE CASCADE,
+        FOREIGN KEY (parent_id) REFERENCES locations (id)
+    );
+
+    -- codes are unique among siblings, the zones being the children of the store
+    CREATE UNIQUE INDEX IF NOT EXISTS locations_code_key ON locations (store_id, COALESCE(parent_id, store_id), code);
+
+    CREATE TABLE IF NOT EXISTS bin_stock (
+        location_id  UUID NOT NULL,
+        inventory_id UUID NOT NULL,
+        quantity     INTEGER NOT NULL CHECK (quantity >= 0),
+        PRIMARY KEY (inventory_id, location_id),
+        FOREIGN KEY (location_id) REFERENCES locations (id) ON DELETE CASCADE,
+        FOREIGN KEY (inventory_id) REFERENCES inventories (id) ON DELETE CASCADE
+    );
+
+    CREATE INDEX IF NOT EXISTS bin_stock_location_id_idx ON bin_stock (location_id);
+
+    -- the bins never hold more than the quantity, a decrement not aimed at a bin
+    -- takes the unassigned stock first and then empties the bins holding the least
+    CREATE OR REPLACE FUNCTION inventories_unbin() RETURNS TRIGGER AS $$
+    DECLARE
+        excess  INTEGER;
+        current RECORD;
+    BEGIN
+        SELECT COALESCE(SUM(quantity), 0) - GREATEST(NEW.quantity, 0) INTO excess
+        FROM bin_stock
+        WHERE inventory_id = NEW.id;
+
+        FOR current IN
+            SELECT location_id, quantity
+            FROM bin_stock
+            WHERE inventory_id = NEW.id
+            ORDER BY quantity, location_id
+            FOR UPDATE
+        LOOP
+            EXIT WHEN excess <= 0;
+
+            IF current.quantity <= excess THEN
+                DELETE FROM bin_stock WHERE inventory_id = NEW.id AND location_id = current.location_id;
+                excess := excess - current.quantity;
+            ELSE
+                UPDATE bin_stock
+                SET quantity = quantity - excess
+                WHERE inventory_id = NEW.id AND location_id = current.location_id;
+                excess := 0;
+            END IF;
+        END LOOP;
+
+        RETURN NEW;
+    END;
+    $$ LANGUAGE plpgsql;
+
+    DROP TRIGGER IF EXISTS inventories_unbin ON inventories;
+    CREATE TRIGGER inventories_unbin
+        AFTER UPDATE OF quantity ON inventories
+        FOR EACH ROW
+        WHEN (NEW.quantity < OLD.quantity)
+        EXECUTE FUNCTION inventories_unbin();
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+    DROP TABLE IF EXISTS unit_conversions;
+    DROP TABLE IF EXISTS units;
+END;
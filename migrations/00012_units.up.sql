@@ -0,0 +1,16 @@
+BEGIN;
+    CREATE TABLE IF NOT EXISTS units (
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        product_id VARCHAR PRIMARY KEY,
+        base_unit  VARCHAR NOT NULL
+    );
+
+    CREATE TABLE IF NOT EXISTS unit_conversions (
+        product_id VARCHAR NOT NULL,
+        code       VARCHAR NOT NULL,
+        factor     NUMERIC NOT NULL CHECK (factor > 0),
+        PRIMARY KEY (product_id, code),
+        FOREIGN KEY (product_id) REFERENCES units (product_id) ON DELETE CASCADE
+    );
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+    CREATE TABLE IF NOT EXISTS prices (
+        created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        valid_from    TIMESTAMPTZ NOT NULL,
+        valid_to      TIMESTAMPTZ CHECK (valid_to > valid_from),
+        id            UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        inventory_id  UUID NOT NULL,
+        price         NUMERIC NOT NULL,
+        price_special NUMERIC,
+        FOREIGN KEY (inventory_id) REFERENCES inventories (id) ON DELETE CASCADE
+    );
+
+    CREATE INDEX IF NOT EXISTS prices_inventory_id_idx ON prices (inventory_id, valid_from);
+    CREATE INDEX IF NOT EXISTS prices_valid_from_idx ON prices (valid_from);
+    CREATE INDEX IF NOT EXISTS prices_valid_to_idx ON prices (valid_to);
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+    DROP TABLE IF EXISTS exchange_rates;
+    ALTER TABLE currencies DROP COLUMN IF EXISTS code;
+END;
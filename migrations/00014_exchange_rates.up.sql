@@ -0,0 +1,14 @@
+BEGIN;
+    ALTER TABLE currencies ADD COLUMN IF NOT EXISTS code VARCHAR(3) UNIQUE CHECK (code ~ '^[A-Z]{3}$');
+
+    UPDATE currencies SET code='KZT' WHERE sign='₸' AND code IS NULL;
+
+    CREATE TABLE IF NOT EXISTS exchange_rates (
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        valid_from TIMESTAMPTZ NOT NULL,
+        base       VARCHAR(3) NOT NULL,
+        quote      VARCHAR(3) NOT NULL CHECK (quote <> base),
+        rate       NUMERIC NOT NULL CHECK (rate > 0),
+        PRIMARY KEY (base, quote, valid_from)
+    );
+COMMIT;
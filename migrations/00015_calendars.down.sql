@@ -0,0 +1,4 @@
+BEGIN;
+    DROP TABLE IF EXISTS schedule_exceptions;
+    DROP TABLE IF EXISTS schedule_seasons;
+END;
@@ -0,0 +1,26 @@
+BEGIN;
+    CREATE TABLE IF NOT EXISTS schedule_seasons (
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id         UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        store_id   UUID NOT NULL,
+        name       VARCHAR,
+        valid_from DATE NOT NULL,
+        valid_to   DATE CHECK (valid_to >= valid_from),
+        periods    JSONB NOT NULL DEFAULT '[]',
+        FOREIGN KEY (store_id) REFERENCES stores (id) ON DELETE CASCADE
+    );
+
+    CREATE INDEX IF NOT EXISTS schedule_seasons_store_id_idx ON schedule_seasons (store_id, valid_from);
+
+    CREATE TABLE IF NOT EXISTS schedule_exceptions (
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        store_id   UUID NOT NULL,
+        date       DATE NOT NULL,
+        closed     BOOLEAN NOT NULL DEFAULT FALSE,
+        hours      JSONB NOT NULL DEFAULT '[]',
+        note       VARCHAR,
+        PRIMARY KEY (store_id, date),
+        FOREIGN KEY (store_id) REFERENCES stores (id) ON DELETE CASCADE
+    );
+COMMIT;
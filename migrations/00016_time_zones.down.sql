@@ -0,0 +1,4 @@
+BEGIN;
+    ALTER TABLE cities DROP COLUMN IF EXISTS time_zone;
+    ALTER TABLE countries DROP COLUMN IF EXISTS time_zone;
+END;
@@ -0,0 +1,9 @@
+BEGIN;
+    ALTER TABLE countries ADD COLUMN IF NOT EXISTS time_zone VARCHAR;
+
+    UPDATE countries SET time_zone='Asia/Almaty' WHERE time_zone IS NULL;
+
+    ALTER TABLE countries ALTER COLUMN time_zone SET NOT NULL;
+
+    ALTER TABLE cities ADD COLUMN IF NOT EXISTS time_zone VARCHAR;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+    UPDATE deliveries SET areas=COALESCE(areas->0, '[]'::jsonb) WHERE jsonb_typeof(areas->0)='array';
+END;
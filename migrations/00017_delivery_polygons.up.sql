@@ -0,0 +1,4 @@
+BEGIN;
+    -- a flat list of points becomes the single polygon of the store
+    UPDATE deliveries SET areas=jsonb_build_array(areas) WHERE jsonb_typeof(areas->0)='object';
+COMMIT;
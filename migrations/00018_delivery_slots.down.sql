@@ -0,0 +1,6 @@
+BEGIN;
+    DROP TABLE IF EXISTS delivery_bookings;
+    DROP TABLE IF EXISTS delivery_slots;
+    ALTER TABLE deliveries DROP COLUMN IF EXISTS slot_capacity;
+    ALTER TABLE deliveries DROP COLUMN IF EXISTS slot_minutes;
+END;
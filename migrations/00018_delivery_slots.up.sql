@@ -0,0 +1,22 @@
+BEGIN;
+    ALTER TABLE deliveries ADD COLUMN IF NOT EXISTS slot_minutes INTEGER NOT NULL DEFAULT 60 CHECK (slot_minutes > 0 AND slot_minutes <= 1440);
+    ALTER TABLE deliveries ADD COLUMN IF NOT EXISTS slot_capacity INTEGER NOT NULL DEFAULT 10 CHECK (slot_capacity > 0);
+
+    CREATE TABLE IF NOT EXISTS delivery_slots (
+        store_id   UUID NOT NULL,
+        starts_at  TIMESTAMPTZ NOT NULL,
+        booked     INTEGER NOT NULL DEFAULT 0 CHECK (booked >= 0),
+        PRIMARY KEY (store_id, starts_at),
+        FOREIGN KEY (store_id) REFERENCES stores (id) ON DELETE CASCADE
+    );
+
+    CREATE TABLE IF NOT EXISTS delivery_bookings (
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        id         UUID PRIMARY KEY DEFAULT GEN_RANDOM_UUID(),
+        store_id   UUID NOT NULL,
+        starts_at  TIMESTAMPTZ NOT NULL,
+        ends_at    TIMESTAMPTZ NOT NULL,
+        reference  VARCHAR,
+        FOREIGN KEY (store_id, starts_at) REFERENCES delivery_slots (store_id, starts_at) ON DELETE CASCADE
+    );
+COMMIT;
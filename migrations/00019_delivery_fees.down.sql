@@ -0,0 +1,3 @@
+BEGIN;
+    ALTER TABLE deliveries DROP COLUMN IF EXISTS fees;
+END;
@@ -0,0 +1,3 @@
+BEGIN;
+    ALTER TABLE deliveries ADD COLUMN IF NOT EXISTS fees JSONB;
+COMMIT;
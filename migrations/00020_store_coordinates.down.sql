@@ -0,0 +1,5 @@
+BEGIN;
+    ALTER TABLE stores DROP CONSTRAINT IF EXISTS stores_coordinates_check;
+    ALTER TABLE stores DROP COLUMN IF EXISTS longitude;
+    ALTER TABLE stores DROP COLUMN IF EXISTS latitude;
+END;
@@ -0,0 +1,13 @@
+BEGIN;
+    ALTER TABLE stores ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90);
+    ALTER TABLE stores ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180);
+    ALTER TABLE stores ADD CONSTRAINT stores_coordinates_check CHECK ((latitude IS NULL) = (longitude IS NULL));
+
+    -- locations written as "latitude, longitude" carry over, the rest are left without coordinates
+    UPDATE stores
+    SET latitude=TRIM(SPLIT_PART(location, ',', 1))::DOUBLE PRECISION,
+        longitude=TRIM(SPLIT_PART(location, ',', 2))::DOUBLE PRECISION
+    WHERE location ~ '^\s*-?[0-9]{1,2}(\.[0-9]+)?\s*,\s*-?[0-9]{1,3}(\.[0-9]+)?\s*$'
+        AND ABS(TRIM(SPLIT_PART(location, ',', 1))::DOUBLE PRECISION) <= 90
+        AND ABS(TRIM(SPLIT_PART(location, ',', 2))::DOUBLE PRECISION) <= 180;
+COMMIT;
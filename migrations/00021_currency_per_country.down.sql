@@ -0,0 +1,3 @@
+BEGIN;
+    DROP INDEX IF EXISTS currencies_country_id_key;
+END;
@@ -0,0 +1,14 @@
+BEGIN;
+    -- a country keeps one currency: the oldest one with a code, or else the oldest one
+    DELETE FROM currencies
+    WHERE id IN (
+        SELECT id
+        FROM (
+            SELECT id, ROW_NUMBER() OVER (PARTITION BY country_id ORDER BY code IS NULL, created_at, id) AS position
+            FROM currencies
+        ) ranked
+        WHERE position > 1
+    );
+
+    CREATE UNIQUE INDEX IF NOT EXISTS currencies_country_id_key ON currencies (country_id);
+COMMIT;
@@ -0,0 +1,175 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// earthRadius is the mean radius of the Earth in kilometres.
+const earthRadius = 6371.0088
+
+// Point is a position in degrees.
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// Validate checks the point lies within the ranges of latitude and longitude.
+func (p Point) Validate() error {
+	if p.Lat < -90 || p.Lat > 90 {
+		return errors.New("latitude: must be between -90 and 90")
+	}
+
+	if p.Lng < -180 || p.Lng > 180 {
+		return errors.New("longitude: must be between -180 and 180")
+	}
+
+	return nil
+}
+
+// Distance returns the great-circle distance between the points in kilometres.
+func Distance(a, b Point) float64 {
+	radians := func(degrees float64) float64 {
+		return degrees * math.Pi / 180
+	}
+
+	dLat := radians(b.Lat - a.Lat)
+	dLng := radians(b.Lng - a.Lng)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(radians(a.Lat))*math.Cos(radians(b.Lat))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Polygon is a closed ring, its last point repeats the first one. The degrees are
+// taken as plane coordinates, which holds for areas the size of a city.
+type Polygon []Point
+
+// Validate checks the ring is closed, has at least three corners, and no two of
+// its edges cross or touch except adjacent edges at their shared corner.
+func (p Polygon) Validate() error {
+	if len(p) < 4 {
+		return errors.New("must have at least three points and repeat the first one last")
+	}
+
+	if p[0] != p[len(p)-1] {
+		return errors.New("must be closed, the last point repeating the first one")
+	}
+
+	for i, object := range p {
+		if err := object.Validate(); err != nil {
+			return fmt.Errorf("point %d: %w", i, err)
+		}
+	}
+
+	edges := len(p) - 1
+	for i := 0; i < edges; i++ {
+		if p[i] == p[i+1] {
+			return fmt.Errorf("point %d: repeats the point before it", i+1)
+		}
+
+		for j := i + 1; j < edges; j++ {
+			adjacent := j == i+1 || i == 0 && j == edges-1
+			if adjacent {
+				// adjacent edges share a corner, they only fail by folding back over each other
+				if collinearOverlap(p[i], p[i+1], p[j], p[j+1]) {
+					return fmt.Errorf("edges %d and %d overlap", i, j)
+				}
+				continue
+			}
+
+			if intersects(p[i], p[i+1], p[j], p[j+1]) {
+				return fmt.Errorf("edges %d and %d cross", i, j)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Contains tells whether the point lies inside the ring or on its border.
+func (p Polygon) Contains(point Point) bool {
+	inside := false
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		a, b := p[i], p[j]
+		if onSegment(a, b, point) {
+			return true
+		}
+
+		// count the edges a ray running east from the point crosses
+		if (a.Lat > point.Lat) != (b.Lat > point.Lat) {
+			lng := a.Lng + (point.Lat-a.Lat)*(b.Lng-a.Lng)/(b.Lat-a.Lat)
+			if point.Lng < lng {
+				inside = !inside
+			}
+		}
+	}
+
+	return inside
+}
+
+// orientation is positive when c lies left of the line from a to b, negative
+// when it lies right and zero when the three are on one line.
+func orientation(a, b, c Point) float64 {
+	return (b.Lng-a.Lng)*(c.Lat-a.Lat) - (b.Lat-a.Lat)*(c.Lng-a.Lng)
+}
+
+// onSegment tells whether c lies on the segment from a to b.
+func onSegment(a, b, c Point) bool {
+	if orientation(a, b, c) != 0 {
+		return false
+	}
+
+	return c.Lat >= minFloat(a.Lat, b.Lat) && c.Lat <= maxFloat(a.Lat, b.Lat) &&
+		c.Lng >= minFloat(a.Lng, b.Lng) && c.Lng <= maxFloat(a.Lng, b.Lng)
+}
+
+// intersects tells whether the segments from a to b and from c to d have a point in common.
+func intersects(a, b, c, d Point) bool {
+	o1, o2 := orientation(a, b, c), orientation(a, b, d)
+	o3, o4 := orientation(c, d, a), orientation(c, d, b)
+
+	if (o1 > 0 && o2 < 0 || o1 < 0 && o2 > 0) && (o3 > 0 && o4 < 0 || o3 < 0 && o4 > 0) {
+		return true
+	}
+
+	return onSegment(a, b, c) || onSegment(a, b, d) || onSegment(c, d, a) || onSegment(c, d, b)
+}
+
+// collinearOverlap tells whether the adjacent segments from a to b and from c to d,
+// sharing one end, lie on one line and run over each other.
+func collinearOverlap(a, b, c, d Point) bool {
+	if orientation(a, b, c) != 0 || orientation(a, b, d) != 0 {
+		return false
+	}
+
+	// the ends the segments do not share
+	var shared, x, y Point
+	switch {
+	case b == c:
+		shared, x, y = b, a, d
+	case a == d:
+		shared, x, y = a, b, c
+	default:
+		return false
+	}
+
+	// both run the same way from the shared corner
+	return (x.Lat-shared.Lat)*(y.Lat-shared.Lat)+(x.Lng-shared.Lng)*(y.Lng-shared.Lng) > 0
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,71 @@
+package geo
+
+import "testing"
+
+// ring builds a polygon from lat, lng pairs.
+func ring(coordinates ...float64) (dest Polygon) {
+	for i := 0; i+1 < len(coordinates); i += 2 {
+		dest = append(dest, Point{Lat: coordinates[i], Lng: coordinates[i+1]})
+	}
+	return
+}
+
+func TestPolygonValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		polygon Polygon
+		valid   bool
+	}{
+		{"square", ring(0, 0, 0, 1, 1, 1, 1, 0, 0, 0), true},
+		{"triangle", ring(0, 0, 0, 1, 1, 0, 0, 0), true},
+		{"concave", ring(0, 0, 0, 2, 2, 2, 1, 1, 2, 0, 0, 0), true},
+		{"too few points", ring(0, 0, 0, 1, 0, 0), false},
+		{"not closed", ring(0, 0, 0, 1, 1, 1, 1, 0), false},
+		{"latitude out of range", ring(0, 0, 0, 1, 91, 1, 0, 0), false},
+		{"longitude out of range", ring(0, 0, 0, 181, 1, 1, 0, 0), false},
+		{"repeated point", ring(0, 0, 0, 1, 0, 1, 1, 1, 0, 0), false},
+		{"bow tie", ring(0, 0, 1, 1, 0, 1, 1, 0, 0, 0), false},
+		{"edge folding back", ring(0, 0, 0, 2, 0, 1, 1, 1, 0, 0), false},
+		{"corner touching an edge", ring(0, 0, 0, 2, 2, 2, 0, 1, 2, 0, 0, 0), false},
+		{"all points on a line", ring(0, 0, 0, 1, 0, 2, 0, 0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.polygon.Validate()
+			if tt.valid && err != nil {
+				t.Fatalf("want valid, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Fatal("want an error, got none")
+			}
+		})
+	}
+}
+
+func TestPolygonContains(t *testing.T) {
+	square := ring(0, 0, 0, 2, 2, 2, 2, 0, 0, 0)
+	notch := ring(0, 0, 0, 2, 2, 2, 1, 1, 2, 0, 0, 0)
+
+	tests := []struct {
+		name    string
+		polygon Polygon
+		point   Point
+		inside  bool
+	}{
+		{"inside", square, Point{Lat: 1, Lng: 1}, true},
+		{"outside", square, Point{Lat: 3, Lng: 1}, false},
+		{"on an edge", square, Point{Lat: 0, Lng: 1}, true},
+		{"on a corner", square, Point{Lat: 2, Lng: 2}, true},
+		{"in the notch of a concave ring", notch, Point{Lat: 1.5, Lng: 1}, false},
+		{"beside the notch", notch, Point{Lat: 1.5, Lng: 0.2}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if inside := tt.polygon.Contains(tt.point); inside != tt.inside {
+				t.Fatalf("contains is %v, want %v", inside, tt.inside)
+			}
+		})
+	}
+}
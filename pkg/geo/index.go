@@ -0,0 +1,150 @@
+package geo
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+const (
+	// cellDegrees is the side of a grid cell, about 5.5 km of latitude.
+	cellDegrees = 0.05
+
+	// kmPerDegree is the length of a degree of latitude.
+	kmPerDegree = 111.32
+
+	// maxCells is how many cells a search looks through before scanning every point instead.
+	maxCells = 4096
+)
+
+// Hit is a point found by a search, Distance is in kilometres from the centre.
+type Hit struct {
+	ID       string
+	Point    Point
+	Distance float64
+}
+
+type cell struct {
+	lat int
+	lng int
+}
+
+// Index finds the points within a distance of a centre without measuring the
+// distance to each of them, keeping the points on a grid of cells. It is safe
+// for concurrent use.
+type Index struct {
+	points map[string]Point
+	cells  map[cell]map[string]Point
+	sync.RWMutex
+}
+
+func NewIndex() *Index {
+	return &Index{
+		points: make(map[string]Point),
+		cells:  make(map[cell]map[string]Point),
+	}
+}
+
+// Reset replaces every point of the index.
+func (x *Index) Reset(points map[string]Point) {
+	x.Lock()
+	defer x.Unlock()
+
+	x.points = make(map[string]Point, len(points))
+	x.cells = make(map[cell]map[string]Point)
+	for id, point := range points {
+		x.put(id, point)
+	}
+}
+
+// Put adds the point with the id, moving it when the id is already in the index.
+func (x *Index) Put(id string, point Point) {
+	x.Lock()
+	defer x.Unlock()
+
+	x.remove(id)
+	x.put(id, point)
+}
+
+// Remove takes the point with the id out of the index.
+func (x *Index) Remove(id string) {
+	x.Lock()
+	defer x.Unlock()
+
+	x.remove(id)
+}
+
+// Within returns the points at most radius kilometres from the centre, nearest first.
+func (x *Index) Within(center Point, radius float64) (dest []Hit) {
+	x.RLock()
+	defer x.RUnlock()
+
+	dest = make([]Hit, 0)
+	collect := func(points map[string]Point) {
+		for id, point := range points {
+			if distance := Distance(center, point); distance <= radius {
+				dest = append(dest, Hit{ID: id, Point: point, Distance: distance})
+			}
+		}
+	}
+
+	latSpan := radius / kmPerDegree
+	lngSpan := 360.0
+	if cos := math.Cos(center.Lat * math.Pi / 180); cos > 0.01 {
+		lngSpan = latSpan / cos
+	}
+
+	from, to := cellOf(Point{Lat: center.Lat - latSpan, Lng: center.Lng - lngSpan}), cellOf(Point{Lat: center.Lat + latSpan, Lng: center.Lng + lngSpan})
+	cells := (to.lat - from.lat + 1) * (to.lng - from.lng + 1)
+
+	// near the poles, across the antimeridian or for a wide radius every point is measured
+	if lngSpan >= 180 || center.Lng-lngSpan < -180 || center.Lng+lngSpan > 180 || cells > maxCells {
+		collect(x.points)
+	} else {
+		for lat := from.lat; lat <= to.lat; lat++ {
+			for lng := from.lng; lng <= to.lng; lng++ {
+				collect(x.cells[cell{lat: lat, lng: lng}])
+			}
+		}
+	}
+
+	sort.Slice(dest, func(i, j int) bool {
+		if dest[i].Distance != dest[j].Distance {
+			return dest[i].Distance < dest[j].Distance
+		}
+		return dest[i].ID < dest[j].ID
+	})
+
+	return
+}
+
+func (x *Index) put(id string, point Point) {
+	x.points[id] = point
+
+	key := cellOf(point)
+	if x.cells[key] == nil {
+		x.cells[key] = make(map[string]Point)
+	}
+	x.cells[key][id] = point
+}
+
+func (x *Index) remove(id string) {
+	point, ok := x.points[id]
+	if !ok {
+		return
+	}
+	delete(x.points, id)
+
+	key := cellOf(point)
+	delete(x.cells[key], id)
+	if len(x.cells[key]) == 0 {
+		delete(x.cells, key)
+	}
+}
+
+func cellOf(point Point) cell {
+	return cell{
+		lat: int(math.Floor(point.Lat / cellDegrees)),
+		lng: int(math.Floor(point.Lng / cellDegrees)),
+	}
+}
@@ -0,0 +1,157 @@
+package geo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func hitIDs(hits []Hit) []string {
+	dest := make([]string, len(hits))
+	for i, hit := range hits {
+		dest[i] = hit.ID
+	}
+	return dest
+}
+
+func TestIndexWithin(t *testing.T) {
+	tests := []struct {
+		name   string
+		points map[string]Point
+		center Point
+		radius float64
+		want   []string
+	}{
+		{
+			name:   "empty index",
+			points: map[string]Point{},
+			center: Point{Lat: 0, Lng: 0},
+			radius: 10,
+			want:   []string{},
+		},
+		{
+			name: "nearest first",
+			points: map[string]Point{
+				"far":  {Lat: 0, Lng: 0.03},
+				"near": {Lat: 0, Lng: 0.01},
+				"mid":  {Lat: 0.02, Lng: 0},
+			},
+			center: Point{Lat: 0, Lng: 0},
+			radius: 5,
+			want:   []string{"near", "mid", "far"},
+		},
+		{
+			name: "points beyond the radius are left out",
+			points: map[string]Point{
+				"inside":  {Lat: 0, Lng: 0.01},
+				"outside": {Lat: 0, Lng: 0.1},
+			},
+			center: Point{Lat: 0, Lng: 0},
+			radius: 5,
+			want:   []string{"inside"},
+		},
+		{
+			name: "same distance ordered by id",
+			points: map[string]Point{
+				"b": {Lat: 0, Lng: 0.01},
+				"a": {Lat: 0, Lng: -0.01},
+				"c": {Lat: 0.01, Lng: 0},
+			},
+			center: Point{Lat: 0, Lng: 0},
+			radius: 5,
+			want:   []string{"a", "b", "c"},
+		},
+		{
+			name: "points in the neighbouring cells",
+			points: map[string]Point{
+				"south": {Lat: -0.001, Lng: 0.049},
+				"east":  {Lat: 0.001, Lng: 0.051},
+			},
+			center: Point{Lat: 0.001, Lng: 0.049},
+			radius: 1,
+			want:   []string{"east", "south"},
+		},
+		{
+			name: "wide radius",
+			points: map[string]Point{
+				"almaty": {Lat: 43.24, Lng: 76.89},
+				"astana": {Lat: 51.17, Lng: 71.45},
+				"london": {Lat: 51.51, Lng: -0.13},
+			},
+			center: Point{Lat: 43.24, Lng: 76.89},
+			radius: 2000,
+			want:   []string{"almaty", "astana"},
+		},
+		{
+			name: "across the antimeridian",
+			points: map[string]Point{
+				"west": {Lat: 0, Lng: -179.99},
+				"east": {Lat: 0, Lng: 179.98},
+			},
+			center: Point{Lat: 0, Lng: 179.995},
+			radius: 5,
+			want:   []string{"west", "east"},
+		},
+		{
+			name: "near the pole",
+			points: map[string]Point{
+				"across": {Lat: 89.999, Lng: 180},
+			},
+			center: Point{Lat: 89.999, Lng: 0},
+			radius: 1,
+			want:   []string{"across"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index := NewIndex()
+			index.Reset(tt.points)
+
+			hits := index.Within(tt.center, tt.radius)
+			if got := hitIDs(hits); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("hits are %v, want %v", got, tt.want)
+			}
+
+			for _, hit := range hits {
+				if hit.Distance > tt.radius {
+					t.Errorf("%s is %.3f km away, beyond %.3f", hit.ID, hit.Distance, tt.radius)
+				}
+			}
+		})
+	}
+}
+
+func TestIndexPutRemove(t *testing.T) {
+	center := Point{Lat: 43.24, Lng: 76.89}
+
+	index := NewIndex()
+	index.Put("store", Point{Lat: 43.25, Lng: 76.9})
+	index.Put("other", Point{Lat: 43.24, Lng: 76.88})
+
+	if got := hitIDs(index.Within(center, 5)); !reflect.DeepEqual(got, []string{"other", "store"}) {
+		t.Fatalf("hits are %v, want both points", got)
+	}
+
+	// moving the point to another cell leaves nothing at the old place
+	index.Put("store", Point{Lat: 44, Lng: 77})
+	if got := hitIDs(index.Within(center, 5)); !reflect.DeepEqual(got, []string{"other"}) {
+		t.Fatalf("hits are %v, want the store moved away", got)
+	}
+	if got := hitIDs(index.Within(Point{Lat: 44, Lng: 77}, 1)); !reflect.DeepEqual(got, []string{"store"}) {
+		t.Fatalf("hits are %v, want the store at its new place", got)
+	}
+
+	index.Remove("other")
+	index.Remove("missing")
+	if got := hitIDs(index.Within(center, 5)); len(got) != 0 {
+		t.Fatalf("hits are %v, want none", got)
+	}
+
+	index.Reset(map[string]Point{"fresh": center})
+	if got := hitIDs(index.Within(Point{Lat: 44, Lng: 77}, 1)); len(got) != 0 {
+		t.Fatalf("hits are %v, want the points before the reset gone", got)
+	}
+	if got := hitIDs(index.Within(center, 1)); !reflect.DeepEqual(got, []string{"fresh"}) {
+		t.Fatalf("hits are %v, want the point after the reset", got)
+	}
+}
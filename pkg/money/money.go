@@ -0,0 +1,111 @@
+package money
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	nbsp       = "\u00a0"
+	narrowNbsp = "\u202f"
+)
+
+// Currency is how the amounts of a currency are written.
+type Currency struct {
+	Sign     string
+	Decimals int32
+	Prefix   bool
+}
+
+// Locale is how a language separates the groups of thousands and the decimals,
+// the zero value writes numbers the English way.
+type Locale struct {
+	Group   string
+	Decimal string
+}
+
+// English writes 1,234.50.
+var English = Locale{Group: ",", Decimal: "."}
+
+var locales = map[string]Locale{
+	"en": English,
+	"zh": English,
+	"ja": English,
+	"ko": English,
+	"kk": {Group: nbsp, Decimal: ","},
+	"ru": {Group: nbsp, Decimal: ","},
+	"ky": {Group: nbsp, Decimal: ","},
+	"uz": {Group: nbsp, Decimal: ","},
+	"uk": {Group: nbsp, Decimal: ","},
+	"pl": {Group: nbsp, Decimal: ","},
+	"fr": {Group: narrowNbsp, Decimal: ","},
+	"de": {Group: ".", Decimal: ","},
+	"tr": {Group: ".", Decimal: ","},
+	"es": {Group: ".", Decimal: ","},
+	"it": {Group: ".", Decimal: ","},
+}
+
+// ParseLocale picks the locale of the most preferred known language of an
+// Accept-Language header, the zero value when none is known.
+func ParseLocale(header string) (dest Locale) {
+	best := 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+
+		weight := 1.0
+		if params = strings.TrimSpace(params); strings.HasPrefix(params, "q=") {
+			var err error
+			if weight, err = strconv.ParseFloat(strings.TrimPrefix(params, "q="), 64); err != nil {
+				continue
+			}
+		}
+
+		language, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		locale, ok := locales[language]
+		if ok && weight > best {
+			dest, best = locale, weight
+		}
+	}
+	return
+}
+
+// Format rounds the amount half away from zero to the decimals of the currency,
+// groups the thousands the way the locale does and places the sign before the
+// amount or after it, a negative amount starts with a minus either way.
+func Format(amount decimal.Decimal, currency Currency, locale Locale) string {
+	if locale == (Locale{}) {
+		locale = English
+	}
+
+	amount = amount.Round(currency.Decimals)
+	whole, fraction, _ := strings.Cut(amount.Abs().StringFixed(currency.Decimals), ".")
+
+	var number strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			number.WriteString(locale.Group)
+		}
+		number.WriteRune(digit)
+	}
+
+	if fraction != "" {
+		number.WriteString(locale.Decimal)
+		number.WriteString(fraction)
+	}
+
+	minus := ""
+	if amount.IsNegative() {
+		minus = "-"
+	}
+
+	if currency.Sign == "" {
+		return minus + number.String()
+	}
+
+	if currency.Prefix {
+		return minus + currency.Sign + number.String()
+	}
+	return minus + number.String() + nbsp + currency.Sign
+}
@@ -52,6 +52,16 @@ func NotFound(w http.ResponseWriter, r *http.Request, err error) {
 	render.JSON(w, r, v)
 }
 
+func Conflict(w http.ResponseWriter, r *http.Request, err error) {
+	render.Status(r, http.StatusConflict)
+
+	v := Object{
+		Success: false,
+		Message: err.Error(),
+	}
+	render.JSON(w, r, v)
+}
+
 func InternalServerError(w http.ResponseWriter, r *http.Request, err error) {
 	render.Status(r, http.StatusInternalServerError)
 
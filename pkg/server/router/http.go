@@ -27,7 +27,7 @@ func New() *chi.Mux {
 
 	r.Use(middleware.Timeout(time.Second * 60))
 
-	r.Use(middleware.AllowContentType("application/json"))
+	r.Use(middleware.AllowContentType("application/json", "text/csv"))
 
 	r.Use(render.SetContentType(render.ContentTypeJSON))
 